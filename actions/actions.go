@@ -0,0 +1,129 @@
+// Package actions builds EOSIO actions for the atomicassets contracts.
+//
+// The builders validate their input against the data fetched from the
+// API (for example that an asset is transferable or that an account is
+// authorized by a collection) and return an eosio.Action with both the
+// json and the serialized form of the action data.
+package actions
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/eosswedenorg-go/atomicasset"
+	"github.com/eosswedenorg-go/atomicasset/eosio"
+)
+
+// DefaultPermission is used when a builder has no permission set.
+const DefaultPermission = "active"
+
+// Uint64s is a vector of uint64 encoded as strings in json.
+type Uint64s []string
+
+func (v Uint64s) Pack(e *eosio.Encoder) {
+	e.Length(len(v))
+	for _, s := range v {
+		packUint64(e, s)
+	}
+}
+
+// packUint64 writes a uint64 given as a decimal string (asset and offer ids).
+func packUint64(e *eosio.Encoder, s string) {
+	n, err := strconv.ParseUint(s, 10, 64)
+	if err != nil {
+		e.SetError(fmt.Errorf("invalid id '%s'", s))
+		return
+	}
+	e.Uint64(n)
+}
+
+// Formats is a vector of FORMAT (schema attributes).
+type Formats []atomicasset.SchemaFormat
+
+func (v Formats) Pack(e *eosio.Encoder) {
+	e.Length(len(v))
+	for _, f := range v {
+		e.String(f.Name)
+		e.String(f.Type)
+	}
+}
+
+// Quantity is an EOSIO asset (amount and symbol).
+type Quantity atomicasset.Token
+
+func (q Quantity) Pack(e *eosio.Encoder) {
+	units, err := atomicasset.Token(q).Units()
+	if err != nil {
+		e.SetError(err)
+		return
+	}
+	e.Asset(units, q.Precision, q.Symbol)
+}
+
+// MarshalJSON encodes the quantity as an asset string ("1.50000000 WAX").
+func (q Quantity) MarshalJSON() ([]byte, error) {
+	if _, err := atomicasset.Token(q).Units(); err != nil {
+		return nil, err
+	}
+	return json.Marshal(atomicasset.Token(q).String())
+}
+
+// Tokens is a vector of EOSIO assets.
+type Tokens []atomicasset.Token
+
+func (v Tokens) Pack(e *eosio.Encoder) {
+	e.Length(len(v))
+	for _, t := range v {
+		Quantity(t).Pack(e)
+	}
+}
+
+func (v Tokens) MarshalJSON() ([]byte, error) {
+	out := []Quantity{}
+	for _, t := range v {
+		out = append(out, Quantity(t))
+	}
+	return json.Marshal(out)
+}
+
+// Symbol is an EOSIO symbol (precision and code).
+type Symbol struct {
+	Precision int
+	Code      string
+}
+
+func (s Symbol) Pack(e *eosio.Encoder) {
+	e.Symbol(s.Precision, s.Code)
+}
+
+// MarshalJSON encodes the symbol as "precision,code" ("8,WAX").
+func (s Symbol) MarshalJSON() ([]byte, error) {
+	return json.Marshal(strconv.Itoa(s.Precision) + "," + s.Code)
+}
+
+// builder holds the settings shared by the contract builders.
+type builder struct {
+	contract   string
+	permission string
+}
+
+func (b builder) action(name string, actor string, data eosio.Packer) (eosio.Action, error) {
+	permission := b.permission
+	if len(permission) < 1 {
+		permission = DefaultPermission
+	}
+
+	auth := []eosio.PermissionLevel{{Actor: actor, Permission: permission}}
+	return eosio.NewAction(b.contract, name, auth, data)
+}
+
+// authorized returns an error if account is not an authorized account of the collection.
+func authorized(c atomicasset.Collection, account string) error {
+	for _, a := range c.AuthorizedAccounts {
+		if a == account {
+			return nil
+		}
+	}
+	return fmt.Errorf("account '%s' is not authorized by collection '%s'", account, c.CollectionName)
+}
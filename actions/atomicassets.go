@@ -0,0 +1,565 @@
+package actions
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/eosswedenorg-go/atomicasset"
+	"github.com/eosswedenorg-go/atomicasset/eosio"
+)
+
+// DefaultAtomicAssetsContract is the account of the atomicassets contract.
+const DefaultAtomicAssetsContract = "atomicassets"
+
+// MaxMarketFee is the highest market fee allowed by the atomicassets contract.
+const MaxMarketFee = 0.15
+
+// Types
+
+// AtomicAssets builds actions for the atomicassets contract.
+type AtomicAssets struct {
+	// Contract account, DefaultAtomicAssetsContract if empty.
+	Contract string
+
+	// Permission used to authorize the actions, DefaultPermission if empty.
+	Permission string
+}
+
+func (a AtomicAssets) builder() builder {
+	contract := a.Contract
+	if len(contract) < 1 {
+		contract = DefaultAtomicAssetsContract
+	}
+	return builder{contract: contract, permission: a.Permission}
+}
+
+// Action data
+
+type TransferArgs struct {
+	From     string  `json:"from"`
+	To       string  `json:"to"`
+	AssetIDs Uint64s `json:"asset_ids"`
+	Memo     string  `json:"memo"`
+}
+
+func (d TransferArgs) Pack(e *eosio.Encoder) {
+	e.Name(d.From)
+	e.Name(d.To)
+	d.AssetIDs.Pack(e)
+	e.String(d.Memo)
+}
+
+type CreateOfferArgs struct {
+	Sender            string  `json:"sender"`
+	Recipient         string  `json:"recipient"`
+	SenderAssetIDs    Uint64s `json:"sender_asset_ids"`
+	RecipientAssetIDs Uint64s `json:"recipient_asset_ids"`
+	Memo              string  `json:"memo"`
+}
+
+func (d CreateOfferArgs) Pack(e *eosio.Encoder) {
+	e.Name(d.Sender)
+	e.Name(d.Recipient)
+	d.SenderAssetIDs.Pack(e)
+	d.RecipientAssetIDs.Pack(e)
+	e.String(d.Memo)
+}
+
+// OfferArgs is the data of acceptoffer, declineoffer and canceloffer.
+type OfferArgs struct {
+	OfferID string `json:"offer_id"`
+}
+
+func (d OfferArgs) Pack(e *eosio.Encoder) {
+	packUint64(e, d.OfferID)
+}
+
+type BurnAssetArgs struct {
+	AssetOwner string `json:"asset_owner"`
+	AssetID    string `json:"asset_id"`
+}
+
+func (d BurnAssetArgs) Pack(e *eosio.Encoder) {
+	e.Name(d.AssetOwner)
+	packUint64(e, d.AssetID)
+}
+
+type SetAssetDataArgs struct {
+	AuthorizedEditor string       `json:"authorized_editor"`
+	AssetOwner       string       `json:"asset_owner"`
+	AssetID          string       `json:"asset_id"`
+	NewMutableData   AttributeMap `json:"new_mutable_data"`
+}
+
+func (d SetAssetDataArgs) Pack(e *eosio.Encoder) {
+	e.Name(d.AuthorizedEditor)
+	e.Name(d.AssetOwner)
+	packUint64(e, d.AssetID)
+	d.NewMutableData.Pack(e)
+}
+
+type MintAssetArgs struct {
+	AuthorizedMinter string       `json:"authorized_minter"`
+	CollectionName   string       `json:"collection_name"`
+	SchemaName       string       `json:"schema_name"`
+	TemplateID       int32        `json:"template_id"`
+	NewAssetOwner    string       `json:"new_asset_owner"`
+	ImmutableData    AttributeMap `json:"immutable_data"`
+	MutableData      AttributeMap `json:"mutable_data"`
+	TokensToBack     Tokens       `json:"tokens_to_back"`
+}
+
+func (d MintAssetArgs) Pack(e *eosio.Encoder) {
+	e.Name(d.AuthorizedMinter)
+	e.Name(d.CollectionName)
+	e.Name(d.SchemaName)
+	e.Int32(d.TemplateID)
+	e.Name(d.NewAssetOwner)
+	d.ImmutableData.Pack(e)
+	d.MutableData.Pack(e)
+	d.TokensToBack.Pack(e)
+}
+
+type CreateTemplateArgs struct {
+	AuthorizedCreator string       `json:"authorized_creator"`
+	CollectionName    string       `json:"collection_name"`
+	SchemaName        string       `json:"schema_name"`
+	Transferable      bool         `json:"transferable"`
+	Burnable          bool         `json:"burnable"`
+	MaxSupply         uint32       `json:"max_supply"`
+	ImmutableData     AttributeMap `json:"immutable_data"`
+}
+
+func (d CreateTemplateArgs) Pack(e *eosio.Encoder) {
+	e.Name(d.AuthorizedCreator)
+	e.Name(d.CollectionName)
+	e.Name(d.SchemaName)
+	e.Bool(d.Transferable)
+	e.Bool(d.Burnable)
+	e.Uint32(d.MaxSupply)
+	d.ImmutableData.Pack(e)
+}
+
+type LockTemplateArgs struct {
+	AuthorizedEditor string `json:"authorized_editor"`
+	CollectionName   string `json:"collection_name"`
+	TemplateID       int32  `json:"template_id"`
+}
+
+func (d LockTemplateArgs) Pack(e *eosio.Encoder) {
+	e.Name(d.AuthorizedEditor)
+	e.Name(d.CollectionName)
+	e.Int32(d.TemplateID)
+}
+
+type CreateSchemaArgs struct {
+	AuthorizedCreator string  `json:"authorized_creator"`
+	CollectionName    string  `json:"collection_name"`
+	SchemaName        string  `json:"schema_name"`
+	SchemaFormat      Formats `json:"schema_format"`
+}
+
+func (d CreateSchemaArgs) Pack(e *eosio.Encoder) {
+	e.Name(d.AuthorizedCreator)
+	e.Name(d.CollectionName)
+	e.Name(d.SchemaName)
+	d.SchemaFormat.Pack(e)
+}
+
+type ExtendSchemaArgs struct {
+	AuthorizedEditor      string  `json:"authorized_editor"`
+	CollectionName        string  `json:"collection_name"`
+	SchemaName            string  `json:"schema_name"`
+	SchemaFormatExtension Formats `json:"schema_format_extension"`
+}
+
+func (d ExtendSchemaArgs) Pack(e *eosio.Encoder) {
+	e.Name(d.AuthorizedEditor)
+	e.Name(d.CollectionName)
+	e.Name(d.SchemaName)
+	d.SchemaFormatExtension.Pack(e)
+}
+
+type CreateCollectionArgs struct {
+	Author             string       `json:"author"`
+	CollectionName     string       `json:"collection_name"`
+	AllowNotify        bool         `json:"allow_notify"`
+	AuthorizedAccounts []string     `json:"authorized_accounts"`
+	NotifyAccounts     []string     `json:"notify_accounts"`
+	MarketFee          float64      `json:"market_fee"`
+	Data               AttributeMap `json:"data"`
+}
+
+func (d CreateCollectionArgs) Pack(e *eosio.Encoder) {
+	e.Name(d.Author)
+	e.Name(d.CollectionName)
+	e.Bool(d.AllowNotify)
+	e.Names(d.AuthorizedAccounts)
+	e.Names(d.NotifyAccounts)
+	e.Float64(d.MarketFee)
+	d.Data.Pack(e)
+}
+
+type SetCollectionDataArgs struct {
+	CollectionName string       `json:"collection_name"`
+	Data           AttributeMap `json:"data"`
+}
+
+func (d SetCollectionDataArgs) Pack(e *eosio.Encoder) {
+	e.Name(d.CollectionName)
+	d.Data.Pack(e)
+}
+
+// MintAssetParams holds the input of AtomicAssets.MintAsset.
+type MintAssetParams struct {
+	Minter string
+	Owner  string
+
+	// Schema of the asset, Schema.Collection must be set.
+	Schema atomicasset.Schema
+
+	// Template of the asset or nil to mint without a template.
+	Template *atomicasset.Template
+
+	ImmutableData map[string]interface{}
+	MutableData   map[string]interface{}
+	Tokens        []atomicasset.Token
+}
+
+// Action builders
+
+// Transfer builds a "transfer" action that sends assets from one account to another.
+func (a AtomicAssets) Transfer(from string, to string, assets []atomicasset.Asset, memo string) (eosio.Action, error) {
+	if len(assets) < 1 {
+		return eosio.Action{}, errors.New("no assets to transfer")
+	}
+
+	if from == to {
+		return eosio.Action{}, errors.New("can't transfer assets to the same account")
+	}
+
+	ids, err := transferableIDs(from, assets)
+	if err != nil {
+		return eosio.Action{}, err
+	}
+
+	return a.builder().action("transfer", from, TransferArgs{From: from, To: to, AssetIDs: ids, Memo: memo})
+}
+
+// CreateOffer builds a "createoffer" action that offers to trade the sender's
+// assets for the recipient's assets. One of the lists may be empty.
+func (a AtomicAssets) CreateOffer(sender string, recipient string, senderAssets []atomicasset.Asset, recipientAssets []atomicasset.Asset, memo string) (eosio.Action, error) {
+	if len(senderAssets) < 1 && len(recipientAssets) < 1 {
+		return eosio.Action{}, errors.New("offer has no assets")
+	}
+
+	if sender == recipient {
+		return eosio.Action{}, errors.New("can't create an offer to the same account")
+	}
+
+	senderIDs, err := transferableIDs(sender, senderAssets)
+	if err != nil {
+		return eosio.Action{}, err
+	}
+
+	recipientIDs, err := transferableIDs(recipient, recipientAssets)
+	if err != nil {
+		return eosio.Action{}, err
+	}
+
+	return a.builder().action("createoffer", sender, CreateOfferArgs{
+		Sender:            sender,
+		Recipient:         recipient,
+		SenderAssetIDs:    senderIDs,
+		RecipientAssetIDs: recipientIDs,
+		Memo:              memo,
+	})
+}
+
+// AcceptOffer builds an "acceptoffer" action authorized by the recipient of the offer.
+func (a AtomicAssets) AcceptOffer(offer atomicasset.Offer) (eosio.Action, error) {
+	return a.offerAction("acceptoffer", offer.Recipient, offer)
+}
+
+// DeclineOffer builds a "declineoffer" action authorized by the recipient of the offer.
+func (a AtomicAssets) DeclineOffer(offer atomicasset.Offer) (eosio.Action, error) {
+	return a.offerAction("declineoffer", offer.Recipient, offer)
+}
+
+// CancelOffer builds a "canceloffer" action authorized by the sender of the offer.
+func (a AtomicAssets) CancelOffer(offer atomicasset.Offer) (eosio.Action, error) {
+	return a.offerAction("canceloffer", offer.Sender, offer)
+}
+
+func (a AtomicAssets) offerAction(name string, actor string, offer atomicasset.Offer) (eosio.Action, error) {
+	if offer.State != atomicasset.OfferStatePending {
+		return eosio.Action{}, fmt.Errorf("offer %s is not pending (%s)", offer.ID, offer.State)
+	}
+	return a.builder().action(name, actor, OfferArgs{OfferID: offer.ID})
+}
+
+// BurnAsset builds a "burnasset" action authorized by the owner of the asset.
+func (a AtomicAssets) BurnAsset(asset atomicasset.Asset) (eosio.Action, error) {
+	if len(asset.BurnedByAccount) > 0 {
+		return eosio.Action{}, fmt.Errorf("asset %s is already burned", asset.ID)
+	}
+
+	if !asset.IsBurnable {
+		return eosio.Action{}, fmt.Errorf("asset %s is not burnable", asset.ID)
+	}
+
+	return a.builder().action("burnasset", asset.Owner, BurnAssetArgs{AssetOwner: asset.Owner, AssetID: asset.ID})
+}
+
+// SetAssetData builds a "setassetdata" action that replaces the mutable data of an asset.
+// data is validated against the schema of the asset.
+func (a AtomicAssets) SetAssetData(editor string, asset atomicasset.Asset, data map[string]interface{}) (eosio.Action, error) {
+	if err := authorized(asset.Collection, editor); err != nil {
+		return eosio.Action{}, err
+	}
+
+	attrs, err := NewAttributeMap(data, asset.Schema.Format)
+	if err != nil {
+		return eosio.Action{}, fmt.Errorf("asset %s: %s", asset.ID, err)
+	}
+
+	return a.builder().action("setassetdata", editor, SetAssetDataArgs{
+		AuthorizedEditor: editor,
+		AssetOwner:       asset.Owner,
+		AssetID:          asset.ID,
+		NewMutableData:   attrs,
+	})
+}
+
+// MintAsset builds a "mintasset" action.
+func (a AtomicAssets) MintAsset(params MintAssetParams) (eosio.Action, error) {
+	collection := params.Schema.Collection
+	if err := authorized(collection, params.Minter); err != nil {
+		return eosio.Action{}, err
+	}
+
+	templateID := int32(-1)
+	if t := params.Template; t != nil {
+		if t.Schema.Name != params.Schema.Name {
+			return eosio.Action{}, fmt.Errorf("template %s does not belong to schema '%s'", t.ID, params.Schema.Name)
+		}
+
+		id, err := templateID32(*t)
+		if err != nil {
+			return eosio.Action{}, err
+		}
+		templateID = id
+
+		max, _ := strconv.ParseUint(t.MaxSupply, 10, 64)
+		issued, _ := strconv.ParseUint(t.IssuedSupply, 10, 64)
+		if max > 0 && issued >= max {
+			return eosio.Action{}, fmt.Errorf("template %s has reached its max supply (%d)", t.ID, max)
+		}
+	}
+
+	immutable, err := NewAttributeMap(params.ImmutableData, params.Schema.Format)
+	if err != nil {
+		return eosio.Action{}, fmt.Errorf("immutable data: %s", err)
+	}
+
+	mutable, err := NewAttributeMap(params.MutableData, params.Schema.Format)
+	if err != nil {
+		return eosio.Action{}, fmt.Errorf("mutable data: %s", err)
+	}
+
+	return a.builder().action("mintasset", params.Minter, MintAssetArgs{
+		AuthorizedMinter: params.Minter,
+		CollectionName:   collection.CollectionName,
+		SchemaName:       params.Schema.Name,
+		TemplateID:       templateID,
+		NewAssetOwner:    params.Owner,
+		ImmutableData:    immutable,
+		MutableData:      mutable,
+		TokensToBack:     Tokens(params.Tokens),
+	})
+}
+
+// CreateTemplate builds a "createtempl" action. A maxSupply of zero means no limit.
+func (a AtomicAssets) CreateTemplate(creator string, schema atomicasset.Schema, transferable bool, burnable bool, maxSupply uint32, data map[string]interface{}) (eosio.Action, error) {
+	if err := authorized(schema.Collection, creator); err != nil {
+		return eosio.Action{}, err
+	}
+
+	attrs, err := NewAttributeMap(data, schema.Format)
+	if err != nil {
+		return eosio.Action{}, fmt.Errorf("immutable data: %s", err)
+	}
+
+	return a.builder().action("createtempl", creator, CreateTemplateArgs{
+		AuthorizedCreator: creator,
+		CollectionName:    schema.Collection.CollectionName,
+		SchemaName:        schema.Name,
+		Transferable:      transferable,
+		Burnable:          burnable,
+		MaxSupply:         maxSupply,
+		ImmutableData:     attrs,
+	})
+}
+
+// LockTemplate builds a "locktemplate" action that sets the max supply
+// of a template to its issued supply.
+func (a AtomicAssets) LockTemplate(editor string, template atomicasset.Template) (eosio.Action, error) {
+	if err := authorized(template.Collection, editor); err != nil {
+		return eosio.Action{}, err
+	}
+
+	id, err := templateID32(template)
+	if err != nil {
+		return eosio.Action{}, err
+	}
+
+	if issued, _ := strconv.ParseUint(template.IssuedSupply, 10, 64); issued < 1 {
+		return eosio.Action{}, fmt.Errorf("template %s has no issued assets", template.ID)
+	}
+
+	return a.builder().action("locktemplate", editor, LockTemplateArgs{
+		AuthorizedEditor: editor,
+		CollectionName:   template.Collection.CollectionName,
+		TemplateID:       id,
+	})
+}
+
+// CreateSchema builds a "createschema" action.
+func (a AtomicAssets) CreateSchema(creator string, collection atomicasset.Collection, name string, format []atomicasset.SchemaFormat) (eosio.Action, error) {
+	if err := authorized(collection, creator); err != nil {
+		return eosio.Action{}, err
+	}
+
+	if err := checkFormat(nil, format); err != nil {
+		return eosio.Action{}, err
+	}
+
+	return a.builder().action("createschema", creator, CreateSchemaArgs{
+		AuthorizedCreator: creator,
+		CollectionName:    collection.CollectionName,
+		SchemaName:        name,
+		SchemaFormat:      format,
+	})
+}
+
+// ExtendSchema builds an "extendschema" action that adds attributes to a schema.
+func (a AtomicAssets) ExtendSchema(editor string, schema atomicasset.Schema, extension []atomicasset.SchemaFormat) (eosio.Action, error) {
+	if err := authorized(schema.Collection, editor); err != nil {
+		return eosio.Action{}, err
+	}
+
+	if len(extension) < 1 {
+		return eosio.Action{}, errors.New("schema extension has no attributes")
+	}
+
+	if err := checkFormat(schema.Format, extension); err != nil {
+		return eosio.Action{}, err
+	}
+
+	return a.builder().action("extendschema", editor, ExtendSchemaArgs{
+		AuthorizedEditor:      editor,
+		CollectionName:        schema.Collection.CollectionName,
+		SchemaName:            schema.Name,
+		SchemaFormatExtension: extension,
+	})
+}
+
+// CreateCollection builds a "createcol" action authorized by the author of c.
+// c.Data is validated against format (AssetsConfig.CollectionFormat).
+func (a AtomicAssets) CreateCollection(c atomicasset.Collection, format []atomicasset.SchemaFormat) (eosio.Action, error) {
+	if c.MarketFee < 0 || c.MarketFee > MaxMarketFee {
+		return eosio.Action{}, fmt.Errorf("market fee %g is not between 0 and %g", c.MarketFee, MaxMarketFee)
+	}
+
+	attrs, err := NewAttributeMap(c.Data, format)
+	if err != nil {
+		return eosio.Action{}, fmt.Errorf("collection data: %s", err)
+	}
+
+	authorizedAccounts := c.AuthorizedAccounts
+	if authorizedAccounts == nil {
+		authorizedAccounts = []string{}
+	}
+
+	notifyAccounts := c.NotifyAccounts
+	if notifyAccounts == nil {
+		notifyAccounts = []string{}
+	}
+
+	return a.builder().action("createcol", c.Author, CreateCollectionArgs{
+		Author:             c.Author,
+		CollectionName:     c.CollectionName,
+		AllowNotify:        c.AllowNotify,
+		AuthorizedAccounts: authorizedAccounts,
+		NotifyAccounts:     notifyAccounts,
+		MarketFee:          c.MarketFee,
+		Data:               attrs,
+	})
+}
+
+// SetCollectionData builds a "setcoldata" action authorized by the author of the collection.
+// data is validated against format (AssetsConfig.CollectionFormat).
+func (a AtomicAssets) SetCollectionData(c atomicasset.Collection, data map[string]interface{}, format []atomicasset.SchemaFormat) (eosio.Action, error) {
+	attrs, err := NewAttributeMap(data, format)
+	if err != nil {
+		return eosio.Action{}, fmt.Errorf("collection data: %s", err)
+	}
+
+	return a.builder().action("setcoldata", c.Author, SetCollectionDataArgs{
+		CollectionName: c.CollectionName,
+		Data:           attrs,
+	})
+}
+
+// Helpers
+
+// transferableIDs returns the ids of assets after checking that they are
+// owned by owner and can be transferred.
+func transferableIDs(owner string, assets []atomicasset.Asset) (Uint64s, error) {
+	ids := Uint64s{}
+	for _, asset := range assets {
+		if asset.Owner != owner {
+			return nil, fmt.Errorf("asset %s is not owned by '%s'", asset.ID, owner)
+		}
+
+		if !asset.IsTransferable {
+			return nil, fmt.Errorf("asset %s is not transferable", asset.ID)
+		}
+		ids = append(ids, asset.ID)
+	}
+	return ids, nil
+}
+
+func templateID32(t atomicasset.Template) (int32, error) {
+	id, err := strconv.ParseInt(t.ID, 10, 32)
+	if err != nil || id < 0 {
+		return 0, fmt.Errorf("invalid template id '%s'", t.ID)
+	}
+	return int32(id), nil
+}
+
+// checkFormat checks that the attributes in format have supported types
+// and names that are unique (also among the attributes in existing).
+func checkFormat(existing []atomicasset.SchemaFormat, format []atomicasset.SchemaFormat) error {
+	names := map[string]bool{}
+	for _, f := range existing {
+		names[f.Name] = true
+	}
+
+	for _, f := range format {
+		if len(f.Name) < 1 {
+			return errors.New("schema attribute has no name")
+		}
+
+		if names[f.Name] {
+			return fmt.Errorf("schema attribute '%s' is declared more than once", f.Name)
+		}
+		names[f.Name] = true
+
+		if _, ok := attributeVariant(f.Type); !ok {
+			return fmt.Errorf("schema attribute '%s' has unsupported type '%s'", f.Name, f.Type)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,273 @@
+package actions
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/eosswedenorg-go/atomicasset"
+	"github.com/eosswedenorg-go/atomicasset/eosio"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testCollection = atomicasset.Collection{
+	CollectionName:     "mycollection",
+	Author:             "alice",
+	AuthorizedAccounts: []string{"alice"},
+}
+
+var testSchema = atomicasset.Schema{
+	Name: "heroes",
+	Format: []atomicasset.SchemaFormat{
+		{Name: "name", Type: "string"},
+		{Name: "level", Type: "uint8"},
+		{Name: "power", Type: "float"},
+		{Name: "tags", Type: "string[]"},
+		{Name: "alive", Type: "bool"},
+		{Name: "blob", Type: "bytes"},
+	},
+	Collection: testCollection,
+}
+
+func testAsset(id string, owner string) atomicasset.Asset {
+	return atomicasset.Asset{
+		ID:             id,
+		Owner:          owner,
+		IsTransferable: true,
+		IsBurnable:     true,
+		Collection:     testCollection,
+		Schema:         atomicasset.InlineSchema{Name: testSchema.Name, Format: testSchema.Format},
+	}
+}
+
+func TestAtomicAssets_Transfer(t *testing.T) {
+	assets := []atomicasset.Asset{testAsset("1099511627776", "alice"), testAsset("2", "alice")}
+
+	a, err := AtomicAssets{}.Transfer("alice", "bob", assets, "hi")
+	require.NoError(t, err)
+
+	assert.Equal(t, "atomicassets", a.Account)
+	assert.Equal(t, "transfer", a.Name)
+	assert.Equal(t, []eosio.PermissionLevel{{Actor: "alice", Permission: "active"}}, a.Authorization)
+	assert.Equal(t, "0000000000855c34"+"0000000000000e3d"+"02"+"0000000000010000"+"0200000000000000"+"026869", a.HexData)
+
+	b, err := json.Marshal(a.Data)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"from":"alice","to":"bob","asset_ids":["1099511627776","2"],"memo":"hi"}`, string(b))
+
+	a, err = AtomicAssets{Contract: "atomicassetx", Permission: "transfer"}.Transfer("alice", "bob", assets[1:], "")
+	require.NoError(t, err)
+	assert.Equal(t, "atomicassetx", a.Account)
+	assert.Equal(t, []eosio.PermissionLevel{{Actor: "alice", Permission: "transfer"}}, a.Authorization)
+}
+
+func TestAtomicAssets_TransferErrors(t *testing.T) {
+	locked := testAsset("3", "alice")
+	locked.IsTransferable = false
+
+	tests := []struct {
+		name   string
+		from   string
+		to     string
+		assets []atomicasset.Asset
+		err    string
+	}{
+		{"empty", "alice", "bob", nil, "no assets to transfer"},
+		{"self", "alice", "alice", []atomicasset.Asset{testAsset("1", "alice")}, "can't transfer assets to the same account"},
+		{"owner", "alice", "bob", []atomicasset.Asset{testAsset("1", "bob")}, "asset 1 is not owned by 'alice'"},
+		{"transferable", "alice", "bob", []atomicasset.Asset{locked}, "asset 3 is not transferable"},
+		{"name", "alice", "Bob", []atomicasset.Asset{testAsset("1", "alice")}, "action atomicassets::transfer: invalid name 'Bob': invalid character 'B'"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := AtomicAssets{}.Transfer(tt.from, tt.to, tt.assets, "")
+			assert.EqualError(t, err, tt.err)
+		})
+	}
+}
+
+func TestAtomicAssets_Offers(t *testing.T) {
+	a, err := AtomicAssets{}.CreateOffer("alice", "bob", []atomicasset.Asset{testAsset("1", "alice")}, nil, "")
+	require.NoError(t, err)
+	assert.Equal(t, "0000000000855c34"+"0000000000000e3d"+"01"+"0100000000000000"+"00"+"00", a.HexData)
+
+	_, err = AtomicAssets{}.CreateOffer("alice", "bob", nil, nil, "")
+	assert.EqualError(t, err, "offer has no assets")
+
+	_, err = AtomicAssets{}.CreateOffer("alice", "bob", nil, []atomicasset.Asset{testAsset("1", "alice")}, "")
+	assert.EqualError(t, err, "asset 1 is not owned by 'bob'")
+
+	offer := atomicasset.Offer{ID: "7", Sender: "alice", Recipient: "bob", State: atomicasset.OfferStatePending}
+
+	a, err = AtomicAssets{}.AcceptOffer(offer)
+	require.NoError(t, err)
+	assert.Equal(t, "acceptoffer", a.Name)
+	assert.Equal(t, "bob", a.Authorization[0].Actor)
+	assert.Equal(t, "0700000000000000", a.HexData)
+
+	a, err = AtomicAssets{}.DeclineOffer(offer)
+	require.NoError(t, err)
+	assert.Equal(t, "declineoffer", a.Name)
+	assert.Equal(t, "bob", a.Authorization[0].Actor)
+
+	a, err = AtomicAssets{}.CancelOffer(offer)
+	require.NoError(t, err)
+	assert.Equal(t, "canceloffer", a.Name)
+	assert.Equal(t, "alice", a.Authorization[0].Actor)
+
+	offer.State = atomicasset.OfferStateAccepted
+	_, err = AtomicAssets{}.AcceptOffer(offer)
+	assert.EqualError(t, err, "offer 7 is not pending (accepted)")
+}
+
+func TestAtomicAssets_BurnAsset(t *testing.T) {
+	asset := testAsset("2", "bob")
+
+	a, err := AtomicAssets{}.BurnAsset(asset)
+	require.NoError(t, err)
+	assert.Equal(t, "bob", a.Authorization[0].Actor)
+	assert.Equal(t, "0000000000000e3d"+"0200000000000000", a.HexData)
+
+	asset.IsBurnable = false
+	_, err = AtomicAssets{}.BurnAsset(asset)
+	assert.EqualError(t, err, "asset 2 is not burnable")
+}
+
+func TestAtomicAssets_SetAssetData(t *testing.T) {
+	asset := testAsset("2", "bob")
+
+	a, err := AtomicAssets{}.SetAssetData("alice", asset, map[string]interface{}{"level": 3})
+	require.NoError(t, err)
+	assert.Equal(t, "alice", a.Authorization[0].Actor)
+	assert.Equal(t, "0000000000855c34"+"0000000000000e3d"+"0200000000000000"+"01"+"056c6576656c"+"04"+"03", a.HexData)
+
+	_, err = AtomicAssets{}.SetAssetData("bob", asset, nil)
+	assert.EqualError(t, err, "account 'bob' is not authorized by collection 'mycollection'")
+
+	_, err = AtomicAssets{}.SetAssetData("alice", asset, map[string]interface{}{"level": 300, "color": "red"})
+	assert.EqualError(t, err, "asset 2: attribute 'level' (uint8): '300' is not a valid uint8; attribute 'color': not defined in schema")
+}
+
+func TestAtomicAssets_MintAsset(t *testing.T) {
+	template := &atomicasset.Template{
+		ID:           "5",
+		MaxSupply:    "10",
+		IssuedSupply: "9",
+		Schema:       atomicasset.InlineSchema{Name: "heroes"},
+	}
+
+	a, err := AtomicAssets{}.MintAsset(MintAssetParams{
+		Minter:        "alice",
+		Owner:         "bob",
+		Schema:        testSchema,
+		Template:      template,
+		ImmutableData: map[string]interface{}{"name": "Hero", "level": 3, "tags": []string{"a"}, "alive": true},
+		Tokens:        []atomicasset.Token{{Symbol: "WAX", Precision: 8, Amount: "150000000"}},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "0000000000855c34"+"30a9cb48c5489197"+"000000006045af6a"+"05000000"+"0000000000000e3d"+
+		"04"+"046e616d65"+"0a"+"044865726f"+"056c6576656c"+"04"+"03"+"0474616773"+"15"+"010161"+"05616c697665"+"04"+"01"+
+		"00"+
+		"01"+"80d1f00800000000"+"0857415800000000", a.HexData)
+
+	b, err := json.Marshal(a.Data)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"authorized_minter": "alice",
+		"collection_name": "mycollection",
+		"schema_name": "heroes",
+		"template_id": 5,
+		"new_asset_owner": "bob",
+		"immutable_data": [
+			{"key": "name", "value": ["string", "Hero"]},
+			{"key": "level", "value": ["uint8", 3]},
+			{"key": "tags", "value": ["STRING_VEC", ["a"]]},
+			{"key": "alive", "value": ["uint8", 1]}
+		],
+		"mutable_data": [],
+		"tokens_to_back": ["1.50000000 WAX"]
+	}`, string(b))
+
+	a, err = AtomicAssets{}.MintAsset(MintAssetParams{Minter: "alice", Owner: "bob", Schema: testSchema})
+	require.NoError(t, err)
+	assert.Equal(t, int32(-1), a.Data.(MintAssetArgs).TemplateID)
+
+	template.IssuedSupply = "10"
+	_, err = AtomicAssets{}.MintAsset(MintAssetParams{Minter: "alice", Owner: "bob", Schema: testSchema, Template: template})
+	assert.EqualError(t, err, "template 5 has reached its max supply (10)")
+
+	_, err = AtomicAssets{}.MintAsset(MintAssetParams{Minter: "bob", Owner: "bob", Schema: testSchema})
+	assert.EqualError(t, err, "account 'bob' is not authorized by collection 'mycollection'")
+}
+
+func TestAtomicAssets_Templates(t *testing.T) {
+	a, err := AtomicAssets{}.CreateTemplate("alice", testSchema, true, false, 0, map[string]interface{}{"blob": []interface{}{1, 255}})
+	require.NoError(t, err)
+	assert.Equal(t, "createtempl", a.Name)
+	assert.Equal(t, "0000000000855c34"+"30a9cb48c5489197"+"000000006045af6a"+"01"+"00"+"00000000"+"01"+"04626c6f62"+"0f"+"0201ff", a.HexData)
+
+	b, err := json.Marshal(a.Data.(CreateTemplateArgs).ImmutableData)
+	require.NoError(t, err)
+	assert.JSONEq(t, `[{"key": "blob", "value": ["UINT8_VEC", [1, 255]]}]`, string(b))
+
+	template := atomicasset.Template{ID: "5", IssuedSupply: "1", Collection: testCollection}
+	a, err = AtomicAssets{}.LockTemplate("alice", template)
+	require.NoError(t, err)
+	assert.Equal(t, "0000000000855c34"+"30a9cb48c5489197"+"05000000", a.HexData)
+
+	template.IssuedSupply = "0"
+	_, err = AtomicAssets{}.LockTemplate("alice", template)
+	assert.EqualError(t, err, "template 5 has no issued assets")
+}
+
+func TestAtomicAssets_Schemas(t *testing.T) {
+	format := []atomicasset.SchemaFormat{{Name: "name", Type: "string"}}
+
+	a, err := AtomicAssets{}.CreateSchema("alice", testCollection, "heroes", format)
+	require.NoError(t, err)
+	assert.Equal(t, "0000000000855c34"+"30a9cb48c5489197"+"000000006045af6a"+"01"+"046e616d65"+"06737472696e67", a.HexData)
+
+	_, err = AtomicAssets{}.CreateSchema("alice", testCollection, "heroes", append(format, format...))
+	assert.EqualError(t, err, "schema attribute 'name' is declared more than once")
+
+	_, err = AtomicAssets{}.CreateSchema("alice", testCollection, "heroes", []atomicasset.SchemaFormat{{Name: "x", Type: "bytes[]"}})
+	assert.EqualError(t, err, "schema attribute 'x' has unsupported type 'bytes[]'")
+
+	a, err = AtomicAssets{}.ExtendSchema("alice", testSchema, []atomicasset.SchemaFormat{{Name: "speed", Type: "uint16[]"}})
+	require.NoError(t, err)
+	assert.Equal(t, "extendschema", a.Name)
+
+	_, err = AtomicAssets{}.ExtendSchema("alice", testSchema, format)
+	assert.EqualError(t, err, "schema attribute 'name' is declared more than once")
+
+	_, err = AtomicAssets{}.ExtendSchema("alice", testSchema, nil)
+	assert.EqualError(t, err, "schema extension has no attributes")
+}
+
+func TestAtomicAssets_Collections(t *testing.T) {
+	format := []atomicasset.SchemaFormat{{Name: "name", Type: "string"}, {Name: "img", Type: "ipfs"}}
+
+	c := testCollection
+	c.MarketFee = 0.05
+	c.Data = map[string]interface{}{"name": "My"}
+
+	a, err := AtomicAssets{}.CreateCollection(c, format)
+	require.NoError(t, err)
+	assert.Equal(t, "createcol", a.Name)
+	assert.Equal(t, "alice", a.Authorization[0].Actor)
+	assert.Equal(t, "0000000000855c34"+"30a9cb48c5489197"+"00"+"01"+"0000000000855c34"+"00"+"9a9999999999a93f"+"01"+"046e616d65"+"0a"+"024d79", a.HexData)
+
+	c.MarketFee = 0.2
+	_, err = AtomicAssets{}.CreateCollection(c, format)
+	assert.EqualError(t, err, "market fee 0.2 is not between 0 and 0.15")
+
+	a, err = AtomicAssets{}.SetCollectionData(c, map[string]interface{}{"img": "Qm"}, format)
+	require.NoError(t, err)
+	assert.Equal(t, "setcoldata", a.Name)
+	assert.Equal(t, "30a9cb48c5489197"+"01"+"03696d67"+"0a"+"02516d", a.HexData)
+
+	_, err = AtomicAssets{}.SetCollectionData(c, map[string]interface{}{"img": 1.5}, format)
+	assert.Error(t, err)
+}
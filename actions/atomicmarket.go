@@ -0,0 +1,554 @@
+package actions
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/eosswedenorg-go/atomicasset"
+	"github.com/eosswedenorg-go/atomicasset/eosio"
+)
+
+// DefaultAtomicMarketContract is the account of the atomicmarket contract.
+const DefaultAtomicMarketContract = "atomicmarket"
+
+// Memos the atomicmarket contract expects on transfers and offers it receives.
+const (
+	MarketMemoDeposit = "deposit"
+	MarketMemoSale    = "sale"
+	MarketMemoAuction = "auction"
+)
+
+// Types
+
+// AtomicMarket builds transactions for the atomicmarket contract.
+//
+// Each builder returns the actions of a transaction in the order they
+// must be executed.
+type AtomicMarket struct {
+	// Config of the market, the contract accounts, supported tokens and
+	// delphi pairs are read from it.
+	Config atomicasset.MarketConfig
+
+	// Marketplace is set as the maker marketplace of listings and the
+	// taker marketplace of purchases and bids.
+	Marketplace string
+
+	// Permission used to authorize the actions, DefaultPermission if empty.
+	Permission string
+}
+
+func (m AtomicMarket) builder() builder {
+	contract := m.Config.AtomicmarketContract
+	if len(contract) < 1 {
+		contract = DefaultAtomicMarketContract
+	}
+	return builder{contract: contract, permission: m.Permission}
+}
+
+func (m AtomicMarket) assets() AtomicAssets {
+	return AtomicAssets{Contract: m.Config.AtomicassetsContract, Permission: m.Permission}
+}
+
+// Action data
+
+type TokenTransferArgs struct {
+	From     string   `json:"from"`
+	To       string   `json:"to"`
+	Quantity Quantity `json:"quantity"`
+	Memo     string   `json:"memo"`
+}
+
+func (d TokenTransferArgs) Pack(e *eosio.Encoder) {
+	e.Name(d.From)
+	e.Name(d.To)
+	d.Quantity.Pack(e)
+	e.String(d.Memo)
+}
+
+type AnnounceSaleArgs struct {
+	Seller           string   `json:"seller"`
+	AssetIDs         Uint64s  `json:"asset_ids"`
+	ListingPrice     Quantity `json:"listing_price"`
+	SettlementSymbol Symbol   `json:"settlement_symbol"`
+	MakerMarketplace string   `json:"maker_marketplace"`
+}
+
+func (d AnnounceSaleArgs) Pack(e *eosio.Encoder) {
+	e.Name(d.Seller)
+	d.AssetIDs.Pack(e)
+	d.ListingPrice.Pack(e)
+	d.SettlementSymbol.Pack(e)
+	e.Name(d.MakerMarketplace)
+}
+
+type AssertSaleArgs struct {
+	SaleID                   string   `json:"sale_id"`
+	AssetIDsToAssert         Uint64s  `json:"asset_ids_to_assert"`
+	ListingPriceToAssert     Quantity `json:"listing_price_to_assert"`
+	SettlementSymbolToAssert Symbol   `json:"settlement_symbol_to_assert"`
+}
+
+func (d AssertSaleArgs) Pack(e *eosio.Encoder) {
+	packUint64(e, d.SaleID)
+	d.AssetIDsToAssert.Pack(e)
+	d.ListingPriceToAssert.Pack(e)
+	d.SettlementSymbolToAssert.Pack(e)
+}
+
+type PurchaseSaleArgs struct {
+	Buyer                string `json:"buyer"`
+	SaleID               string `json:"sale_id"`
+	IntendedDelphiMedian uint64 `json:"intended_delphi_median"`
+	TakerMarketplace     string `json:"taker_marketplace"`
+}
+
+func (d PurchaseSaleArgs) Pack(e *eosio.Encoder) {
+	e.Name(d.Buyer)
+	packUint64(e, d.SaleID)
+	e.Uint64(d.IntendedDelphiMedian)
+	e.Name(d.TakerMarketplace)
+}
+
+type CancelSaleArgs struct {
+	SaleID string `json:"sale_id"`
+}
+
+func (d CancelSaleArgs) Pack(e *eosio.Encoder) {
+	packUint64(e, d.SaleID)
+}
+
+type AnnounceAuctionArgs struct {
+	Seller           string   `json:"seller"`
+	AssetIDs         Uint64s  `json:"asset_ids"`
+	StartingBid      Quantity `json:"starting_bid"`
+	Duration         uint32   `json:"duration"`
+	MakerMarketplace string   `json:"maker_marketplace"`
+}
+
+func (d AnnounceAuctionArgs) Pack(e *eosio.Encoder) {
+	e.Name(d.Seller)
+	d.AssetIDs.Pack(e)
+	d.StartingBid.Pack(e)
+	e.Uint32(d.Duration)
+	e.Name(d.MakerMarketplace)
+}
+
+type AuctionBidArgs struct {
+	Bidder           string   `json:"bidder"`
+	AuctionID        string   `json:"auction_id"`
+	Bid              Quantity `json:"bid"`
+	TakerMarketplace string   `json:"taker_marketplace"`
+}
+
+func (d AuctionBidArgs) Pack(e *eosio.Encoder) {
+	e.Name(d.Bidder)
+	packUint64(e, d.AuctionID)
+	d.Bid.Pack(e)
+	e.Name(d.TakerMarketplace)
+}
+
+// AuctionArgs is the data of auctclaimbuy and auctclaimsel.
+type AuctionArgs struct {
+	AuctionID string `json:"auction_id"`
+}
+
+func (d AuctionArgs) Pack(e *eosio.Encoder) {
+	packUint64(e, d.AuctionID)
+}
+
+type CreateBuyOfferArgs struct {
+	Buyer            string   `json:"buyer"`
+	Recipient        string   `json:"recipient"`
+	Price            Quantity `json:"price"`
+	AssetIDs         Uint64s  `json:"asset_ids"`
+	Memo             string   `json:"memo"`
+	MakerMarketplace string   `json:"maker_marketplace"`
+}
+
+func (d CreateBuyOfferArgs) Pack(e *eosio.Encoder) {
+	e.Name(d.Buyer)
+	e.Name(d.Recipient)
+	d.Price.Pack(e)
+	d.AssetIDs.Pack(e)
+	e.String(d.Memo)
+	e.Name(d.MakerMarketplace)
+}
+
+// BuyOfferArgs is the data of cancelbuyo.
+type BuyOfferArgs struct {
+	BuyOfferID string `json:"buyoffer_id"`
+}
+
+func (d BuyOfferArgs) Pack(e *eosio.Encoder) {
+	packUint64(e, d.BuyOfferID)
+}
+
+// Transaction builders
+
+// ListSale lists assets for sale.
+//
+// price is the listing price. If settlement is a different symbol than the
+// price, the sale is priced using the delphi pair between the symbols.
+// The transaction is announcesale followed by an offer of the assets to the market.
+func (m AtomicMarket) ListSale(seller string, assets []atomicasset.Asset, price atomicasset.Token, settlement string) ([]eosio.Action, error) {
+	settlementSymbol, err := m.settlementSymbol(price, settlement)
+	if err != nil {
+		return nil, err
+	}
+
+	ids, err := transferableIDs(seller, assets)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(ids) < 1 {
+		return nil, errors.New("no assets to sell")
+	}
+
+	announce, err := m.builder().action("announcesale", seller, AnnounceSaleArgs{
+		Seller:           seller,
+		AssetIDs:         ids,
+		ListingPrice:     Quantity(price),
+		SettlementSymbol: settlementSymbol,
+		MakerMarketplace: m.Marketplace,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	offer, err := m.assets().CreateOffer(seller, m.builder().contract, assets, nil, MarketMemoSale)
+	if err != nil {
+		return nil, err
+	}
+	return []eosio.Action{announce, offer}, nil
+}
+
+// CancelSale cancels a sale, authorized by the seller.
+func (m AtomicMarket) CancelSale(sale atomicasset.Sale) (eosio.Action, error) {
+	if sale.State != atomicasset.SalesStateWaiting && sale.State != atomicasset.SalesStateListed {
+		return eosio.Action{}, fmt.Errorf("sale %s can not be canceled (%s)", sale.ID, sale.State)
+	}
+	return m.builder().action("cancelsale", sale.Seller, CancelSaleArgs{SaleID: sale.ID})
+}
+
+// PurchaseSale buys a listed sale.
+//
+// The transaction asserts the sale's assets and price, deposits the
+// settlement price to the market and purchases the sale. For sales that
+// use delphi, the settlement price and intended median are calculated
+// from the median in the pair data of the config.
+func (m AtomicMarket) PurchaseSale(buyer string, sale atomicasset.Sale) ([]eosio.Action, error) {
+	if sale.State != atomicasset.SalesStateListed {
+		return nil, fmt.Errorf("sale %s is not listed (%s)", sale.ID, sale.State)
+	}
+
+	if sale.Seller == buyer {
+		return nil, errors.New("can't purchase your own sale")
+	}
+
+	price, err := m.Config.SettlementPrice(sale, nil)
+	if err != nil {
+		return nil, fmt.Errorf("sale %s: %s", sale.ID, err)
+	}
+
+	median, err := m.Config.IntendedDelphiMedian(sale)
+	if err != nil {
+		return nil, fmt.Errorf("sale %s: %s", sale.ID, err)
+	}
+
+	listing, err := m.listingPrice(sale)
+	if err != nil {
+		return nil, fmt.Errorf("sale %s: %s", sale.ID, err)
+	}
+
+	ids := Uint64s{}
+	for _, a := range sale.Assets {
+		ids = append(ids, a.ID)
+	}
+
+	assert, err := m.builder().action("assertsale", buyer, AssertSaleArgs{
+		SaleID:                   sale.ID,
+		AssetIDsToAssert:         ids,
+		ListingPriceToAssert:     Quantity(listing),
+		SettlementSymbolToAssert: Symbol{Precision: price.Precision, Code: price.Symbol},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	deposit, err := m.deposit(buyer, price)
+	if err != nil {
+		return nil, err
+	}
+
+	purchase, err := m.builder().action("purchasesale", buyer, PurchaseSaleArgs{
+		Buyer:                buyer,
+		SaleID:               sale.ID,
+		IntendedDelphiMedian: median,
+		TakerMarketplace:     m.Marketplace,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return []eosio.Action{assert, deposit, purchase}, nil
+}
+
+// ListAuction puts assets up for auction.
+//
+// The transaction is announceauct followed by a transfer of the assets to the market.
+func (m AtomicMarket) ListAuction(seller string, assets []atomicasset.Asset, startingBid atomicasset.Token, duration time.Duration) ([]eosio.Action, error) {
+	if err := m.supportedToken(startingBid); err != nil {
+		return nil, err
+	}
+
+	seconds := int64(duration / time.Second)
+	if min := int64(m.Config.MinimumAuctionDuration); min > 0 && seconds < min {
+		return nil, fmt.Errorf("auction duration %s is shorter than the minimum %s", duration, time.Duration(min)*time.Second)
+	}
+
+	if max := int64(m.Config.MaximumAuctionDuration); max > 0 && seconds > max {
+		return nil, fmt.Errorf("auction duration %s is longer than the maximum %s", duration, time.Duration(max)*time.Second)
+	}
+
+	if seconds > math.MaxUint32 {
+		return nil, fmt.Errorf("auction duration %s is too long", duration)
+	}
+
+	ids, err := transferableIDs(seller, assets)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(ids) < 1 {
+		return nil, errors.New("no assets to auction")
+	}
+
+	announce, err := m.builder().action("announceauct", seller, AnnounceAuctionArgs{
+		Seller:           seller,
+		AssetIDs:         ids,
+		StartingBid:      Quantity(startingBid),
+		Duration:         uint32(seconds),
+		MakerMarketplace: m.Marketplace,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	transfer, err := m.assets().Transfer(seller, m.builder().contract, assets, MarketMemoAuction)
+	if err != nil {
+		return nil, err
+	}
+	return []eosio.Action{announce, transfer}, nil
+}
+
+// Bid places a bid on an active auction.
+//
+// The bid must be at least the starting price, or the current bid raised
+// by the minimum bid increase of the config. The transaction deposits the
+// bid to the market followed by auctionbid.
+func (m AtomicMarket) Bid(bidder string, auction atomicasset.Auction, bid atomicasset.Token, now time.Time) ([]eosio.Action, error) {
+	if !auction.IsActive(now) {
+		return nil, fmt.Errorf("auction %s is not active", auction.ID)
+	}
+
+	if auction.Seller == bidder {
+		return nil, errors.New("can't bid on your own auction")
+	}
+
+	if bid.Symbol != auction.Price.Symbol {
+		return nil, fmt.Errorf("bid must be in %s", auction.Price.Symbol)
+	}
+
+	amount, err := bid.Units()
+	if err != nil {
+		return nil, err
+	}
+
+	minimum, err := auction.MinimumNextBid(m.Config)
+	if err != nil {
+		return nil, fmt.Errorf("auction %s: %s", auction.ID, err)
+	}
+
+	if units, _ := minimum.Units(); amount < units {
+		return nil, fmt.Errorf("bid %s is lower than the minimum bid %s", bid, minimum)
+	}
+
+	deposit, err := m.deposit(bidder, bid)
+	if err != nil {
+		return nil, err
+	}
+
+	action, err := m.builder().action("auctionbid", bidder, AuctionBidArgs{
+		Bidder:           bidder,
+		AuctionID:        auction.ID,
+		Bid:              Quantity(bid),
+		TakerMarketplace: m.Marketplace,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return []eosio.Action{deposit, action}, nil
+}
+
+// ClaimAuctionBuyer claims the assets of a won auction, authorized by the buyer.
+func (m AtomicMarket) ClaimAuctionBuyer(auction atomicasset.Auction, now time.Time) (eosio.Action, error) {
+	if err := claimable(auction, now); err != nil {
+		return eosio.Action{}, err
+	}
+
+	if auction.ClaimedByBuyer {
+		return eosio.Action{}, fmt.Errorf("auction %s is already claimed by the buyer", auction.ID)
+	}
+	return m.builder().action("auctclaimbuy", auction.Buyer, AuctionArgs{AuctionID: auction.ID})
+}
+
+// ClaimAuctionSeller claims the winning bid of an auction, authorized by the seller.
+func (m AtomicMarket) ClaimAuctionSeller(auction atomicasset.Auction, now time.Time) (eosio.Action, error) {
+	if err := claimable(auction, now); err != nil {
+		return eosio.Action{}, err
+	}
+
+	if auction.ClaimedBySeller {
+		return eosio.Action{}, fmt.Errorf("auction %s is already claimed by the seller", auction.ID)
+	}
+	return m.builder().action("auctclaimsel", auction.Seller, AuctionArgs{AuctionID: auction.ID})
+}
+
+func claimable(auction atomicasset.Auction, now time.Time) error {
+	if !auction.IsEnded(now) {
+		return fmt.Errorf("auction %s has not ended", auction.ID)
+	}
+
+	if len(auction.Buyer) < 1 {
+		return fmt.Errorf("auction %s has no bids", auction.ID)
+	}
+	return nil
+}
+
+// CreateBuyOffer offers to buy assets owned by recipient.
+//
+// The transaction deposits the price to the market followed by createbuyo.
+func (m AtomicMarket) CreateBuyOffer(buyer string, recipient string, assets []atomicasset.Asset, price atomicasset.Token, memo string) ([]eosio.Action, error) {
+	if err := m.supportedToken(price); err != nil {
+		return nil, err
+	}
+
+	if buyer == recipient {
+		return nil, errors.New("can't create a buyoffer to the same account")
+	}
+
+	ids, err := transferableIDs(recipient, assets)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(ids) < 1 {
+		return nil, errors.New("buyoffer has no assets")
+	}
+
+	deposit, err := m.deposit(buyer, price)
+	if err != nil {
+		return nil, err
+	}
+
+	offer, err := m.builder().action("createbuyo", buyer, CreateBuyOfferArgs{
+		Buyer:            buyer,
+		Recipient:        recipient,
+		Price:            Quantity(price),
+		AssetIDs:         ids,
+		Memo:             memo,
+		MakerMarketplace: m.Marketplace,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return []eosio.Action{deposit, offer}, nil
+}
+
+// CancelBuyOffer cancels a pending buyoffer, authorized by the buyer.
+func (m AtomicMarket) CancelBuyOffer(offer atomicasset.BuyOffer) (eosio.Action, error) {
+	if offer.State != atomicasset.BuyOfferStatePending {
+		return eosio.Action{}, fmt.Errorf("buyoffer %s is not pending (%s)", offer.ID, offer.State)
+	}
+	return m.builder().action("cancelbuyo", offer.Buyer, BuyOfferArgs{BuyOfferID: offer.ID})
+}
+
+// Helpers
+
+// deposit builds a transfer of quantity to the market.
+func (m AtomicMarket) deposit(account string, quantity atomicasset.Token) (eosio.Action, error) {
+	contract := quantity.Contract
+	if len(contract) < 1 {
+		t, ok := m.Config.FindToken(quantity.Symbol)
+		if !ok {
+			return eosio.Action{}, fmt.Errorf("token %s is not supported by the market", quantity.Symbol)
+		}
+		contract = t.Contract
+	}
+
+	b := builder{contract: contract, permission: m.Permission}
+	return b.action("transfer", account, TokenTransferArgs{
+		From:     account,
+		To:       m.builder().contract,
+		Quantity: Quantity(quantity),
+		Memo:     MarketMemoDeposit,
+	})
+}
+
+// supportedToken returns an error if the market does not support the token.
+func (m AtomicMarket) supportedToken(token atomicasset.Token) error {
+	t, ok := m.Config.FindToken(token.Symbol)
+	if !ok {
+		return fmt.Errorf("token %s is not supported by the market", token.Symbol)
+	}
+
+	if t.Precision != token.Precision {
+		return fmt.Errorf("token %s has precision %d, not %d", token.Symbol, t.Precision, token.Precision)
+	}
+	return nil
+}
+
+// settlementSymbol returns the symbol a sale listed at price is settled in.
+func (m AtomicMarket) settlementSymbol(price atomicasset.Token, settlement string) (Symbol, error) {
+	if len(settlement) < 1 || settlement == price.Symbol {
+		if err := m.supportedToken(price); err != nil {
+			return Symbol{}, err
+		}
+		return Symbol{Precision: price.Precision, Code: price.Symbol}, nil
+	}
+
+	if _, ok := m.Config.FindPair(price.Symbol, settlement); !ok {
+		return Symbol{}, atomicasset.ErrNoDelphiPair
+	}
+
+	t, ok := m.Config.FindToken(settlement)
+	if !ok {
+		return Symbol{}, fmt.Errorf("token %s is not supported by the market", settlement)
+	}
+	return Symbol{Precision: t.Precision, Code: t.Symbol}, nil
+}
+
+// listingPrice returns the price a sale was listed at.
+func (m AtomicMarket) listingPrice(sale atomicasset.Sale) (atomicasset.Token, error) {
+	if !sale.UsesDelphi() {
+		return sale.Price, nil
+	}
+
+	pair, ok := m.Config.FindPair(sale.ListingSymbol, sale.Price.Symbol)
+	if !ok {
+		return atomicasset.Token{}, atomicasset.ErrNoDelphiPair
+	}
+
+	median, err := pair.DelphiMedian()
+	if err != nil {
+		return atomicasset.Token{}, err
+	}
+
+	return atomicasset.Token{
+		Symbol:    sale.ListingSymbol,
+		Precision: pair.ListingPrecision(median),
+		Amount:    sale.ListingPrice.String(),
+	}, nil
+}
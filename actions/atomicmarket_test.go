@@ -0,0 +1,256 @@
+package actions
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/eosswedenorg-go/atomicasset"
+	"github.com/eosswedenorg-go/atomicasset/eosio"
+	"github.com/eosswedenorg-go/unixtime"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testMarket = AtomicMarket{
+	Marketplace: "market.place",
+	Config: atomicasset.MarketConfig{
+		AtomicassetsContract:   "atomicassets",
+		AtomicmarketContract:   "atomicmarket",
+		MinimumAuctionDuration: 120,
+		MaximumAuctionDuration: 2592000,
+		MinimumBidIncrease:     0.1,
+		SupportedTokens: []atomicasset.PriceToken{
+			{Contract: "eosio.token", Symbol: "WAX", Precision: 8},
+		},
+		SupportedPairs: []atomicasset.TokenPair{
+			{
+				ListingSymbol:    "USD",
+				SettlementSymbol: "WAX",
+				DelphiPairName:   "waxpusd",
+				Data: map[string]interface{}{
+					"median":           595,
+					"median_precision": 4,
+					"base_precision":   8,
+					"quote_precision":  2,
+				},
+			},
+		},
+	},
+}
+
+func wax(amount string) atomicasset.Token {
+	return atomicasset.Token{Contract: "eosio.token", Symbol: "WAX", Precision: 8, Amount: amount}
+}
+
+// actionSummary returns "contract::name actor" for each action.
+func actionSummary(actions []eosio.Action) []string {
+	out := []string{}
+	for _, a := range actions {
+		out = append(out, a.Account+"::"+a.Name+" "+a.Authorization[0].Actor)
+	}
+	return out
+}
+
+func actionJSON(t *testing.T, a eosio.Action) string {
+	b, err := json.Marshal(a.Data)
+	require.NoError(t, err)
+	return string(b)
+}
+
+func TestAtomicMarket_ListSale(t *testing.T) {
+	assets := []atomicasset.Asset{testAsset("1", "alice")}
+
+	actions, err := testMarket.ListSale("alice", assets, wax("150000000"), "")
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"atomicmarket::announcesale alice", "atomicassets::createoffer alice"}, actionSummary(actions))
+	assert.JSONEq(t, `{
+		"seller": "alice",
+		"asset_ids": ["1"],
+		"listing_price": "1.50000000 WAX",
+		"settlement_symbol": "8,WAX",
+		"maker_marketplace": "market.place"
+	}`, actionJSON(t, actions[0]))
+	assert.Equal(t, "0000000000855c34"+"01"+"0100000000000000"+"80d1f00800000000"+"0857415800000000"+"0857415800000000"+"a09089156405af91", actions[0].HexData)
+
+	assert.JSONEq(t, `{
+		"sender": "alice",
+		"recipient": "atomicmarket",
+		"sender_asset_ids": ["1"],
+		"recipient_asset_ids": [],
+		"memo": "sale"
+	}`, actionJSON(t, actions[1]))
+
+	// Delphi listing.
+	actions, err = testMarket.ListSale("alice", assets, atomicasset.Token{Symbol: "USD", Precision: 2, Amount: "100"}, "WAX")
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"seller": "alice",
+		"asset_ids": ["1"],
+		"listing_price": "1.00 USD",
+		"settlement_symbol": "8,WAX",
+		"maker_marketplace": "market.place"
+	}`, actionJSON(t, actions[0]))
+
+	_, err = testMarket.ListSale("alice", assets, atomicasset.Token{Symbol: "EUR", Precision: 2, Amount: "100"}, "WAX")
+	assert.Equal(t, atomicasset.ErrNoDelphiPair, err)
+
+	_, err = testMarket.ListSale("alice", assets, atomicasset.Token{Symbol: "WAX", Precision: 4, Amount: "100"}, "")
+	assert.EqualError(t, err, "token WAX has precision 8, not 4")
+
+	_, err = testMarket.ListSale("alice", nil, wax("1"), "")
+	assert.EqualError(t, err, "no assets to sell")
+}
+
+func TestAtomicMarket_PurchaseSale(t *testing.T) {
+	sale := atomicasset.Sale{
+		ID:            "42",
+		Seller:        "alice",
+		Price:         wax("150000000"),
+		ListingPrice:  "150000000",
+		ListingSymbol: "WAX",
+		Assets:        []atomicasset.Asset{testAsset("1", "alice"), testAsset("2", "alice")},
+		State:         atomicasset.SalesStateListed,
+	}
+
+	actions, err := testMarket.PurchaseSale("bob", sale)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{
+		"atomicmarket::assertsale bob",
+		"eosio.token::transfer bob",
+		"atomicmarket::purchasesale bob",
+	}, actionSummary(actions))
+
+	assert.JSONEq(t, `{
+		"sale_id": "42",
+		"asset_ids_to_assert": ["1", "2"],
+		"listing_price_to_assert": "1.50000000 WAX",
+		"settlement_symbol_to_assert": "8,WAX"
+	}`, actionJSON(t, actions[0]))
+	assert.JSONEq(t, `{"from": "bob", "to": "atomicmarket", "quantity": "1.50000000 WAX", "memo": "deposit"}`, actionJSON(t, actions[1]))
+	assert.JSONEq(t, `{"buyer": "bob", "sale_id": "42", "intended_delphi_median": 0, "taker_marketplace": "market.place"}`, actionJSON(t, actions[2]))
+
+	// Delphi sale.
+	sale.ListingPrice = "100"
+	sale.ListingSymbol = "USD"
+
+	actions, err = testMarket.PurchaseSale("bob", sale)
+	require.NoError(t, err)
+
+	assert.JSONEq(t, `{
+		"sale_id": "42",
+		"asset_ids_to_assert": ["1", "2"],
+		"listing_price_to_assert": "1.00 USD",
+		"settlement_symbol_to_assert": "8,WAX"
+	}`, actionJSON(t, actions[0]))
+	assert.JSONEq(t, `{"from": "bob", "to": "atomicmarket", "quantity": "16.80672268 WAX", "memo": "deposit"}`, actionJSON(t, actions[1]))
+	assert.JSONEq(t, `{"buyer": "bob", "sale_id": "42", "intended_delphi_median": 595, "taker_marketplace": "market.place"}`, actionJSON(t, actions[2]))
+
+	_, err = testMarket.PurchaseSale("alice", sale)
+	assert.EqualError(t, err, "can't purchase your own sale")
+
+	sale.State = atomicasset.SalesStateSold
+	_, err = testMarket.PurchaseSale("bob", sale)
+	assert.EqualError(t, err, "sale 42 is not listed (sold)")
+}
+
+func TestAtomicMarket_Auction(t *testing.T) {
+	assets := []atomicasset.Asset{testAsset("1", "alice")}
+
+	actions, err := testMarket.ListAuction("alice", assets, wax("100000000"), 24*time.Hour)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"atomicmarket::announceauct alice", "atomicassets::transfer alice"}, actionSummary(actions))
+	assert.JSONEq(t, `{
+		"seller": "alice",
+		"asset_ids": ["1"],
+		"starting_bid": "1.00000000 WAX",
+		"duration": 86400,
+		"maker_marketplace": "market.place"
+	}`, actionJSON(t, actions[0]))
+	assert.JSONEq(t, `{"from": "alice", "to": "atomicmarket", "asset_ids": ["1"], "memo": "auction"}`, actionJSON(t, actions[1]))
+
+	_, err = testMarket.ListAuction("alice", assets, wax("100000000"), time.Minute)
+	assert.EqualError(t, err, "auction duration 1m0s is shorter than the minimum 2m0s")
+
+	now := time.Unix(1700000000, 0)
+	auction := atomicasset.Auction{
+		ID:      "9",
+		Seller:  "alice",
+		Price:   wax("100000000"),
+		EndTime: unixtime.Time(now.Add(time.Hour).UnixMilli()),
+		State:   atomicasset.AuctionStateListed,
+	}
+
+	actions, err = testMarket.Bid("bob", auction, wax("100000000"), now)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"eosio.token::transfer bob", "atomicmarket::auctionbid bob"}, actionSummary(actions))
+	assert.JSONEq(t, `{"from": "bob", "to": "atomicmarket", "quantity": "1.00000000 WAX", "memo": "deposit"}`, actionJSON(t, actions[0]))
+	assert.JSONEq(t, `{"bidder": "bob", "auction_id": "9", "bid": "1.00000000 WAX", "taker_marketplace": "market.place"}`, actionJSON(t, actions[1]))
+
+	auction.Buyer = "carol"
+	_, err = testMarket.Bid("bob", auction, wax("105000000"), now)
+	assert.EqualError(t, err, "bid 1.05000000 WAX is lower than the minimum bid 1.10000001 WAX")
+
+	// 100000000 * 1.1 is slightly above 110000000 as a double, the contract rejects it.
+	_, err = testMarket.Bid("bob", auction, wax("110000000"), now)
+	assert.EqualError(t, err, "bid 1.10000000 WAX is lower than the minimum bid 1.10000001 WAX")
+
+	_, err = testMarket.Bid("bob", auction, wax("110000001"), now)
+	assert.NoError(t, err)
+
+	_, err = testMarket.Bid("bob", auction, wax("110000000"), now.Add(2*time.Hour))
+	assert.EqualError(t, err, "auction 9 is not active")
+
+	_, err = testMarket.ClaimAuctionBuyer(auction, now)
+	assert.EqualError(t, err, "auction 9 has not ended")
+
+	a, err := testMarket.ClaimAuctionBuyer(auction, now.Add(2*time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, "auctclaimbuy", a.Name)
+	assert.Equal(t, "carol", a.Authorization[0].Actor)
+	assert.Equal(t, "0900000000000000", a.HexData)
+
+	a, err = testMarket.ClaimAuctionSeller(auction, now.Add(2*time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, "auctclaimsel", a.Name)
+	assert.Equal(t, "alice", a.Authorization[0].Actor)
+
+	auction.ClaimedBySeller = true
+	_, err = testMarket.ClaimAuctionSeller(auction, now.Add(2*time.Hour))
+	assert.EqualError(t, err, "auction 9 is already claimed by the seller")
+}
+
+func TestAtomicMarket_BuyOffer(t *testing.T) {
+	assets := []atomicasset.Asset{testAsset("1", "alice")}
+
+	actions, err := testMarket.CreateBuyOffer("bob", "alice", assets, wax("500000000"), "please")
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"eosio.token::transfer bob", "atomicmarket::createbuyo bob"}, actionSummary(actions))
+	assert.JSONEq(t, `{"from": "bob", "to": "atomicmarket", "quantity": "5.00000000 WAX", "memo": "deposit"}`, actionJSON(t, actions[0]))
+	assert.JSONEq(t, `{
+		"buyer": "bob",
+		"recipient": "alice",
+		"price": "5.00000000 WAX",
+		"asset_ids": ["1"],
+		"memo": "please",
+		"maker_marketplace": "market.place"
+	}`, actionJSON(t, actions[1]))
+
+	_, err = testMarket.CreateBuyOffer("bob", "carol", assets, wax("500000000"), "")
+	assert.EqualError(t, err, "asset 1 is not owned by 'carol'")
+
+	offer := atomicasset.BuyOffer{ID: "3", Buyer: "bob", State: atomicasset.BuyOfferStatePending}
+	a, err := testMarket.CancelBuyOffer(offer)
+	require.NoError(t, err)
+	assert.Equal(t, "cancelbuyo", a.Name)
+	assert.Equal(t, "bob", a.Authorization[0].Actor)
+
+	offer.State = atomicasset.BuyOfferStateAccepted
+	_, err = testMarket.CancelBuyOffer(offer)
+	assert.EqualError(t, err, "buyoffer 3 is not pending (accepted)")
+}
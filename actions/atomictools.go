@@ -0,0 +1,185 @@
+package actions
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/eosswedenorg-go/atomicasset"
+	"github.com/eosswedenorg-go/atomicasset/eosio"
+)
+
+// DefaultAtomicToolsContract is the account of the atomictools contract.
+const DefaultAtomicToolsContract = "atomictoolsx"
+
+// ToolsMemoLink is the memo the atomictools contract expects on asset transfers to a link.
+const ToolsMemoLink = "link"
+
+// DefaultClaimURL is the base of claim urls created by ClaimURL.
+const DefaultClaimURL = "https://wax.atomichub.io/trading/link"
+
+// Types
+
+// AtomicTools builds actions for the atomictools contract.
+type AtomicTools struct {
+	// Config of the tools contract. The contract accounts are read from it.
+	Config atomicasset.ToolsConfig
+
+	// Permission used to authorize the actions, DefaultPermission if empty.
+	Permission string
+}
+
+func (t AtomicTools) builder() builder {
+	contract := t.Config.AtomictoolsContract
+	if len(contract) < 1 {
+		contract = DefaultAtomicToolsContract
+	}
+	return builder{contract: contract, permission: t.Permission}
+}
+
+// Action data
+
+type AnnounceLinkArgs struct {
+	Creator  string          `json:"creator"`
+	Key      eosio.PublicKey `json:"key"`
+	AssetIDs Uint64s         `json:"asset_ids"`
+	Memo     string          `json:"memo"`
+}
+
+func (d AnnounceLinkArgs) Pack(e *eosio.Encoder) {
+	e.Name(d.Creator)
+	d.Key.Pack(e)
+	d.AssetIDs.Pack(e)
+	e.String(d.Memo)
+}
+
+type ClaimLinkArgs struct {
+	LinkID           string          `json:"link_id"`
+	Claimer          string          `json:"claimer"`
+	ClaimerSignature eosio.Signature `json:"claimer_signature"`
+}
+
+func (d ClaimLinkArgs) Pack(e *eosio.Encoder) {
+	packUint64(e, d.LinkID)
+	e.Name(d.Claimer)
+	d.ClaimerSignature.Pack(e)
+}
+
+// LinkArgs is the data of cancellink.
+type LinkArgs struct {
+	LinkID string `json:"link_id"`
+}
+
+func (d LinkArgs) Pack(e *eosio.Encoder) {
+	packUint64(e, d.LinkID)
+}
+
+// Transaction builders
+
+// CreateLink creates a claim link for assets that can be claimed with the
+// private key of key.
+//
+// The transaction is announcelink followed by a transfer of the assets to
+// the tools contract. The id of the link is assigned by the contract when
+// the transaction is executed.
+func (t AtomicTools) CreateLink(creator string, assets []atomicasset.Asset, key eosio.PublicKey, memo string) ([]eosio.Action, error) {
+	ids, err := transferableIDs(creator, assets)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(ids) < 1 {
+		return nil, errors.New("link has no assets")
+	}
+
+	announce, err := t.builder().action("announcelink", creator, AnnounceLinkArgs{
+		Creator:  creator,
+		Key:      key,
+		AssetIDs: ids,
+		Memo:     memo,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	aa := AtomicAssets{Contract: t.Config.AtomicassetsContract, Permission: t.Permission}
+	transfer, err := aa.Transfer(creator, t.builder().contract, assets, ToolsMemoLink)
+	if err != nil {
+		return nil, err
+	}
+	return []eosio.Action{announce, transfer}, nil
+}
+
+// ClaimLink claims the assets of a link to claimer using the private key of the link.
+func (t AtomicTools) ClaimLink(link atomicasset.Link, claimer string, key eosio.PrivateKey) (eosio.Action, error) {
+	if link.State != atomicasset.LinkStateCreated {
+		return eosio.Action{}, fmt.Errorf("link %s can not be claimed (%s)", link.ID, link.State)
+	}
+
+	if pub, err := eosio.ParsePublicKey(link.PublicKey); err != nil || pub != key.PublicKey() {
+		return eosio.Action{}, fmt.Errorf("key does not match the key of link %s", link.ID)
+	}
+
+	sig, err := ClaimSignature(key, claimer)
+	if err != nil {
+		return eosio.Action{}, err
+	}
+
+	return t.builder().action("claimlink", claimer, ClaimLinkArgs{
+		LinkID:           link.ID,
+		Claimer:          claimer,
+		ClaimerSignature: sig,
+	})
+}
+
+// CancelLink cancels a link and returns the assets to the creator.
+func (t AtomicTools) CancelLink(link atomicasset.Link) (eosio.Action, error) {
+	if link.State != atomicasset.LinkStateWaiting && link.State != atomicasset.LinkStateCreated {
+		return eosio.Action{}, fmt.Errorf("link %s can not be canceled (%s)", link.ID, link.State)
+	}
+	return t.builder().action("cancellink", link.Creator, LinkArgs{LinkID: link.ID})
+}
+
+// Claim signatures and urls
+
+// ClaimSignature returns the signature claimlink requires from claimer,
+// a signature of the sha256 hash of the claimer name as a string
+// (the contract hashes claimer.to_string(), not the packed name).
+func ClaimSignature(key eosio.PrivateKey, claimer string) (eosio.Signature, error) {
+	if _, err := eosio.NameToUint64(claimer); err != nil {
+		return eosio.Signature{}, err
+	}
+	return key.Sign(sha256.Sum256([]byte(claimer)))
+}
+
+// ClaimURL returns a shareable url to claim a link.
+// base is the url of the claim page, DefaultClaimURL is used if empty.
+func ClaimURL(base string, linkID string, key eosio.PrivateKey) string {
+	if len(base) < 1 {
+		base = DefaultClaimURL
+	}
+	return strings.TrimSuffix(base, "/") + "/" + url.PathEscape(linkID) + "?key=" + url.QueryEscape(key.String())
+}
+
+// ParseClaimURL returns the link id and private key of a claim url created by ClaimURL.
+func ParseClaimURL(s string) (string, eosio.PrivateKey, error) {
+	u, err := url.Parse(s)
+	if err != nil {
+		return "", eosio.PrivateKey{}, fmt.Errorf("invalid claim url: %s", err)
+	}
+
+	id := path.Base(u.Path)
+	if _, err := strconv.ParseUint(id, 10, 64); err != nil {
+		return "", eosio.PrivateKey{}, errors.New("invalid claim url: no link id")
+	}
+
+	key, err := eosio.ParsePrivateKey(u.Query().Get("key"))
+	if err != nil {
+		return "", eosio.PrivateKey{}, fmt.Errorf("invalid claim url: %s", err)
+	}
+	return id, key, nil
+}
@@ -0,0 +1,114 @@
+package actions
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/eosswedenorg-go/atomicasset"
+	"github.com/eosswedenorg-go/atomicasset/eosio"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testLinkKey = "5KQwrPbwdL6PhXujxW37FSSQZ1JiwsST4cqQzDeyXtP79zkvFD3"
+
+func TestAtomicTools_CreateLink(t *testing.T) {
+	key, err := eosio.ParsePrivateKey(testLinkKey)
+	require.NoError(t, err)
+
+	actions, err := AtomicTools{}.CreateLink("alice", []atomicasset.Asset{testAsset("1", "alice")}, key.PublicKey(), "gift")
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"atomictoolsx::announcelink alice", "atomicassets::transfer alice"}, actionSummary(actions))
+	assert.JSONEq(t, `{
+		"creator": "alice",
+		"key": "EOS6MRyAjQq8ud7hVNYcfnVPJqcVpscN5So8BhtHuGYqET5GDW5CV",
+		"asset_ids": ["1"],
+		"memo": "gift"
+	}`, actionJSON(t, actions[0]))
+
+	pub := key.PublicKey()
+	assert.Equal(t, "0000000000855c34"+"00"+hex.EncodeToString(pub[:])+"01"+"0100000000000000"+"0467696674", actions[0].HexData)
+
+	assert.JSONEq(t, `{"from": "alice", "to": "atomictoolsx", "asset_ids": ["1"], "memo": "link"}`, actionJSON(t, actions[1]))
+
+	_, err = AtomicTools{}.CreateLink("alice", nil, key.PublicKey(), "")
+	assert.EqualError(t, err, "link has no assets")
+}
+
+func TestAtomicTools_ClaimLink(t *testing.T) {
+	key, err := eosio.ParsePrivateKey(testLinkKey)
+	require.NoError(t, err)
+
+	link := atomicasset.Link{
+		ID:        "12",
+		Creator:   "alice",
+		State:     atomicasset.LinkStateCreated,
+		PublicKey: key.PublicKey().K1String(),
+	}
+
+	a, err := AtomicTools{}.ClaimLink(link, "bob", key)
+	require.NoError(t, err)
+	assert.Equal(t, "claimlink", a.Name)
+	assert.Equal(t, "bob", a.Authorization[0].Actor)
+
+	args := a.Data.(ClaimLinkArgs)
+	assert.Equal(t, "12", args.LinkID)
+	assert.Equal(t, "bob", args.Claimer)
+
+	// The signature must recover to the key of the link from sha256("bob").
+	digest, err := hex.DecodeString("81b637d8fcd2c6da6359e6963113a1170de795e4b725b84d1e0b4cfd9ec58ce9")
+	require.NoError(t, err)
+
+	var d [32]byte
+	copy(d[:], digest)
+	pub, err := args.ClaimerSignature.PublicKey(d)
+	require.NoError(t, err)
+	assert.Equal(t, key.PublicKey(), pub)
+	assert.Equal(t, "SIG_K1_K7guRFn6o4WaCrRiDfAV2ABCoGqUgmcyA8cr9EEjX4qNyoPtijod8cDd7aGcEDZVozMHENwiELg7u9nxgQuNzmnEjBgY5R", args.ClaimerSignature.String())
+
+	sig := args.ClaimerSignature
+	assert.Equal(t, "0c00000000000000"+"0000000000000e3d"+"00"+hex.EncodeToString(sig[:]), a.HexData)
+
+	other, err := eosio.NewPrivateKey()
+	require.NoError(t, err)
+	_, err = AtomicTools{}.ClaimLink(link, "bob", other)
+	assert.EqualError(t, err, "key does not match the key of link 12")
+
+	link.State = atomicasset.LinkStateClaimed
+	_, err = AtomicTools{}.ClaimLink(link, "bob", key)
+	assert.EqualError(t, err, "link 12 can not be claimed (claimed)")
+
+	_, err = AtomicTools{}.CancelLink(link)
+	assert.EqualError(t, err, "link 12 can not be canceled (claimed)")
+
+	link.State = atomicasset.LinkStateCreated
+	a, err = AtomicTools{}.CancelLink(link)
+	require.NoError(t, err)
+	assert.Equal(t, "alice", a.Authorization[0].Actor)
+	assert.Equal(t, "0c00000000000000", a.HexData)
+}
+
+func TestClaimURL(t *testing.T) {
+	key, err := eosio.ParsePrivateKey(testLinkKey)
+	require.NoError(t, err)
+
+	u := ClaimURL("", "12", key)
+	assert.Equal(t, "https://wax.atomichub.io/trading/link/12?key="+testLinkKey, u)
+
+	id, parsed, err := ParseClaimURL(u)
+	require.NoError(t, err)
+	assert.Equal(t, "12", id)
+	assert.Equal(t, key, parsed)
+
+	id, parsed, err = ParseClaimURL(ClaimURL("https://example.com/claim/", "7", key))
+	require.NoError(t, err)
+	assert.Equal(t, "7", id)
+	assert.Equal(t, key, parsed)
+
+	_, _, err = ParseClaimURL("https://wax.atomichub.io/trading/link/abc?key=" + testLinkKey)
+	assert.EqualError(t, err, "invalid claim url: no link id")
+
+	_, _, err = ParseClaimURL("https://wax.atomichub.io/trading/link/12")
+	assert.EqualError(t, err, "invalid claim url: invalid private key")
+}
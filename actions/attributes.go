@@ -0,0 +1,220 @@
+package actions
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/eosswedenorg-go/atomicasset"
+	"github.com/eosswedenorg-go/atomicasset/eosio"
+)
+
+// Names of the ATOMIC_ATTRIBUTE variant types, indexed by variant index.
+var attributeVariantNames = []string{
+	"int8", "int16", "int32", "int64",
+	"uint8", "uint16", "uint32", "uint64",
+	"float32", "float64", "string",
+	"INT8_VEC", "INT16_VEC", "INT32_VEC", "INT64_VEC",
+	"UINT8_VEC", "UINT16_VEC", "UINT32_VEC", "UINT64_VEC",
+	"FLOAT_VEC", "DOUBLE_VEC", "STRING_VEC",
+}
+
+// Index of the first vector type in attributeVariantNames.
+const attributeVectorOffset = 11
+
+// Variant index of the schema types that are not arrays.
+var attributeVariants = map[string]int{
+	"int8":    0,
+	"int16":   1,
+	"int32":   2,
+	"int64":   3,
+	"uint8":   4,
+	"fixed8":  4,
+	"bool":    4,
+	"uint16":  5,
+	"fixed16": 5,
+	"uint32":  6,
+	"fixed32": 6,
+	"uint64":  7,
+	"fixed64": 7,
+	"float":   8,
+	"double":  9,
+	"string":  10,
+	"image":   10,
+	"ipfs":    10,
+	"bytes":   attributeVectorOffset + 4,
+}
+
+// attributeVariant returns the variant index used to send a value of the schema type typ.
+func attributeVariant(typ string) (int, bool) {
+	if strings.HasSuffix(typ, "[]") {
+		i, ok := attributeVariants[strings.TrimSuffix(typ, "[]")]
+		if !ok || i >= attributeVectorOffset {
+			return 0, false
+		}
+		return i + attributeVectorOffset, true
+	}
+
+	i, ok := attributeVariants[typ]
+	return i, ok
+}
+
+// AttributeMap is the ATTRIBUTE_MAP type used by the atomicassets contract
+// for asset, template and collection data.
+//
+// The values must have the go types returned by atomicasset.DecodeAttributes.
+type AttributeMap []atomicasset.Attribute
+
+// NewAttributeMap validates data against format and returns the
+// attributes in the order they are declared in format.
+func NewAttributeMap(data map[string]interface{}, format []atomicasset.SchemaFormat) (AttributeMap, error) {
+	attrs, err := atomicasset.DecodeAttributes(data, format)
+	if err != nil {
+		return nil, err
+	}
+
+	m := AttributeMap{}
+	for _, f := range format {
+		if a, ok := attrs[f.Name]; ok {
+			m = append(m, a)
+		}
+	}
+	return m, nil
+}
+
+func (m AttributeMap) Pack(e *eosio.Encoder) {
+	e.Length(len(m))
+	for _, a := range m {
+		index, ok := attributeVariant(a.Type)
+		if !ok {
+			e.SetError(fmt.Errorf("attribute '%s': unsupported type '%s'", a.Name, a.Type))
+			return
+		}
+
+		e.String(a.Name)
+		e.VarUint32(uint32(index))
+		if err := packAttributeValue(e, index, a.Value); err != nil {
+			e.SetError(fmt.Errorf("attribute '%s': %s", a.Name, err))
+			return
+		}
+	}
+}
+
+func packAttributeValue(e *eosio.Encoder, index int, v interface{}) error {
+	if index >= attributeVectorOffset {
+		rv := reflect.ValueOf(v)
+		if rv.Kind() != reflect.Slice {
+			return fmt.Errorf("expected array, got %T", v)
+		}
+
+		e.Length(rv.Len())
+		for i := 0; i < rv.Len(); i++ {
+			if err := packAttributeValue(e, index-attributeVectorOffset, rv.Index(i).Interface()); err != nil {
+				return fmt.Errorf("index %d: %s", i, err)
+			}
+		}
+		return nil
+	}
+
+	switch v := v.(type) {
+	case int64:
+		switch index {
+		case 0:
+			e.Int8(int8(v))
+		case 1:
+			e.Int16(int16(v))
+		case 2:
+			e.Int32(int32(v))
+		case 3:
+			e.Int64(v)
+		default:
+			return fmt.Errorf("unexpected value %T", v)
+		}
+	case uint64:
+		switch index {
+		case 4:
+			e.Uint8(uint8(v))
+		case 5:
+			e.Uint16(uint16(v))
+		case 6:
+			e.Uint32(uint32(v))
+		case 7:
+			e.Uint64(v)
+		default:
+			return fmt.Errorf("unexpected value %T", v)
+		}
+	case uint8:
+		if index != 4 {
+			return fmt.Errorf("unexpected value %T", v)
+		}
+		e.Uint8(v)
+	case bool:
+		if index != 4 {
+			return fmt.Errorf("unexpected value %T", v)
+		}
+		e.Bool(v)
+	case float64:
+		switch index {
+		case 8:
+			e.Float32(float32(v))
+		case 9:
+			e.Float64(v)
+		default:
+			return fmt.Errorf("unexpected value %T", v)
+		}
+	case string:
+		if index != 10 {
+			return fmt.Errorf("unexpected value %T", v)
+		}
+		e.String(v)
+	default:
+		return fmt.Errorf("unexpected value %T", v)
+	}
+	return nil
+}
+
+// MarshalJSON encodes the map in the format expected by the chain api:
+//
+//	[{"key": "name", "value": ["string", "Hero"]}]
+func (m AttributeMap) MarshalJSON() ([]byte, error) {
+	type pair struct {
+		Key   string         `json:"key"`
+		Value [2]interface{} `json:"value"`
+	}
+
+	out := []pair{}
+	for _, a := range m {
+		index, ok := attributeVariant(a.Type)
+		if !ok {
+			return nil, fmt.Errorf("attribute '%s': unsupported type '%s'", a.Name, a.Type)
+		}
+		out = append(out, pair{Key: a.Name, Value: [2]interface{}{attributeVariantNames[index], jsonAttributeValue(a.Value)}})
+	}
+	return json.Marshal(out)
+}
+
+// jsonAttributeValue converts values that json would encode differently
+// than the chain api expects (byte slices and booleans) to numbers.
+func jsonAttributeValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case bool:
+		if v {
+			return 1
+		}
+		return 0
+	case []bool:
+		out := make([]int, len(v))
+		for i, b := range v {
+			out[i] = jsonAttributeValue(b).(int)
+		}
+		return out
+	case []byte:
+		out := make([]int, len(v))
+		for i, b := range v {
+			out[i] = int(b)
+		}
+		return out
+	}
+	return v
+}
@@ -0,0 +1,289 @@
+package alert
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/eosswedenorg-go/atomicasset"
+)
+
+// Listing is a sale, auction or buyoffer in the form rules are evaluated on.
+type Listing struct {
+	Kind       Kind                   `json:"kind"`
+	ID         string                 `json:"id"`
+	Price      atomicasset.Token      `json:"price"`
+	Assets     []atomicasset.Asset    `json:"assets"`
+	Collection atomicasset.Collection `json:"collection"`
+
+	// EndTime and Bids are only set for auctions.
+	EndTime time.Time `json:"end_time,omitempty"`
+	Bids    int       `json:"bids,omitempty"`
+
+	// The listing that was converted, only one of them is set.
+	Sale     *atomicasset.Sale     `json:"sale,omitempty"`
+	Auction  *atomicasset.Auction  `json:"auction,omitempty"`
+	BuyOffer *atomicasset.BuyOffer `json:"buyoffer,omitempty"`
+}
+
+// SaleListing converts a sale to a listing.
+func SaleListing(s atomicasset.Sale) Listing {
+	return Listing{
+		Kind:       KindSale,
+		ID:         s.ID,
+		Price:      s.Price,
+		Assets:     s.Assets,
+		Collection: s.Collection,
+		Sale:       &s,
+	}
+}
+
+// AuctionListing converts an auction to a listing.
+func AuctionListing(a atomicasset.Auction) Listing {
+	bids := len(a.Bids)
+	if bids < 1 && len(a.Buyer) > 0 {
+		bids = 1
+	}
+
+	return Listing{
+		Kind:       KindAuction,
+		ID:         a.ID,
+		Price:      a.Price,
+		Assets:     a.Assets,
+		Collection: a.Collection,
+		EndTime:    a.EndTime.Time(),
+		Bids:       bids,
+		Auction:    &a,
+	}
+}
+
+// BuyOfferListing converts a buyoffer to a listing.
+func BuyOfferListing(b atomicasset.BuyOffer) Listing {
+	return Listing{
+		Kind:       KindBuyOffer,
+		ID:         b.ID,
+		Price:      b.Price,
+		Assets:     b.Assets,
+		Collection: b.Collection,
+		BuyOffer:   &b,
+	}
+}
+
+// Alert is sent to the sinks when a rule matches a listing.
+type Alert struct {
+	Rule    string    `json:"rule"`
+	Time    time.Time `json:"time"`
+	Listing Listing   `json:"listing"`
+}
+
+// Engine evaluates rules and sends alerts to its sinks.
+//
+// An alert is only sent once per rule and listing, and no alerts are sent
+// for a rule within its cooldown (those listings can still fire later).
+//
+// Sent alerts are forgotten once the rule's cooldown has expired, or for
+// rules without a cooldown, once the listing is no longer evaluated. So a
+// listing can fire again after the cooldown of its rule.
+type Engine struct {
+	rules []Rule
+	sinks []Sink
+
+	// Now returns the current time, defaults to time.Now.
+	Now func() time.Time
+
+	mu    sync.Mutex
+	fired map[string]firedAlert
+	last  map[string]time.Time
+}
+
+// firedAlert is when an alert was sent for a rule and listing.
+type firedAlert struct {
+	time     time.Time
+	cooldown time.Duration
+}
+
+// NewEngine validates rules and creates an engine sending alerts to sinks.
+func NewEngine(rules []Rule, sinks ...Sink) (*Engine, error) {
+	names := map[string]bool{}
+	compiled := []Rule{}
+	for _, r := range rules {
+		if err := r.compile(); err != nil {
+			return nil, err
+		}
+
+		if names[r.Name] {
+			return nil, fmt.Errorf("duplicate rule '%s'", r.Name)
+		}
+		names[r.Name] = true
+		compiled = append(compiled, r)
+	}
+
+	return &Engine{
+		rules: compiled,
+		sinks: sinks,
+		Now:   time.Now,
+		fired: map[string]firedAlert{},
+		last:  map[string]time.Time{},
+	}, nil
+}
+
+// Evaluate checks listings against all rules and sends the alerts.
+// The first error returned by a sink is returned after all listings are evaluated.
+func (e *Engine) Evaluate(ctx context.Context, listings ...Listing) error {
+	alerts := e.match(listings)
+
+	var first error
+	for _, a := range alerts {
+		for _, s := range e.sinks {
+			if err := s.Send(ctx, a); err != nil && first == nil {
+				first = err
+			}
+		}
+	}
+	return first
+}
+
+// match returns the alerts for listings and records them as fired.
+func (e *Engine) match(listings []Listing) []Alert {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	now := e.Now()
+	alerts := []Alert{}
+	seen := map[string]bool{}
+	for i := range e.rules {
+		r := &e.rules[i]
+		for _, l := range listings {
+			key := r.Name + "/" + string(l.Kind) + "/" + l.ID
+			seen[key] = true
+
+			if _, ok := e.fired[key]; ok || !r.matches(l, now) {
+				continue
+			}
+
+			if last, ok := e.last[r.Name]; ok && r.Cooldown > 0 && now.Sub(last) < r.Cooldown {
+				continue
+			}
+
+			e.fired[key] = firedAlert{time: now, cooldown: r.Cooldown}
+			e.last[r.Name] = now
+			alerts = append(alerts, Alert{Rule: r.Name, Time: now, Listing: l})
+		}
+	}
+
+	e.prune(now, seen)
+	return alerts
+}
+
+// prune forgets fired alerts whose cooldown has expired, and for rules
+// without a cooldown, the ones whose listing was not evaluated.
+func (e *Engine) prune(now time.Time, seen map[string]bool) {
+	for key, f := range e.fired {
+		if f.cooldown > 0 {
+			if now.Sub(f.time) >= f.cooldown {
+				delete(e.fired, key)
+			}
+		} else if !seen[key] {
+			delete(e.fired, key)
+		}
+	}
+}
+
+// Source returns the listings to evaluate, for example by polling the API.
+type Source func(ctx context.Context) ([]Listing, error)
+
+// Run evaluates the listings from source every interval until ctx is done.
+// Errors from source or the sinks are passed to onError if it is not nil.
+func (e *Engine) Run(ctx context.Context, source Source, interval time.Duration, onError func(error)) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		listings, err := source(ctx)
+		if err == nil {
+			err = e.Evaluate(ctx, listings...)
+		}
+
+		if err != nil && onError != nil && ctx.Err() == nil {
+			onError(err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// APISource returns a source that polls the latest listed sales, active
+// auctions and pending buyoffers (limit of each) from client.
+// Only the kinds given are fetched, all kinds if none are given.
+func APISource(client *atomicasset.Client, limit int, kinds ...Kind) Source {
+	want := func(k Kind) bool {
+		if len(kinds) < 1 {
+			return true
+		}
+		for _, kind := range kinds {
+			if kind == k {
+				return true
+			}
+		}
+		return false
+	}
+
+	return func(ctx context.Context) ([]Listing, error) {
+		c := client.WithContext(ctx)
+		listings := []Listing{}
+
+		if want(KindSale) {
+			resp, err := c.GetSales(atomicasset.SalesRequestParams{
+				State: atomicasset.SalesStateListed,
+				Limit: limit,
+				Order: atomicasset.SortDescending,
+				Sort:  atomicasset.SaleSortCreated,
+			})
+			if err != nil {
+				return nil, err
+			}
+
+			for _, s := range resp.Data {
+				listings = append(listings, SaleListing(s))
+			}
+		}
+
+		if want(KindAuction) {
+			resp, err := c.GetAuctions(atomicasset.AuctionsRequestParams{
+				State: atomicasset.AuctionStateListed,
+				Limit: limit,
+				Order: atomicasset.SortDescending,
+				Sort:  atomicasset.SaleSortCreated,
+			})
+			if err != nil {
+				return nil, err
+			}
+
+			for _, a := range resp.Data {
+				listings = append(listings, AuctionListing(a))
+			}
+		}
+
+		if want(KindBuyOffer) {
+			resp, err := c.GetBuyOffers(atomicasset.BuyOffersRequestParams{
+				State: atomicasset.BuyOfferStatePending,
+				Limit: limit,
+				Order: atomicasset.SortDescending,
+				Sort:  atomicasset.BuyOfferSortCreated,
+			})
+			if err != nil {
+				return nil, err
+			}
+
+			for _, b := range resp.Data {
+				listings = append(listings, BuyOfferListing(b))
+			}
+		}
+		return listings, nil
+	}
+}
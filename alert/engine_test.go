@@ -0,0 +1,159 @@
+package alert
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/eosswedenorg-go/atomicasset"
+	"github.com/eosswedenorg-go/unixtime"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEngine_Evaluate(t *testing.T) {
+	now := time.Date(2023, time.January, 1, 12, 0, 0, 0, time.UTC)
+
+	alerts := []Alert{}
+	e, err := NewEngine([]Rule{
+		{Name: "cheap", Kind: KindSale, PriceBelow: "1 WAX"},
+		{Name: "cheap-cooldown", Kind: KindSale, PriceBelow: "1 WAX", Cooldown: time.Minute},
+	}, FuncSink(func(a Alert) { alerts = append(alerts, a) }))
+	require.NoError(t, err)
+	e.Now = func() time.Time { return now }
+
+	sales := []Listing{
+		SaleListing(atomicasset.Sale{ID: "1", Price: wax("10")}),
+		SaleListing(atomicasset.Sale{ID: "2", Price: wax("20")}),
+		SaleListing(atomicasset.Sale{ID: "3", Price: wax("200000000")}),
+	}
+
+	require.NoError(t, e.Evaluate(context.Background(), sales...))
+
+	fired := func() []string {
+		out := []string{}
+		for _, a := range alerts {
+			out = append(out, a.Rule+":"+a.Listing.ID)
+		}
+		alerts = alerts[:0]
+		return out
+	}
+
+	// The cooldown rule only fires once.
+	assert.Equal(t, []string{"cheap:1", "cheap:2", "cheap-cooldown:1"}, fired())
+
+	// Listings are only alerted once per rule.
+	require.NoError(t, e.Evaluate(context.Background(), sales...))
+	assert.Equal(t, []string{}, fired())
+
+	// After the cooldown, the suppressed listing fires.
+	now = now.Add(time.Minute)
+	require.NoError(t, e.Evaluate(context.Background(), sales...))
+	assert.Equal(t, []string{"cheap-cooldown:2"}, fired())
+}
+
+func TestEngine_Evaluate_Prune(t *testing.T) {
+	now := time.Date(2023, time.January, 1, 12, 0, 0, 0, time.UTC)
+
+	e, err := NewEngine([]Rule{
+		{Name: "all", Kind: KindSale},
+		{Name: "cooldown", Kind: KindSale, Cooldown: time.Minute},
+	})
+	require.NoError(t, err)
+	e.Now = func() time.Time { return now }
+
+	sale := SaleListing(atomicasset.Sale{ID: "1"})
+	require.NoError(t, e.Evaluate(context.Background(), sale))
+	assert.Len(t, e.fired, 2)
+
+	// Still listed and within the cooldown.
+	now = now.Add(30 * time.Second)
+	require.NoError(t, e.Evaluate(context.Background(), sale))
+	assert.Len(t, e.fired, 2)
+
+	// The listing is gone, only the cooldown keeps its alert.
+	require.NoError(t, e.Evaluate(context.Background()))
+	assert.Equal(t, []string{"cooldown/sale/1"}, firedKeys(e))
+
+	now = now.Add(30 * time.Second)
+	require.NoError(t, e.Evaluate(context.Background()))
+	assert.Empty(t, e.fired)
+}
+
+func firedKeys(e *Engine) []string {
+	keys := []string{}
+	for k := range e.fired {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+type errSink struct{}
+
+func (errSink) Send(ctx context.Context, a Alert) error { return errors.New("sink failed") }
+
+func TestEngine_Evaluate_SinkError(t *testing.T) {
+	e, err := NewEngine([]Rule{{Name: "all", Kind: KindSale}}, errSink{})
+	require.NoError(t, err)
+
+	err = e.Evaluate(context.Background(), SaleListing(atomicasset.Sale{ID: "1"}))
+	assert.EqualError(t, err, "sink failed")
+}
+
+func TestNewEngine_Errors(t *testing.T) {
+	_, err := NewEngine([]Rule{{Name: "a", Kind: KindSale}, {Name: "a", Kind: KindSale}})
+	assert.EqualError(t, err, "duplicate rule 'a'")
+
+	_, err = NewEngine([]Rule{{Name: "a"}})
+	assert.EqualError(t, err, "rule 'a': invalid kind ''")
+}
+
+func TestListings(t *testing.T) {
+	end := time.Date(2023, time.January, 1, 12, 0, 0, 0, time.UTC)
+
+	l := AuctionListing(atomicasset.Auction{ID: "5", Buyer: "bob", EndTime: unixtime.Time(end.UnixMilli())})
+	assert.Equal(t, KindAuction, l.Kind)
+	assert.Equal(t, 1, l.Bids)
+	assert.True(t, end.Equal(l.EndTime))
+	assert.Equal(t, "5", l.Auction.ID)
+
+	l = BuyOfferListing(atomicasset.BuyOffer{ID: "7"})
+	assert.Equal(t, KindBuyOffer, l.Kind)
+	assert.Equal(t, "7", l.BuyOffer.ID)
+}
+
+func TestEngine_Run(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "/atomicmarket/v2/sales", req.URL.Path)
+		assert.Equal(t, "1", req.URL.Query().Get("state"))
+
+		res.Header().Add("Content-type", "application/json; charset=utf-8")
+		_, err := res.Write([]byte(`{"success": true, "data": [
+			{"sale_id": "1", "price": {"token_contract": "eosio.token", "token_symbol": "WAX", "token_precision": 8, "amount": "100"}}
+		], "query_time": 0}`))
+		assert.NoError(t, err)
+	}))
+	defer srv.Close()
+
+	ch := make(chan Alert, 1)
+	e, err := NewEngine([]Rule{{Name: "all", Kind: KindSale}}, ChannelSink(ch))
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- e.Run(ctx, APISource(atomicasset.New(srv.URL), 10, KindSale), time.Millisecond, func(err error) {
+			t.Errorf("unexpected error: %s", err)
+		})
+	}()
+
+	a := <-ch
+	assert.Equal(t, "all", a.Rule)
+	assert.Equal(t, "1", a.Listing.ID)
+
+	cancel()
+	assert.ErrorIs(t, <-done, context.Canceled)
+}
@@ -0,0 +1,215 @@
+// Package alert evaluates rules against sales, auctions and buyoffers and
+// sends an alert to one or more sinks when a rule matches.
+//
+// Rules can be declared in YAML:
+//
+//	rules:
+//	  - name: cheap-template
+//	    kind: sale
+//	    template_id: "12345"
+//	    price_below: "10.00000000 WAX"
+//	  - name: low-mint
+//	    kind: sale
+//	    schema: heroes
+//	    mint_below: 100
+//	  - name: ending-auction
+//	    kind: auction
+//	    ending_within: 5m
+//	    no_bids: true
+//	    cooldown: 1m
+//
+// or in Go, where Match can be used for conditions that can not be expressed
+// with the declarative fields.
+package alert
+
+import (
+	"fmt"
+	"io"
+	"math/big"
+	"strconv"
+	"time"
+
+	"github.com/eosswedenorg-go/atomicasset"
+	"gopkg.in/yaml.v3"
+)
+
+// Kind is the kind of listing a rule applies to.
+type Kind string
+
+const (
+	KindSale     = Kind("sale")
+	KindAuction  = Kind("auction")
+	KindBuyOffer = Kind("buyoffer")
+)
+
+// Rule is a condition that fires an alert for each listing that matches it.
+//
+// All fields that are set must match. Asset fields (template, schema,
+// collection and mint) match if any asset in the listing matches all of them.
+type Rule struct {
+	Name string `yaml:"name"`
+	Kind Kind   `yaml:"kind"`
+
+	Collection string `yaml:"collection"`
+	Schema     string `yaml:"schema"`
+	TemplateID string `yaml:"template_id"`
+
+	// MintBelow matches assets with a template mint lower than the value.
+	MintBelow int `yaml:"mint_below"`
+
+	// PriceBelow and PriceAbove are asset strings ("10.00000000 WAX").
+	// The listing must be in the same symbol.
+	PriceBelow string `yaml:"price_below"`
+	PriceAbove string `yaml:"price_above"`
+
+	// EndingWithin and NoBids only apply to auctions.
+	EndingWithin time.Duration `yaml:"ending_within"`
+	NoBids       bool          `yaml:"no_bids"`
+
+	// Cooldown is the minimum time between two alerts from this rule.
+	Cooldown time.Duration `yaml:"cooldown"`
+
+	// Match is an optional extra condition for rules declared in Go.
+	Match func(Listing) bool `yaml:"-"`
+
+	priceBelow *atomicasset.Token
+	priceAbove *atomicasset.Token
+}
+
+// ParseRules parses rules declared in YAML.
+func ParseRules(b []byte) ([]Rule, error) {
+	var doc struct {
+		Rules []Rule `yaml:"rules"`
+	}
+
+	if err := yaml.Unmarshal(b, &doc); err != nil {
+		return nil, err
+	}
+	return doc.Rules, nil
+}
+
+// LoadRules reads and parses rules declared in YAML from r.
+func LoadRules(r io.Reader) ([]Rule, error) {
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRules(b)
+}
+
+// compile validates the rule and parses the price fields.
+func (r *Rule) compile() error {
+	if len(r.Name) < 1 {
+		return fmt.Errorf("rule has no name")
+	}
+
+	switch r.Kind {
+	case KindSale, KindAuction, KindBuyOffer:
+	default:
+		return fmt.Errorf("rule '%s': invalid kind '%s'", r.Name, r.Kind)
+	}
+
+	if r.Kind != KindAuction && (r.EndingWithin > 0 || r.NoBids) {
+		return fmt.Errorf("rule '%s': ending_within and no_bids only apply to auctions", r.Name)
+	}
+
+	for _, p := range []struct {
+		s   string
+		dst **atomicasset.Token
+	}{{r.PriceBelow, &r.priceBelow}, {r.PriceAbove, &r.priceAbove}} {
+		if len(p.s) < 1 {
+			continue
+		}
+
+		t, err := atomicasset.ParseAsset(p.s)
+		if err != nil {
+			return fmt.Errorf("rule '%s': %s", r.Name, err)
+		}
+		*p.dst = &t
+	}
+	return nil
+}
+
+// matches reports whether listing matches the rule at now.
+func (r *Rule) matches(l Listing, now time.Time) bool {
+	if l.Kind != r.Kind {
+		return false
+	}
+
+	if !r.matchAssets(l) {
+		return false
+	}
+
+	if r.priceBelow != nil && !priceCompare(l.Price, *r.priceBelow, func(c int) bool { return c < 0 }) {
+		return false
+	}
+
+	if r.priceAbove != nil && !priceCompare(l.Price, *r.priceAbove, func(c int) bool { return c > 0 }) {
+		return false
+	}
+
+	if r.EndingWithin > 0 && (l.EndTime.IsZero() || !now.Before(l.EndTime) || l.EndTime.Sub(now) > r.EndingWithin) {
+		return false
+	}
+
+	if r.NoBids && l.Bids > 0 {
+		return false
+	}
+
+	return r.Match == nil || r.Match(l)
+}
+
+func (r *Rule) matchAssets(l Listing) bool {
+	if len(r.Collection) < 1 && len(r.Schema) < 1 && len(r.TemplateID) < 1 && r.MintBelow < 1 {
+		return true
+	}
+
+	for _, a := range l.Assets {
+		if len(r.Collection) > 0 && a.Collection.CollectionName != r.Collection {
+			continue
+		}
+
+		if len(r.Schema) > 0 && a.Schema.Name != r.Schema {
+			continue
+		}
+
+		if len(r.TemplateID) > 0 && a.Template.ID != r.TemplateID {
+			continue
+		}
+
+		if r.MintBelow > 0 {
+			mint, err := strconv.Atoi(a.TemplateMint)
+			if err != nil || mint < 1 || mint >= r.MintBelow {
+				continue
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// priceCompare compares price with limit (scaled to the same precision)
+// and returns the result of cmp. Prices in another symbol never match.
+func priceCompare(price, limit atomicasset.Token, cmp func(int) bool) bool {
+	if price.Symbol != limit.Symbol {
+		return false
+	}
+
+	a, ok := new(big.Int).SetString(price.Amount, 10)
+	if !ok {
+		return false
+	}
+
+	b, ok := new(big.Int).SetString(limit.Amount, 10)
+	if !ok {
+		return false
+	}
+
+	ten := big.NewInt(10)
+	if price.Precision > limit.Precision {
+		b.Mul(b, new(big.Int).Exp(ten, big.NewInt(int64(price.Precision-limit.Precision)), nil))
+	} else if limit.Precision > price.Precision {
+		a.Mul(a, new(big.Int).Exp(ten, big.NewInt(int64(limit.Precision-price.Precision)), nil))
+	}
+	return cmp(a.Cmp(b))
+}
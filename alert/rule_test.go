@@ -0,0 +1,118 @@
+package alert
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/eosswedenorg-go/atomicasset"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testRules = `
+rules:
+  - name: cheap-template
+    kind: sale
+    template_id: "12345"
+    price_below: "10.00000000 WAX"
+  - name: low-mint
+    kind: sale
+    schema: heroes
+    mint_below: 100
+  - name: ending-auction
+    kind: auction
+    ending_within: 5m
+    no_bids: true
+    cooldown: 1m
+`
+
+func wax(amount string) atomicasset.Token {
+	return atomicasset.Token{Contract: "eosio.token", Symbol: "WAX", Precision: 8, Amount: amount}
+}
+
+func TestParseRules(t *testing.T) {
+	rules, err := LoadRules(strings.NewReader(testRules))
+	require.NoError(t, err)
+
+	assert.Equal(t, []Rule{
+		{Name: "cheap-template", Kind: KindSale, TemplateID: "12345", PriceBelow: "10.00000000 WAX"},
+		{Name: "low-mint", Kind: KindSale, Schema: "heroes", MintBelow: 100},
+		{Name: "ending-auction", Kind: KindAuction, EndingWithin: 5 * time.Minute, NoBids: true, Cooldown: time.Minute},
+	}, rules)
+
+	_, err = ParseRules([]byte("rules: {"))
+	assert.Error(t, err)
+}
+
+func TestRule_Compile(t *testing.T) {
+	tests := []struct {
+		name string
+		rule Rule
+		err  string
+	}{
+		{"NoName", Rule{Kind: KindSale}, "rule has no name"},
+		{"InvalidKind", Rule{Name: "a", Kind: "offer"}, "rule 'a': invalid kind 'offer'"},
+		{"AuctionOnly", Rule{Name: "a", Kind: KindSale, NoBids: true}, "rule 'a': ending_within and no_bids only apply to auctions"},
+		{"InvalidPrice", Rule{Name: "a", Kind: KindSale, PriceBelow: "10"}, "rule 'a': invalid asset '10'"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.EqualError(t, tt.rule.compile(), tt.err)
+		})
+	}
+}
+
+func TestRule_Matches(t *testing.T) {
+	now := time.Date(2023, time.January, 1, 12, 0, 0, 0, time.UTC)
+
+	asset := func(template, schema, mint string) atomicasset.Asset {
+		return atomicasset.Asset{
+			Collection:   atomicasset.Collection{CollectionName: "col"},
+			Schema:       atomicasset.InlineSchema{Name: schema},
+			Template:     atomicasset.Template{ID: template},
+			TemplateMint: mint,
+		}
+	}
+
+	sale := func(price string, assets ...atomicasset.Asset) Listing {
+		return Listing{Kind: KindSale, ID: "1", Price: wax(price), Assets: assets}
+	}
+
+	auction := func(end time.Duration, bids int) Listing {
+		return Listing{Kind: KindAuction, ID: "1", Price: wax("100"), EndTime: now.Add(end), Bids: bids}
+	}
+
+	tests := []struct {
+		name     string
+		rule     Rule
+		listing  Listing
+		expected bool
+	}{
+		{"PriceBelow", Rule{Kind: KindSale, TemplateID: "12345", PriceBelow: "10 WAX"}, sale("999999999", asset("12345", "s", "1")), true},
+		{"PriceNotBelow", Rule{Kind: KindSale, TemplateID: "12345", PriceBelow: "10 WAX"}, sale("1000000000", asset("12345", "s", "1")), false},
+		{"PriceOtherSymbol", Rule{Kind: KindSale, PriceBelow: "10 TLM"}, sale("1"), false},
+		{"PriceAbove", Rule{Kind: KindSale, PriceAbove: "0.5 WAX"}, sale("50000001"), true},
+		{"OtherTemplate", Rule{Kind: KindSale, TemplateID: "12345"}, sale("1", asset("1", "s", "1")), false},
+		{"OtherKind", Rule{Kind: KindAuction}, sale("1"), false},
+		{"MintBelow", Rule{Kind: KindSale, Schema: "heroes", MintBelow: 100}, sale("1", asset("1", "other", "5"), asset("2", "heroes", "99")), true},
+		{"MintNotBelow", Rule{Kind: KindSale, Schema: "heroes", MintBelow: 100}, sale("1", asset("1", "heroes", "100")), false},
+		{"MintAndSchemaOnSameAsset", Rule{Kind: KindSale, Schema: "heroes", MintBelow: 100}, sale("1", asset("1", "other", "5"), asset("2", "heroes", "500")), false},
+		{"Collection", Rule{Kind: KindSale, Collection: "col"}, sale("1", asset("1", "s", "1")), true},
+		{"EndingWithin", Rule{Kind: KindAuction, EndingWithin: 5 * time.Minute, NoBids: true}, auction(4*time.Minute, 0), true},
+		{"EndingLater", Rule{Kind: KindAuction, EndingWithin: 5 * time.Minute}, auction(6*time.Minute, 0), false},
+		{"Ended", Rule{Kind: KindAuction, EndingWithin: 5 * time.Minute}, auction(-time.Minute, 0), false},
+		{"HasBids", Rule{Kind: KindAuction, NoBids: true}, auction(time.Minute, 2), false},
+		{"Match", Rule{Kind: KindSale, Match: func(l Listing) bool { return l.ID == "1" }}, sale("1"), true},
+		{"NoMatch", Rule{Kind: KindSale, Match: func(l Listing) bool { return false }}, sale("1"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.rule.Name = tt.name
+			require.NoError(t, tt.rule.compile())
+			assert.Equal(t, tt.expected, tt.rule.matches(tt.listing, now))
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package alert
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// Sink receives alerts from an engine.
+type Sink interface {
+	Send(ctx context.Context, a Alert) error
+}
+
+// ChannelSink sends alerts to a channel, blocking until it is received or ctx is done.
+type ChannelSink chan<- Alert
+
+func (s ChannelSink) Send(ctx context.Context, a Alert) error {
+	select {
+	case s <- a:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// FuncSink calls a function for each alert.
+type FuncSink func(a Alert)
+
+func (f FuncSink) Send(ctx context.Context, a Alert) error {
+	f(a)
+	return nil
+}
+
+// WebhookSink posts each alert as json to URL.
+type WebhookSink struct {
+	URL string
+
+	// Client defaults to http.DefaultClient.
+	Client *http.Client
+}
+
+func (s *WebhookSink) Send(ctx context.Context, a Alert) error {
+	body, err := json.Marshal(a)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", s.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	client := s.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook '%s' returned status %d", s.URL, resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+package alert
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/eosswedenorg-go/atomicasset"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhookSink(t *testing.T) {
+	var received Alert
+	srv := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "POST", req.Method)
+		assert.Equal(t, "application/json", req.Header.Get("Content-Type"))
+		assert.NoError(t, json.NewDecoder(req.Body).Decode(&received))
+	}))
+	defer srv.Close()
+
+	sink := &WebhookSink{URL: srv.URL}
+	err := sink.Send(context.Background(), Alert{Rule: "r", Listing: SaleListing(atomicasset.Sale{ID: "1", Price: wax("5")})})
+	require.NoError(t, err)
+
+	assert.Equal(t, "r", received.Rule)
+	assert.Equal(t, KindSale, received.Listing.Kind)
+	assert.Equal(t, "1", received.Listing.ID)
+	assert.Equal(t, wax("5"), received.Listing.Price)
+}
+
+func TestWebhookSink_Status(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		res.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	err := (&WebhookSink{URL: srv.URL}).Send(context.Background(), Alert{})
+	assert.EqualError(t, err, "webhook '"+srv.URL+"' returned status 500")
+}
+
+func TestChannelSink_Canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := ChannelSink(make(chan Alert)).Send(ctx, Alert{})
+	assert.ErrorIs(t, err, context.Canceled)
+}
@@ -0,0 +1,113 @@
+package atomicasset
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Token amounts are stored as integer units of the token's smallest
+// denomination. So "1.50000000 WAX" has an amount of 150000000 with precision 8.
+
+// ParseUnits parses a decimal string ("1.5") into integer units with the given precision.
+func ParseUnits(s string, precision int) (int64, error) {
+	neg := strings.HasPrefix(s, "-")
+	s = strings.TrimPrefix(s, "-")
+
+	whole, frac, _ := strings.Cut(s, ".")
+	if !isDigits(whole) || (len(frac) > 0 && !isDigits(frac)) {
+		return 0, fmt.Errorf("invalid amount '%s'", s)
+	}
+
+	if len(frac) > precision {
+		return 0, fmt.Errorf("amount '%s' has more than %d decimals", s, precision)
+	}
+
+	n, err := strconv.ParseInt(whole+frac+strings.Repeat("0", precision-len(frac)), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("amount '%s' is out of range", s)
+	}
+
+	if neg {
+		n = -n
+	}
+	return n, nil
+}
+
+func isDigits(s string) bool {
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return len(s) > 0
+}
+
+// FormatUnits formats integer units as a decimal string with precision decimals.
+func FormatUnits(units int64, precision int) string {
+	sign := ""
+	u := uint64(units)
+	if units < 0 {
+		sign = "-"
+		u = uint64(-units)
+	}
+
+	s := strconv.FormatUint(u, 10)
+	if precision <= 0 {
+		return sign + s
+	}
+
+	if len(s) <= precision {
+		s = strings.Repeat("0", precision-len(s)+1) + s
+	}
+	return sign + s[:len(s)-precision] + "." + s[len(s)-precision:]
+}
+
+// ParseAsset parses an eosio asset string ("1.50000000 WAX") into a Token.
+// The returned token has no contract set.
+func ParseAsset(s string) (Token, error) {
+	amount, symbol, ok := strings.Cut(strings.TrimSpace(s), " ")
+	if !ok || len(symbol) < 1 {
+		return Token{}, fmt.Errorf("invalid asset '%s'", s)
+	}
+
+	precision := 0
+	if p := strings.IndexByte(amount, '.'); p >= 0 {
+		precision = len(amount) - p - 1
+	}
+
+	units, err := ParseUnits(amount, precision)
+	if err != nil {
+		return Token{}, err
+	}
+
+	return Token{
+		Symbol:    symbol,
+		Precision: precision,
+		Amount:    strconv.FormatInt(units, 10),
+	}, nil
+}
+
+// Units returns the token amount as integer units.
+func (t Token) Units() (int64, error) {
+	n, err := strconv.ParseInt(t.Amount, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid token amount '%s'", t.Amount)
+	}
+	return n, nil
+}
+
+// String returns the token as an eosio asset string ("1.50000000 WAX").
+func (t Token) String() string {
+	units, err := t.Units()
+	if err != nil {
+		return t.Amount + " " + t.Symbol
+	}
+	return FormatUnits(units, t.Precision) + " " + t.Symbol
+}
+
+// withUnits returns a copy of the token with the amount set to units.
+func (t Token) withUnits(units int64) Token {
+	t.Amount = strconv.FormatInt(units, 10)
+	return t
+}
@@ -0,0 +1,76 @@
+package atomicasset
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseUnits(t *testing.T) {
+	tests := []struct {
+		input     string
+		precision int
+		expected  int64
+	}{
+		{"1", 0, 1},
+		{"1.5", 8, 150000000},
+		{"0.00000001", 8, 1},
+		{"12.34", 2, 1234},
+		{"-1.5", 2, -150},
+		{"100", 4, 1000000},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			n, err := ParseUnits(tt.input, tt.precision)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, n)
+		})
+	}
+}
+
+func TestParseUnits_Errors(t *testing.T) {
+	_, err := ParseUnits("", 2)
+	assert.EqualError(t, err, "invalid amount ''")
+
+	_, err = ParseUnits("1.2.3", 2)
+	assert.EqualError(t, err, "invalid amount '1.2.3'")
+
+	_, err = ParseUnits("1.234", 2)
+	assert.EqualError(t, err, "amount '1.234' has more than 2 decimals")
+
+	_, err = ParseUnits("99999999999999999999", 0)
+	assert.EqualError(t, err, "amount '99999999999999999999' is out of range")
+}
+
+func TestFormatUnits(t *testing.T) {
+	assert.Equal(t, "1.50000000", FormatUnits(150000000, 8))
+	assert.Equal(t, "0.00000001", FormatUnits(1, 8))
+	assert.Equal(t, "0.00", FormatUnits(0, 2))
+	assert.Equal(t, "-1.50", FormatUnits(-150, 2))
+	assert.Equal(t, "42", FormatUnits(42, 0))
+}
+
+func TestParseAsset(t *testing.T) {
+	token, err := ParseAsset("1.50000000 WAX")
+	require.NoError(t, err)
+	assert.Equal(t, Token{Symbol: "WAX", Precision: 8, Amount: "150000000"}, token)
+	assert.Equal(t, "1.50000000 WAX", token.String())
+
+	token, err = ParseAsset("10 TOKEN")
+	require.NoError(t, err)
+	assert.Equal(t, Token{Symbol: "TOKEN", Precision: 0, Amount: "10"}, token)
+
+	_, err = ParseAsset("1.5")
+	assert.EqualError(t, err, "invalid asset '1.5'")
+}
+
+func TestToken_Units(t *testing.T) {
+	n, err := Token{Amount: "50000000"}.Units()
+	require.NoError(t, err)
+	assert.Equal(t, int64(50000000), n)
+
+	_, err = Token{Amount: "abc"}.Units()
+	assert.EqualError(t, err, "invalid token amount 'abc'")
+}
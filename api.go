@@ -1,6 +1,8 @@
 package atomicasset
 
 import (
+	"encoding/json"
+
 	"github.com/eosswedenorg-go/unixtime"
 	null "gopkg.in/guregu/null.v4"
 )
@@ -15,3 +17,9 @@ type APIResponse struct {
 	Success   bool          `json:"success"`
 	QueryTime unixtime.Time `json:"query_time"`
 }
+
+// CountResponse is the response of the "_count" endpoints.
+type CountResponse struct {
+	APIResponse
+	Data json.Number
+}
@@ -88,6 +88,32 @@ type AssetSale struct {
 
 // Request Parameters
 
+type AssetSortColumn string
+
+const (
+
+	// AssetSortDefault sorts by the default column (asset_id)
+	AssetSortDefault AssetSortColumn = ""
+
+	// AssetSortID sorts by the asset_id column
+	AssetSortID AssetSortColumn = "asset_id"
+
+	// AssetSortMinted sorts by the minted column
+	AssetSortMinted AssetSortColumn = "minted"
+
+	// AssetSortUpdated sorts by the updated column
+	AssetSortUpdated AssetSortColumn = "updated"
+
+	// AssetSortTransferred sorts by the transferred column
+	AssetSortTransferred AssetSortColumn = "transferred"
+
+	// AssetSortTemplateMint sorts by the template_mint column
+	AssetSortTemplateMint AssetSortColumn = "template_mint"
+
+	// AssetSortName sorts by the name column
+	AssetSortName AssetSortColumn = "name"
+)
+
 // AssetsRequestParams holds the parameters for an Asset request
 type AssetsRequestParams struct {
 	CollectionName          string          `qs:"collection_name,omitempty"`
@@ -116,9 +142,10 @@ type AssetsRequestParams struct {
 	Before int `qs:"before,omitempty"`
 	After  int `qs:"after,omitempty"`
 
-	Limit int       `qs:"limit,omitempty"`
-	Order SortOrder `qs:"order,omitempty"`
-	Sort  string    `qs:"sort,omitempty"`
+	Page  int             `qs:"page,omitempty"`
+	Limit int             `qs:"limit,omitempty"`
+	Order SortOrder       `qs:"order,omitempty"`
+	Sort  AssetSortColumn `qs:"sort,omitempty"`
 }
 
 // AssetSalesRequestParams holds the parameters for an AssetSales request
@@ -165,6 +192,18 @@ func (c *Client) GetAssets(params AssetsRequestParams) (AssetsResponse, error) {
 	return assets, err
 }
 
+// GetAssetsCount fetches "/atomicassets/v1/assets/_count" from API
+func (c *Client) GetAssetsCount(params AssetsRequestParams) (CountResponse, error) {
+	var resp CountResponse
+
+	r, err := c.fetch("GET", "/atomicassets/v1/assets/_count", params, &resp.APIResponse)
+	if err == nil {
+		// Parse json
+		err = r.Unmarshal(&resp)
+	}
+	return resp, err
+}
+
 // GetAsset fetches "/atomicassets/v1/assets/{asset_id}" from API
 func (c *Client) GetAsset(assetID string) (AssetResponse, error) {
 	var asset AssetResponse
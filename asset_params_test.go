@@ -47,9 +47,13 @@ func TestRequest_AssetsRequestParams(t *testing.T) {
 		{"Before", AssetsRequestParams{Before: 10}, url.Values{"before": []string{"10"}}},
 		{"After", AssetsRequestParams{After: 20}, url.Values{"after": []string{"20"}}},
 
+		{"Page", AssetsRequestParams{Page: 2}, url.Values{"page": []string{"2"}}},
 		{"Limit", AssetsRequestParams{Limit: 50}, url.Values{"limit": []string{"50"}}},
 		{"Order", AssetsRequestParams{Order: SortDescending}, url.Values{"order": []string{"desc"}}},
 		{"Sort", AssetsRequestParams{Sort: "column"}, url.Values{"sort": []string{"column"}}},
+		{"Sort Default", AssetsRequestParams{Sort: AssetSortDefault}, url.Values{}},
+		{"Sort ID", AssetsRequestParams{Sort: AssetSortID}, url.Values{"sort": []string{"asset_id"}}},
+		{"Sort TemplateMint", AssetsRequestParams{Sort: AssetSortTemplateMint}, url.Values{"sort": []string{"template_mint"}}},
 	}
 
 	for _, tt := range tests {
@@ -608,3 +608,21 @@ func TestGetAssetSaleFilterBuyer(t *testing.T) {
 
 	assert.Equal(t, expected, res.Data)
 }
+
+func TestClient_GetAssetsCount(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "/atomicassets/v1/assets/_count?collection_name=mycol", req.URL.String())
+
+		res.Header().Add("Content-type", "application/json; charset=utf-8")
+		_, err := res.Write([]byte(`{"success": true, "data": "1337", "query_time": 1645374772067}`))
+		assert.NoError(t, err)
+	}))
+	defer srv.Close()
+
+	resp, err := New(srv.URL).GetAssetsCount(AssetsRequestParams{CollectionName: "mycol"})
+	require.NoError(t, err)
+
+	n, err := resp.Data.Int64()
+	require.NoError(t, err)
+	assert.Equal(t, int64(1337), n)
+}
@@ -0,0 +1,496 @@
+package atomicasset
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Types
+
+// Attribute is a single attribute value converted to the go type
+// declared by its schema format.
+//
+//	int8, int16, int32, int64         -> int64
+//	uint8, uint16, uint32, uint64     -> uint64
+//	fixed8, fixed16, fixed32, fixed64 -> uint64
+//	float, double                     -> float64
+//	string, image, ipfs               -> string
+//	bool                              -> bool
+//	bytes                             -> []byte
+//
+// Array types ("uint64[]", "string[]" ...) are converted to a slice of the element type.
+type Attribute struct {
+	Name  string
+	Type  string
+	Value interface{}
+}
+
+// AttributeMap holds typed attributes indexed by name.
+type AttributeMap map[string]Attribute
+
+// AttributeError is returned when a value does not match its schema type.
+type AttributeError struct {
+	Name  string
+	Type  string
+	Value interface{}
+	Msg   string
+}
+
+func (e *AttributeError) Error() string {
+	if len(e.Type) < 1 {
+		return fmt.Sprintf("attribute '%s': %s", e.Name, e.Msg)
+	}
+	return fmt.Sprintf("attribute '%s' (%s): %s", e.Name, e.Type, e.Msg)
+}
+
+// AttributeErrors is a list of errors reported while decoding attributes.
+type AttributeErrors []*AttributeError
+
+func (e AttributeErrors) Error() string {
+	msgs := []string{}
+	for _, err := range e {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Decoding
+
+// DecodeAttributes converts the values in data to the types declared in format.
+//
+// Attributes missing from data are skipped. Values that does not match
+// their type and keys that are not declared in format are reported as
+// AttributeErrors, all other attributes are still returned in the map.
+func DecodeAttributes(data map[string]interface{}, format []SchemaFormat) (AttributeMap, error) {
+	attrs := AttributeMap{}
+	errs := AttributeErrors{}
+	known := map[string]bool{}
+
+	for _, f := range format {
+		known[f.Name] = true
+
+		v, ok := data[f.Name]
+		if !ok {
+			continue
+		}
+
+		value, err := convertAttribute(f.Type, v)
+		if err != nil {
+			errs = append(errs, &AttributeError{Name: f.Name, Type: f.Type, Value: v, Msg: err.Error()})
+			continue
+		}
+		attrs[f.Name] = Attribute{Name: f.Name, Type: f.Type, Value: value}
+	}
+
+	unknown := []string{}
+	for name := range data {
+		if !known[name] {
+			unknown = append(unknown, name)
+		}
+	}
+	sort.Strings(unknown)
+
+	for _, name := range unknown {
+		errs = append(errs, &AttributeError{Name: name, Value: data[name], Msg: "not defined in schema"})
+	}
+
+	if len(errs) > 0 {
+		return attrs, errs
+	}
+	return attrs, nil
+}
+
+// DecodeAttributesJSON is like DecodeAttributes but decodes data from a
+// json object. Numbers are kept as json.Number until they are converted,
+// so 64 bit integers above 2^53 are exact.
+func DecodeAttributesJSON(data []byte, format []SchemaFormat) (AttributeMap, error) {
+	obj := map[string]interface{}{}
+	if err := unmarshalNumbers(data, &obj); err != nil {
+		return nil, err
+	}
+	return DecodeAttributes(obj, format)
+}
+
+// Attributes decodes Data using the format of the asset's schema.
+//
+// Numbers in Data are float64, use DecodeAttributesJSON on the raw json
+// data for 64 bit integers above 2^53.
+func (a Asset) Attributes() (AttributeMap, error) {
+	return DecodeAttributes(a.Data, a.Schema.Format)
+}
+
+// Attributes decodes ImmutableData using the format of the template's schema.
+func (t Template) Attributes() (AttributeMap, error) {
+	return DecodeAttributes(t.ImmutableData, t.Schema.Format)
+}
+
+// Validation
+
+// ValidateAttributes checks data against format before it is used in a mint
+// or data update, format is typically Schema.Format or
+// AssetsConfig.CollectionFormat.
+//
+// Every value must match its type: integers must be in range of their size,
+// floats in range of a float32, array elements must match the element type,
+// "ipfs" values must be a CID (optionally followed by a path) and "image"
+// values a CID or url (see ParseMedia). Keys not declared in format are
+// reported as well. All problems are returned at once as AttributeErrors,
+// ordered as in format with unknown keys last.
+func ValidateAttributes(data map[string]interface{}, format []SchemaFormat) error {
+	attrs, err := DecodeAttributes(data, format)
+	errs, _ := err.(AttributeErrors)
+
+	for _, f := range format {
+		a, ok := attrs[f.Name]
+		if !ok {
+			continue
+		}
+
+		if err := validateAttribute(a); err != nil {
+			errs = append(errs, &AttributeError{Name: f.Name, Type: f.Type, Value: data[f.Name], Msg: err.Error()})
+		}
+	}
+
+	if len(errs) < 1 {
+		return nil
+	}
+
+	index := map[string]int{}
+	for i, f := range format {
+		index[f.Name] = i
+	}
+
+	sort.SliceStable(errs, func(i, j int) bool {
+		return errorIndex(index, errs[i]) < errorIndex(index, errs[j])
+	})
+	return errs
+}
+
+// errorIndex returns the position of the attribute of err in format,
+// unknown attributes are placed last.
+func errorIndex(index map[string]int, err *AttributeError) int {
+	if len(err.Type) < 1 {
+		return len(index)
+	}
+	return index[err.Name]
+}
+
+// validateAttribute checks the constraints of a decoded attribute
+// that are not checked when converting it.
+func validateAttribute(a Attribute) error {
+	elem, isArray := arrayElemType(a.Type)
+	if !isArray {
+		return validateValue(a.Type, a.Value)
+	}
+
+	rv := reflect.ValueOf(a.Value)
+	for i := 0; i < rv.Len(); i++ {
+		if err := validateValue(elem, rv.Index(i).Interface()); err != nil {
+			return fmt.Errorf("index %d: %s", i, err)
+		}
+	}
+	return nil
+}
+
+func validateValue(typ string, v interface{}) error {
+	switch typ {
+	case "float":
+		if f := v.(float64); math.Abs(f) > math.MaxFloat32 {
+			return fmt.Errorf("%v is out of range for float", f)
+		}
+	case "ipfs":
+		s := v.(string)
+		cid, _, _ := strings.Cut(s, "/")
+		if ValidateCID(cid) != nil {
+			return fmt.Errorf("'%s' is not a valid CID", s)
+		}
+	case "image":
+		s := v.(string)
+		if _, err := ParseMedia(s); err != nil {
+			return fmt.Errorf("'%s' is not a valid CID or url", s)
+		}
+	}
+	return nil
+}
+
+// JSON
+
+// unmarshalNumbers decodes b into v with numbers in interface{} values
+// decoded as json.Number instead of float64.
+func unmarshalNumbers(b []byte, v interface{}) error {
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	return dec.Decode(v)
+}
+
+// Accessors
+
+// Int64 returns the value of a signed integer attribute.
+func (m AttributeMap) Int64(name string) (int64, bool) {
+	v, ok := m[name].Value.(int64)
+	return v, ok
+}
+
+// Uint64 returns the value of an unsigned or fixed integer attribute.
+func (m AttributeMap) Uint64(name string) (uint64, bool) {
+	v, ok := m[name].Value.(uint64)
+	return v, ok
+}
+
+// Float64 returns the value of a float or double attribute.
+func (m AttributeMap) Float64(name string) (float64, bool) {
+	v, ok := m[name].Value.(float64)
+	return v, ok
+}
+
+// String returns the value of a string, image or ipfs attribute.
+func (m AttributeMap) String(name string) (string, bool) {
+	v, ok := m[name].Value.(string)
+	return v, ok
+}
+
+// Bool returns the value of a bool attribute.
+func (m AttributeMap) Bool(name string) (bool, bool) {
+	v, ok := m[name].Value.(bool)
+	return v, ok
+}
+
+// Image returns the value of an attribute declared as "image" in the schema.
+func (m AttributeMap) Image(name string) (string, bool) {
+	a, ok := m[name]
+	if !ok || a.Type != "image" {
+		return "", false
+	}
+	return m.String(name)
+}
+
+// Conversion
+
+// convertAttribute converts v to the go type for the schema type typ.
+func convertAttribute(typ string, v interface{}) (interface{}, error) {
+	if elem, ok := arrayElemType(typ); ok {
+		return convertArray(elem, v)
+	}
+
+	switch typ {
+	case "int8":
+		return toInt(v, 8)
+	case "int16":
+		return toInt(v, 16)
+	case "int32":
+		return toInt(v, 32)
+	case "int64":
+		return toInt(v, 64)
+	case "uint8", "fixed8":
+		return toUint(v, 8)
+	case "uint16", "fixed16":
+		return toUint(v, 16)
+	case "uint32", "fixed32":
+		return toUint(v, 32)
+	case "uint64", "fixed64":
+		return toUint(v, 64)
+	case "float", "double":
+		return toFloat(v)
+	case "string", "image", "ipfs":
+		return toString(v)
+	case "bool":
+		return toBool(v)
+	case "bytes":
+		return toBytes(v)
+	}
+	return nil, fmt.Errorf("unsupported type '%s'", typ)
+}
+
+// arrayElemType returns the element type of an array type ("uint64[]" -> "uint64").
+func arrayElemType(typ string) (string, bool) {
+	if strings.HasSuffix(typ, "[]") {
+		return strings.TrimSuffix(typ, "[]"), true
+	}
+	return "", false
+}
+
+func convertArray(elem string, v interface{}) (interface{}, error) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Slice {
+		return nil, fmt.Errorf("expected array, got %T", v)
+	}
+
+	values := []interface{}{}
+	for i := 0; i < rv.Len(); i++ {
+		value, err := convertAttribute(elem, rv.Index(i).Interface())
+		if err != nil {
+			return nil, fmt.Errorf("index %d: %s", i, err)
+		}
+		values = append(values, value)
+	}
+
+	switch elem {
+	case "int8", "int16", "int32", "int64":
+		return typedSlice[int64](values), nil
+	case "uint8", "uint16", "uint32", "uint64", "fixed8", "fixed16", "fixed32", "fixed64":
+		return typedSlice[uint64](values), nil
+	case "float", "double":
+		return typedSlice[float64](values), nil
+	case "string", "image", "ipfs":
+		return typedSlice[string](values), nil
+	case "bool":
+		return typedSlice[bool](values), nil
+	}
+	return values, nil
+}
+
+func typedSlice[T any](values []interface{}) []T {
+	s := make([]T, len(values))
+	for i, v := range values {
+		s[i] = v.(T)
+	}
+	return s
+}
+
+// numberString returns the decimal string form of numeric values.
+func numberString(v interface{}) (string, bool) {
+	switch n := v.(type) {
+	case json.Number:
+		return n.String(), true
+	case string:
+		return n, true
+	case int:
+		return strconv.FormatInt(int64(n), 10), true
+	case int8:
+		return strconv.FormatInt(int64(n), 10), true
+	case int16:
+		return strconv.FormatInt(int64(n), 10), true
+	case int32:
+		return strconv.FormatInt(int64(n), 10), true
+	case int64:
+		return strconv.FormatInt(n, 10), true
+	case uint:
+		return strconv.FormatUint(uint64(n), 10), true
+	case uint8:
+		return strconv.FormatUint(uint64(n), 10), true
+	case uint16:
+		return strconv.FormatUint(uint64(n), 10), true
+	case uint32:
+		return strconv.FormatUint(uint64(n), 10), true
+	case uint64:
+		return strconv.FormatUint(n, 10), true
+	}
+	return "", false
+}
+
+// float values are only accepted as integers if they have no fraction
+// and are small enough to be represented exactly.
+const maxExactFloat = 1 << 53
+
+func toInt(v interface{}, bits int) (int64, error) {
+	if f, ok := v.(float64); ok {
+		if f != math.Trunc(f) || math.Abs(f) > maxExactFloat {
+			return 0, fmt.Errorf("%v is not an exact integer", f)
+		}
+		v = int64(f)
+	}
+
+	s, ok := numberString(v)
+	if !ok {
+		return 0, fmt.Errorf("expected integer, got %T", v)
+	}
+
+	n, err := strconv.ParseInt(s, 10, bits)
+	if err != nil {
+		return 0, fmt.Errorf("'%s' is not a valid int%d", s, bits)
+	}
+	return n, nil
+}
+
+func toUint(v interface{}, bits int) (uint64, error) {
+	if f, ok := v.(float64); ok {
+		if f != math.Trunc(f) || f < 0 || f > maxExactFloat {
+			return 0, fmt.Errorf("%v is not an exact unsigned integer", f)
+		}
+		v = uint64(f)
+	}
+
+	s, ok := numberString(v)
+	if !ok {
+		return 0, fmt.Errorf("expected unsigned integer, got %T", v)
+	}
+
+	n, err := strconv.ParseUint(s, 10, bits)
+	if err != nil {
+		return 0, fmt.Errorf("'%s' is not a valid uint%d", s, bits)
+	}
+	return n, nil
+}
+
+func toFloat(v interface{}) (float64, error) {
+	switch f := v.(type) {
+	case float64:
+		return f, nil
+	case float32:
+		return float64(f), nil
+	}
+
+	s, ok := numberString(v)
+	if !ok {
+		return 0, fmt.Errorf("expected number, got %T", v)
+	}
+
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, fmt.Errorf("'%s' is not a valid number", s)
+	}
+	return f, nil
+}
+
+func toString(v interface{}) (string, error) {
+	s, ok := v.(string)
+	if !ok {
+		return "", fmt.Errorf("expected string, got %T", v)
+	}
+	return s, nil
+}
+
+func toBool(v interface{}) (bool, error) {
+	switch b := v.(type) {
+	case bool:
+		return b, nil
+	case float64:
+		// The API might represent booleans as 0 and 1.
+		if b == 0 || b == 1 {
+			return b == 1, nil
+		}
+	case json.Number:
+		if b == "0" || b == "1" {
+			return b == "1", nil
+		}
+	case string:
+		if b, err := strconv.ParseBool(b); err == nil {
+			return b, nil
+		}
+	}
+	return false, fmt.Errorf("%v is not a valid bool", v)
+}
+
+func toBytes(v interface{}) ([]byte, error) {
+	switch b := v.(type) {
+	case []byte:
+		return b, nil
+	case []interface{}:
+		buf := []byte{}
+		for i, item := range b {
+			n, err := toUint(item, 8)
+			if err != nil {
+				return nil, fmt.Errorf("index %d: %s", i, err)
+			}
+			buf = append(buf, byte(n))
+		}
+		return buf, nil
+	}
+	return nil, fmt.Errorf("expected byte array, got %T", v)
+}
@@ -0,0 +1,288 @@
+package atomicasset
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var attributeTestFormat = []SchemaFormat{
+	{Name: "name", Type: "string"},
+	{Name: "img", Type: "image"},
+	{Name: "video", Type: "ipfs"},
+	{Name: "level", Type: "uint8"},
+	{Name: "serial", Type: "uint64"},
+	{Name: "power", Type: "int32"},
+	{Name: "speed", Type: "float"},
+	{Name: "shiny", Type: "bool"},
+	{Name: "tags", Type: "string[]"},
+	{Name: "stats", Type: "int16[]"},
+}
+
+func TestDecodeAttributes(t *testing.T) {
+	data := map[string]interface{}{
+		"name":   "Dragon",
+		"img":    "QmYUtzfGWAYrQ43eo1nNRfrYKpUS1cvCWmceCQYxjP7CkS",
+		"video":  "QmS3sPdMxXHGyBK1FXRh4K1L7zvaT6ZtahFpAubMfKjK5S",
+		"level":  float64(2),
+		"serial": "18446744073709551615",
+		"power":  float64(-1200),
+		"speed":  float64(1.5),
+		"shiny":  float64(1),
+		"tags":   []interface{}{"fire", "flying"},
+		"stats":  []interface{}{float64(1), "-2", json.Number("3")},
+	}
+
+	attrs, err := DecodeAttributes(data, attributeTestFormat)
+	require.NoError(t, err)
+
+	expected := AttributeMap{
+		"name":   {Name: "name", Type: "string", Value: "Dragon"},
+		"img":    {Name: "img", Type: "image", Value: "QmYUtzfGWAYrQ43eo1nNRfrYKpUS1cvCWmceCQYxjP7CkS"},
+		"video":  {Name: "video", Type: "ipfs", Value: "QmS3sPdMxXHGyBK1FXRh4K1L7zvaT6ZtahFpAubMfKjK5S"},
+		"level":  {Name: "level", Type: "uint8", Value: uint64(2)},
+		"serial": {Name: "serial", Type: "uint64", Value: uint64(18446744073709551615)},
+		"power":  {Name: "power", Type: "int32", Value: int64(-1200)},
+		"speed":  {Name: "speed", Type: "float", Value: float64(1.5)},
+		"shiny":  {Name: "shiny", Type: "bool", Value: true},
+		"tags":   {Name: "tags", Type: "string[]", Value: []string{"fire", "flying"}},
+		"stats":  {Name: "stats", Type: "int16[]", Value: []int64{1, -2, 3}},
+	}
+
+	assert.Equal(t, expected, attrs)
+}
+
+func TestDecodeAttributes_Missing(t *testing.T) {
+	attrs, err := DecodeAttributes(map[string]interface{}{"name": "Dragon"}, attributeTestFormat)
+	require.NoError(t, err)
+	assert.Len(t, attrs, 1)
+}
+
+func TestDecodeAttributes_Mismatch(t *testing.T) {
+	data := map[string]interface{}{
+		"name":   "Dragon",
+		"level":  float64(256),
+		"serial": float64(1.5),
+		"power":  "strong",
+		"shiny":  "maybe",
+		"tags":   []interface{}{"fire", float64(2)},
+		"owner":  "someone",
+	}
+
+	attrs, err := DecodeAttributes(data, attributeTestFormat)
+
+	var errs AttributeErrors
+	require.ErrorAs(t, err, &errs)
+
+	names := []string{}
+	for _, e := range errs {
+		names = append(names, e.Name)
+	}
+	assert.Equal(t, []string{"level", "serial", "power", "shiny", "tags", "owner"}, names)
+
+	assert.Equal(t, "attribute 'level' (uint8): '256' is not a valid uint8", errs[0].Error())
+	assert.Equal(t, "attribute 'owner': not defined in schema", errs[5].Error())
+
+	// Valid attributes are still decoded.
+	assert.Equal(t, AttributeMap{"name": {Name: "name", Type: "string", Value: "Dragon"}}, attrs)
+}
+
+func TestValidateAttributes(t *testing.T) {
+	data := map[string]interface{}{
+		"name":   "Dragon",
+		"img":    "https://example.com/dragon.png",
+		"video":  testCIDv1 + "/video.mp4",
+		"level":  float64(255),
+		"serial": "18446744073709551615",
+		"power":  json.Number("-2147483648"),
+		"speed":  float64(3.4e38),
+		"tags":   []interface{}{},
+		"stats":  []interface{}{float64(-32768), "32767"},
+	}
+	assert.NoError(t, ValidateAttributes(data, attributeTestFormat))
+	assert.NoError(t, ValidateAttributes(map[string]interface{}{}, attributeTestFormat))
+}
+
+func TestValidateAttributes_Errors(t *testing.T) {
+	format := append([]SchemaFormat{
+		{Name: "gallery", Type: "ipfs[]"},
+		{Name: "weights", Type: "float[]"},
+	}, attributeTestFormat...)
+
+	data := map[string]interface{}{
+		"owner":   "someone",
+		"gallery": []interface{}{testCIDv0, "QmInvalid"},
+		"weights": []interface{}{float64(1), float64(1e39)},
+		"name":    float64(1),
+		"img":     "not-a-cid/image.png",
+		"video":   "https://ipfs.io/ipfs/" + testCIDv0,
+		"level":   float64(256),
+		"serial":  float64(-1),
+		"power":   "2147483648",
+		"speed":   float64(1e39),
+		"tags":    "fire",
+		"stats":   []interface{}{float64(1), float64(32768)},
+		"extra":   true,
+	}
+
+	err := ValidateAttributes(data, format)
+
+	var errs AttributeErrors
+	require.ErrorAs(t, err, &errs)
+
+	msgs := []string{}
+	for _, e := range errs {
+		msgs = append(msgs, e.Error())
+	}
+	assert.Equal(t, []string{
+		"attribute 'gallery' (ipfs[]): index 1: 'QmInvalid' is not a valid CID",
+		"attribute 'weights' (float[]): index 1: 1e+39 is out of range for float",
+		"attribute 'name' (string): expected string, got float64",
+		"attribute 'img' (image): 'not-a-cid/image.png' is not a valid CID or url",
+		"attribute 'video' (ipfs): 'https://ipfs.io/ipfs/" + testCIDv0 + "' is not a valid CID",
+		"attribute 'level' (uint8): '256' is not a valid uint8",
+		"attribute 'serial' (uint64): -1 is not an exact unsigned integer",
+		"attribute 'power' (int32): '2147483648' is not a valid int32",
+		"attribute 'speed' (float): 1e+39 is out of range for float",
+		"attribute 'tags' (string[]): expected array, got string",
+		"attribute 'stats' (int16[]): index 1: '32768' is not a valid int16",
+		"attribute 'extra': not defined in schema",
+		"attribute 'owner': not defined in schema",
+	}, msgs)
+}
+
+func TestValidateAttributes_CollectionFormat(t *testing.T) {
+	config := AssetsConfig{CollectionFormat: []SchemaFormat{
+		{Name: "name", Type: "string"},
+		{Name: "img", Type: "ipfs"},
+		{Name: "url", Type: "string"},
+	}}
+
+	assert.NoError(t, ValidateAttributes(map[string]interface{}{"name": "Heroes", "img": testCIDv0}, config.CollectionFormat))
+
+	err := ValidateAttributes(map[string]interface{}{"name": "Heroes", "img": ""}, config.CollectionFormat)
+	assert.EqualError(t, err, "attribute 'img' (ipfs): '' is not a valid CID")
+}
+
+func TestAttributeMap_Accessors(t *testing.T) {
+	attrs := AttributeMap{
+		"name":   {Name: "name", Type: "string", Value: "Dragon"},
+		"img":    {Name: "img", Type: "image", Value: "QmImage"},
+		"serial": {Name: "serial", Type: "uint64", Value: uint64(10)},
+		"power":  {Name: "power", Type: "int32", Value: int64(-5)},
+		"speed":  {Name: "speed", Type: "double", Value: float64(0.25)},
+		"shiny":  {Name: "shiny", Type: "bool", Value: false},
+	}
+
+	s, ok := attrs.String("name")
+	assert.True(t, ok)
+	assert.Equal(t, "Dragon", s)
+
+	img, ok := attrs.Image("img")
+	assert.True(t, ok)
+	assert.Equal(t, "QmImage", img)
+
+	// name is a string, not an image.
+	_, ok = attrs.Image("name")
+	assert.False(t, ok)
+
+	u, ok := attrs.Uint64("serial")
+	assert.True(t, ok)
+	assert.Equal(t, uint64(10), u)
+
+	_, ok = attrs.Uint64("power")
+	assert.False(t, ok)
+
+	i, ok := attrs.Int64("power")
+	assert.True(t, ok)
+	assert.Equal(t, int64(-5), i)
+
+	f, ok := attrs.Float64("speed")
+	assert.True(t, ok)
+	assert.Equal(t, 0.25, f)
+
+	b, ok := attrs.Bool("shiny")
+	assert.True(t, ok)
+	assert.False(t, b)
+
+	_, ok = attrs.String("missing")
+	assert.False(t, ok)
+}
+
+func TestAsset_Attributes(t *testing.T) {
+	asset := Asset{
+		Schema: InlineSchema{Format: attributeTestFormat},
+		Data: map[string]interface{}{
+			"name":  "Dragon",
+			"level": float64(3),
+		},
+	}
+
+	attrs, err := asset.Attributes()
+	require.NoError(t, err)
+
+	level, ok := attrs.Uint64("level")
+	assert.True(t, ok)
+	assert.Equal(t, uint64(3), level)
+}
+
+func TestDecodeAttributesJSON(t *testing.T) {
+	format := []SchemaFormat{
+		{Name: "serial", Type: "uint64"},
+		{Name: "max", Type: "uint64"},
+		{Name: "power", Type: "int64"},
+		{Name: "shiny", Type: "bool"},
+	}
+
+	// 2^63 is sent as a json number, a float64 would round the other values.
+	attrs, err := DecodeAttributesJSON([]byte(`{
+		"serial": 9223372036854775808,
+		"max": 18446744073709551615,
+		"power": -9223372036854775807,
+		"shiny": 1
+	}`), format)
+	require.NoError(t, err)
+
+	serial, ok := attrs.Uint64("serial")
+	assert.True(t, ok)
+	assert.Equal(t, uint64(1<<63), serial)
+
+	max, ok := attrs.Uint64("max")
+	assert.True(t, ok)
+	assert.Equal(t, uint64(18446744073709551615), max)
+
+	power, ok := attrs.Int64("power")
+	assert.True(t, ok)
+	assert.Equal(t, int64(-9223372036854775807), power)
+
+	shiny, ok := attrs.Bool("shiny")
+	assert.True(t, ok)
+	assert.True(t, shiny)
+
+	_, err = DecodeAttributesJSON([]byte(`[]`), format)
+	assert.Error(t, err)
+}
+
+func TestAsset_UnmarshalJSON_Data(t *testing.T) {
+	// The data maps keep the float64 values of encoding/json.
+	var asset Asset
+	require.NoError(t, json.Unmarshal([]byte(`{"data": {"level": 3}, "template": {"immutable_data": {"level": 1}}}`), &asset))
+	assert.Equal(t, float64(3), asset.Data["level"])
+	assert.Equal(t, float64(1), asset.Template.ImmutableData["level"])
+}
+
+func TestTemplate_Attributes(t *testing.T) {
+	tmpl := Template{
+		Schema:        InlineSchema{Format: attributeTestFormat},
+		ImmutableData: map[string]interface{}{"img": "QmImage"},
+	}
+
+	attrs, err := tmpl.Attributes()
+	require.NoError(t, err)
+
+	img, ok := attrs.Image("img")
+	assert.True(t, ok)
+	assert.Equal(t, "QmImage", img)
+}
@@ -2,12 +2,50 @@ package atomicasset
 
 import (
 	"fmt"
+	"math"
+	"strconv"
+	"time"
 
 	"github.com/eosswedenorg-go/unixtime"
 )
 
 // Types
 
+// AuctionState is stored as a string for the same reason as SalesState.
+type AuctionState string
+
+const (
+	AuctionStateWaiting  = AuctionState("0")
+	AuctionStateListed   = AuctionState("1")
+	AuctionStateCanceled = AuctionState("2")
+	AuctionStateSold     = AuctionState("3")
+	AuctionStateInvalid  = AuctionState("4")
+)
+
+var auctionStateNames = []string{"waiting", "active", "canceled", "sold", "invalid"}
+
+// String returns the name of the state.
+func (s AuctionState) String() string {
+	return stateString("AuctionState", string(s), auctionStateNames)
+}
+
+// Validate returns an error if s is not a known auction state.
+func (s AuctionState) Validate() error {
+	return validateState("auction state", string(s), auctionStateNames)
+}
+
+func (s AuctionState) MarshalJSON() ([]byte, error) {
+	return marshalState(string(s))
+}
+
+func (s *AuctionState) UnmarshalJSON(b []byte) error {
+	v, err := unmarshalState(b)
+	if err == nil {
+		*s = AuctionState(v)
+	}
+	return err
+}
+
 type Bid struct {
 	Number         int           `json:"number"`
 	Account        string        `json:"account"`
@@ -31,17 +69,206 @@ type Auction struct {
 	ClaimedByBuyer   bool          `json:"claimed_by_buyer"`
 	ClaimedBySeller  bool          `json:"claimed_by_seller"`
 	Collection       Collection    `json:"collection"`
+	CollectionFee    *float64      `json:"collection_fee,omitempty"`
 	EndTime          unixtime.Time `json:"end_time"`
 	IsSellerContract bool          `json:"is_seller_contract"`
 	UpdatedAtBlock   string        `json:"updated_at_block"`
 	UpdatedAtTime    unixtime.Time `json:"updated_at_time"`
 	CreatedAtBlock   string        `json:"created_at_block"`
 	CreatedAtTime    unixtime.Time `json:"created_at_time"`
-	State            SalesState    `json:"state"`
+	State            AuctionState  `json:"state"`
+}
+
+// IsActive reports whether the auction is listed and accepts bids at now.
+func (a Auction) IsActive(now time.Time) bool {
+	return a.State == AuctionStateListed && now.Before(a.EndTime.Time())
+}
+
+// IsEnded reports whether the auction was listed and has ended at now.
+func (a Auction) IsEnded(now time.Time) bool {
+	switch a.State {
+	case AuctionStateSold:
+		return true
+	case AuctionStateListed:
+		return !now.Before(a.EndTime.Time())
+	case AuctionStateInvalid:
+		return a.endedWithoutBids(now)
+	}
+	return false
+}
+
+// endedWithoutBids reports whether the api has marked the auction invalid
+// because it ended without bids, rather than for another reason such as
+// the seller no longer owning the assets.
+func (a Auction) endedWithoutBids(now time.Time) bool {
+	return a.State == AuctionStateInvalid && a.EndTime > 0 &&
+		!now.Before(a.EndTime.Time()) && len(a.Buyer) < 1
+}
+
+// IsClaimed reports whether both the buyer and seller have claimed the auction.
+func (a Auction) IsClaimed() bool {
+	return a.ClaimedByBuyer && a.ClaimedBySeller
+}
+
+// IsEndedUnclaimed reports whether the auction has ended with a winning
+// bid that the buyer or seller has not claimed yet.
+func (a Auction) IsEndedUnclaimed(now time.Time) bool {
+	return a.IsEnded(now) && len(a.Buyer) > 0 && !a.IsClaimed()
+}
+
+// AuctionPhase describes where an auction is in its lifecycle,
+// including which side still has to claim after it has ended.
+type AuctionPhase int
+
+const (
+	AuctionPhaseWaiting AuctionPhase = iota
+	AuctionPhaseActive
+	AuctionPhaseEndedNoBids
+	AuctionPhaseAwaitingClaims
+	AuctionPhaseAwaitingBuyerClaim
+	AuctionPhaseAwaitingSellerClaim
+	AuctionPhaseCompleted
+	AuctionPhaseCanceled
+	AuctionPhaseInvalid
+)
+
+var auctionPhaseNames = []string{
+	"waiting", "active", "ended_no_bids", "awaiting_claims",
+	"awaiting_buyer_claim", "awaiting_seller_claim", "completed", "canceled", "invalid",
+}
+
+// String returns the name of the phase.
+func (p AuctionPhase) String() string {
+	return stateString("AuctionPhase", strconv.Itoa(int(p)), auctionPhaseNames)
+}
+
+// Phase returns the phase of the auction at now.
+func (a Auction) Phase(now time.Time) AuctionPhase {
+	switch a.State {
+	case AuctionStateWaiting:
+		return AuctionPhaseWaiting
+	case AuctionStateCanceled:
+		return AuctionPhaseCanceled
+	case AuctionStateInvalid:
+		if a.endedWithoutBids(now) {
+			return AuctionPhaseEndedNoBids
+		}
+		return AuctionPhaseInvalid
+	}
+
+	if a.IsActive(now) {
+		return AuctionPhaseActive
+	}
+
+	if !a.IsEnded(now) {
+		return AuctionPhaseInvalid
+	}
+
+	if _, ok := a.Leader(); !ok {
+		return AuctionPhaseEndedNoBids
+	}
+
+	switch {
+	case a.ClaimedByBuyer && a.ClaimedBySeller:
+		return AuctionPhaseCompleted
+	case a.ClaimedByBuyer:
+		return AuctionPhaseAwaitingSellerClaim
+	case a.ClaimedBySeller:
+		return AuctionPhaseAwaitingBuyerClaim
+	}
+	return AuctionPhaseAwaitingClaims
+}
+
+// TimeRemaining returns the time left until the auction ends at now.
+// Zero is returned for auctions that have ended.
+func (a Auction) TimeRemaining(now time.Time) time.Duration {
+	d := a.EndTime.Time().Sub(now)
+	if d < 0 {
+		return 0
+	}
+	return d
+}
+
+// Leader returns the current highest bid.
+//
+// The bid with the highest number is used, if the auction has no bids
+// listed but a buyer is set, a bid is built from the buyer and price.
+func (a Auction) Leader() (Bid, bool) {
+	var leader Bid
+	for _, b := range a.Bids {
+		if b.Number > leader.Number {
+			leader = b
+		}
+	}
+
+	if len(leader.Account) > 0 {
+		return leader, true
+	}
+
+	if len(a.Buyer) > 0 {
+		return Bid{Account: a.Buyer, Amount: a.Price.Amount}, true
+	}
+	return Bid{}, false
+}
+
+// MinimumNextBid returns the lowest bid the contract accepts.
+//
+// The first bid must be at least the starting price. Any following bid
+// must be at least the current bid increased by cfg.MinimumBidIncrease.
+// The contract compares the bid as a double, so the increased amount is
+// rounded up to the next unit. With no increase configured the contract
+// accepts a bid equal to the current one.
+func (a Auction) MinimumNextBid(cfg MarketConfig) (Token, error) {
+	current, err := a.Price.Units()
+	if err != nil {
+		return Token{}, err
+	}
+
+	if _, ok := a.Leader(); !ok {
+		return a.Price, nil
+	}
+
+	return a.Price.withUnits(minimumBid(current, cfg.MinimumBidIncrease)), nil
+}
+
+// minimumBid returns the lowest amount the contract accepts as a bid
+// over current. The contract checks bid >= current * (1 + increase) in
+// double precision, so the same comparison is used to find the amount.
+func minimumBid(current int64, increase float64) int64 {
+	threshold := float64(current) * (1 + increase)
+	min := int64(math.Ceil(threshold))
+
+	// Amounts above 2^53 are not exact as doubles, step to the lowest
+	// amount that passes the comparison.
+	for float64(min) < threshold {
+		min++
+	}
+	for min > current && float64(min-1) >= threshold {
+		min--
+	}
+	return min
+}
+
+// EndTimeAfterBid returns the end time of the auction after a bid at bidTime.
+//
+// Like the contract, a bid placed less than cfg.AuctionResetDuration seconds
+// before the end resets the end time to bidTime plus the reset duration.
+func (a Auction) EndTimeAfterBid(bidTime time.Time, cfg MarketConfig) time.Time {
+	end := a.EndTime.Time()
+	reset := bidTime.Add(time.Duration(cfg.AuctionResetDuration) * time.Second)
+	if end.Before(reset) {
+		return reset
+	}
+	return end
+}
+
+// WouldExtend reports whether a bid at bidTime would extend the auction.
+func (a Auction) WouldExtend(bidTime time.Time, cfg MarketConfig) bool {
+	return a.EndTimeAfterBid(bidTime, cfg).After(a.EndTime.Time())
 }
 
 type AuctionsRequestParams struct {
-	State               SalesState      `qs:"state,omitempty"`
+	State               AuctionState    `qs:"state,omitempty"`
 	MaxAssets           int             `qs:"max_assets,omitempty"`
 	MinAssets           int             `qs:"min_assets,omitempty"`
 	ShowSellerContract  string          `qs:"show_seller_contract,omitempty"`
@@ -0,0 +1,177 @@
+package atomicasset
+
+import (
+	"testing"
+	"time"
+
+	"github.com/eosswedenorg-go/unixtime"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var auctionTestConfig = MarketConfig{
+	MinimumBidIncrease:   0.1,
+	AuctionResetDuration: 120,
+}
+
+// Auction 1000940 (listed with bids) and 10000 (canceled) from TestGetAuction/TestGetAuctions.
+var testAuctionBids = Auction{
+	ID:     "1000940",
+	Seller: "irxc4.wam",
+	Buyer:  "3wkba.wam",
+	Price:  Token{Contract: "eosio.token", Symbol: "WAX", Precision: 8, Amount: "3000000000"},
+	Bids: []Bid{
+		{Number: 1, Account: "jollewaxacc1", Amount: "1100000000"},
+		{Number: 2, Account: "3wkba.wam", Amount: "3000000000"},
+	},
+	EndTime: unixtime.Time(1671294427000),
+	State:   AuctionStateListed,
+}
+
+// An auction that ended without bids, the api reports these as invalid.
+var testAuctionNoBids = Auction{
+	ID:      "1001022",
+	Seller:  "irxc4.wam",
+	Price:   Token{Contract: "eosio.token", Symbol: "WAX", Precision: 8, Amount: "5000000000"},
+	Bids:    []Bid{},
+	EndTime: unixtime.Time(1671294427000),
+	State:   AuctionStateInvalid,
+}
+
+var testAuctionCanceled = Auction{
+	ID:      "10000",
+	Seller:  "svwqu.wam",
+	Price:   Token{Contract: "eosio.token", Symbol: "WAX", Precision: 8, Amount: "300000000000"},
+	EndTime: unixtime.Time(1611186498000),
+	State:   AuctionStateCanceled,
+}
+
+func TestAuction_Leader(t *testing.T) {
+	bid, ok := testAuctionBids.Leader()
+	require.True(t, ok)
+	assert.Equal(t, testAuctionBids.Bids[1], bid)
+
+	_, ok = testAuctionCanceled.Leader()
+	assert.False(t, ok)
+
+	// Buyer without bids listed.
+	bid, ok = Auction{Buyer: "buyer", Price: Token{Amount: "100"}}.Leader()
+	require.True(t, ok)
+	assert.Equal(t, Bid{Account: "buyer", Amount: "100"}, bid)
+}
+
+func TestAuction_MinimumNextBid(t *testing.T) {
+	bid, err := testAuctionBids.MinimumNextBid(auctionTestConfig)
+	require.NoError(t, err)
+	// 3000000000 * 1.1 is 3300000000.0000005 as a double.
+	assert.Equal(t, "33.00000001 WAX", bid.String())
+	assert.Equal(t, "eosio.token", bid.Contract)
+
+	// No bids, the starting price is the minimum.
+	bid, err = testAuctionCanceled.MinimumNextBid(auctionTestConfig)
+	require.NoError(t, err)
+	assert.Equal(t, testAuctionCanceled.Price, bid)
+
+	// Rounded up, the contract requires 16.5.
+	bid, err = Auction{Buyer: "buyer", Price: Token{Amount: "15"}}.MinimumNextBid(auctionTestConfig)
+	require.NoError(t, err)
+	assert.Equal(t, "17", bid.Amount)
+
+	// 100000000 * 1.1 is slightly above 110000000 as a double, which the contract rejects.
+	bid, err = Auction{Buyer: "buyer", Price: Token{Amount: "100000000"}}.MinimumNextBid(auctionTestConfig)
+	require.NoError(t, err)
+	assert.Equal(t, "110000001", bid.Amount)
+
+	// Exact products are not rounded.
+	bid, err = Auction{Buyer: "buyer", Price: Token{Amount: "20"}}.MinimumNextBid(MarketConfig{MinimumBidIncrease: 0.5})
+	require.NoError(t, err)
+	assert.Equal(t, "30", bid.Amount)
+
+	// Without an increase the current bid is accepted again.
+	bid, err = Auction{Buyer: "buyer", Price: Token{Amount: "1"}}.MinimumNextBid(MarketConfig{})
+	require.NoError(t, err)
+	assert.Equal(t, "1", bid.Amount)
+
+	_, err = Auction{Price: Token{Amount: "abc"}}.MinimumNextBid(auctionTestConfig)
+	assert.Error(t, err)
+}
+
+func TestAuction_TimeRemaining(t *testing.T) {
+	end := testAuctionBids.EndTime.Time()
+
+	assert.Equal(t, time.Hour, testAuctionBids.TimeRemaining(end.Add(-time.Hour)))
+	assert.Equal(t, time.Duration(0), testAuctionBids.TimeRemaining(end))
+	assert.Equal(t, time.Duration(0), testAuctionBids.TimeRemaining(end.Add(time.Hour)))
+}
+
+func TestAuction_WouldExtend(t *testing.T) {
+	end := testAuctionBids.EndTime.Time()
+
+	assert.False(t, testAuctionBids.WouldExtend(end.Add(-time.Hour), auctionTestConfig))
+	assert.Equal(t, end, testAuctionBids.EndTimeAfterBid(end.Add(-time.Hour), auctionTestConfig))
+
+	assert.False(t, testAuctionBids.WouldExtend(end.Add(-120*time.Second), auctionTestConfig))
+
+	bidTime := end.Add(-30 * time.Second)
+	assert.True(t, testAuctionBids.WouldExtend(bidTime, auctionTestConfig))
+	assert.Equal(t, bidTime.Add(120*time.Second), testAuctionBids.EndTimeAfterBid(bidTime, auctionTestConfig))
+}
+
+func TestAuction_Phase(t *testing.T) {
+	end := testAuctionBids.EndTime.Time()
+	after := end.Add(time.Minute)
+
+	withClaims := func(a Auction, buyer, seller bool) Auction {
+		a.ClaimedByBuyer = buyer
+		a.ClaimedBySeller = seller
+		return a
+	}
+
+	tests := []struct {
+		name     string
+		auction  Auction
+		now      time.Time
+		expected AuctionPhase
+	}{
+		{"Active", testAuctionBids, end.Add(-time.Minute), AuctionPhaseActive},
+		{"AwaitingClaims", testAuctionBids, after, AuctionPhaseAwaitingClaims},
+		{"AwaitingBuyerClaim", withClaims(testAuctionBids, false, true), after, AuctionPhaseAwaitingBuyerClaim},
+		{"AwaitingSellerClaim", withClaims(testAuctionBids, true, false), after, AuctionPhaseAwaitingSellerClaim},
+		{"Completed", withClaims(testAuctionBids, true, true), after, AuctionPhaseCompleted},
+		{"Canceled", testAuctionCanceled, after, AuctionPhaseCanceled},
+		{"Waiting", Auction{State: AuctionStateWaiting}, after, AuctionPhaseWaiting},
+		{"EndedNoBids", Auction{State: AuctionStateListed, EndTime: unixtime.Time(end.UnixMilli())}, after, AuctionPhaseEndedNoBids},
+		{"EndedNoBidsInvalid", testAuctionNoBids, after, AuctionPhaseEndedNoBids},
+		{"InvalidBeforeEnd", testAuctionNoBids, end.Add(-time.Minute), AuctionPhaseInvalid},
+		{"Invalid", Auction{State: AuctionStateInvalid}, after, AuctionPhaseInvalid},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.auction.Phase(tt.now))
+		})
+	}
+}
+
+func TestAuction_IsEnded(t *testing.T) {
+	end := testAuctionBids.EndTime.Time()
+
+	assert.False(t, testAuctionBids.IsEnded(end.Add(-time.Minute)))
+	assert.True(t, testAuctionBids.IsEnded(end))
+
+	// Invalid auctions have only ended if they ran out without bids.
+	assert.True(t, testAuctionNoBids.IsEnded(end))
+	assert.False(t, testAuctionNoBids.IsEnded(end.Add(-time.Minute)))
+	assert.False(t, testAuctionNoBids.IsEndedUnclaimed(end))
+
+	withBuyer := testAuctionNoBids
+	withBuyer.Buyer = "3wkba.wam"
+	assert.False(t, withBuyer.IsEnded(end))
+
+	assert.False(t, testAuctionCanceled.IsEnded(end))
+}
+
+func TestAuctionPhase_String(t *testing.T) {
+	assert.Equal(t, "awaiting_buyer_claim", AuctionPhaseAwaitingBuyerClaim.String())
+	assert.Equal(t, "AuctionPhase(42)", AuctionPhase(42).String())
+}
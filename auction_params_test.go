@@ -16,11 +16,11 @@ func TestRequest_AuctionsRequestParams(t *testing.T) {
 	}{
 		{"Empty", AuctionsRequestParams{}, url.Values{}},
 
-		{"StateWaiting", AuctionsRequestParams{State: SalesStateWaiting}, url.Values{"state": []string{"0"}}},
-		{"StateListed", AuctionsRequestParams{State: SalesStateListed}, url.Values{"state": []string{"1"}}},
-		{"StateCanceled", AuctionsRequestParams{State: SalesStateCanceled}, url.Values{"state": []string{"2"}}},
-		{"StateSold", AuctionsRequestParams{State: SalesStateSold}, url.Values{"state": []string{"3"}}},
-		{"StateInvalid", AuctionsRequestParams{State: SalesStateInvalid}, url.Values{"state": []string{"4"}}},
+		{"StateWaiting", AuctionsRequestParams{State: AuctionStateWaiting}, url.Values{"state": []string{"0"}}},
+		{"StateListed", AuctionsRequestParams{State: AuctionStateListed}, url.Values{"state": []string{"1"}}},
+		{"StateCanceled", AuctionsRequestParams{State: AuctionStateCanceled}, url.Values{"state": []string{"2"}}},
+		{"StateSold", AuctionsRequestParams{State: AuctionStateSold}, url.Values{"state": []string{"3"}}},
+		{"StateInvalid", AuctionsRequestParams{State: AuctionStateInvalid}, url.Values{"state": []string{"4"}}},
 
 		{"MaxAssets", AuctionsRequestParams{MaxAssets: 25}, url.Values{"max_assets": []string{"25"}}},
 		{"MinAssets", AuctionsRequestParams{MinAssets: 30}, url.Values{"min_assets": []string{"30"}}},
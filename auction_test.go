@@ -341,7 +341,7 @@ func TestGetAuction(t *testing.T) {
 		UpdatedAtTime:    unixtime.Time(1611188638000),
 		CreatedAtBlock:   "99331913",
 		CreatedAtTime:    unixtime.Time(1611100098000),
-		State:            SalesStateCanceled,
+		State:            AuctionStateCanceled,
 	}
 
 	assert.Equal(t, expected, res.Data)
@@ -743,7 +743,7 @@ func TestGetAuctions(t *testing.T) {
 			UpdatedAtTime:    unixtime.Time(1671208416500),
 			CreatedAtBlock:   "219483049",
 			CreatedAtTime:    unixtime.Time(1671207427500),
-			State:            SalesStateListed,
+			State:            AuctionStateListed,
 		},
 	}
 
@@ -8,20 +8,56 @@ import (
 
 // Types
 
+// BuyOfferState is stored as a string for the same reason as SalesState.
+type BuyOfferState string
+
+const (
+	BuyOfferStatePending  = BuyOfferState("0")
+	BuyOfferStateDeclined = BuyOfferState("1")
+	BuyOfferStateCanceled = BuyOfferState("2")
+	BuyOfferStateAccepted = BuyOfferState("3")
+	BuyOfferStateInvalid  = BuyOfferState("4")
+)
+
+var buyOfferStateNames = []string{"pending", "declined", "canceled", "accepted", "invalid"}
+
+// String returns the name of the state.
+func (s BuyOfferState) String() string {
+	return stateString("BuyOfferState", string(s), buyOfferStateNames)
+}
+
+// Validate returns an error if s is not a known buyoffer state.
+func (s BuyOfferState) Validate() error {
+	return validateState("buyoffer state", string(s), buyOfferStateNames)
+}
+
+func (s BuyOfferState) MarshalJSON() ([]byte, error) {
+	return marshalState(string(s))
+}
+
+func (s *BuyOfferState) UnmarshalJSON(b []byte) error {
+	v, err := unmarshalState(b)
+	if err == nil {
+		*s = BuyOfferState(v)
+	}
+	return err
+}
+
 type BuyOffer struct {
-	ID               string     `json:"buyoffer_id"`
-	MarketContract   string     `json:"market_contract"`
-	AssetsContract   string     `json:"assets_contract"`
-	Seller           string     `json:"seller"`
-	Buyer            string     `json:"buyer"`
-	Price            Token      `json:"price"`
-	Assets           []Asset    `json:"assets"`
-	MakerMarketplace string     `json:"maker_marketplace,omitempty"`
-	TakerMarketplace string     `json:"taker_marketplace,omitempty"`
-	Collection       Collection `json:"collection"`
-	State            SalesState `json:"state"`
-	Memo             string     `json:"memo"`
-	DeclineMemo      string     `json:"decline_memo"`
+	ID               string        `json:"buyoffer_id"`
+	MarketContract   string        `json:"market_contract"`
+	AssetsContract   string        `json:"assets_contract"`
+	Seller           string        `json:"seller"`
+	Buyer            string        `json:"buyer"`
+	Price            Token         `json:"price"`
+	Assets           []Asset       `json:"assets"`
+	MakerMarketplace string        `json:"maker_marketplace,omitempty"`
+	TakerMarketplace string        `json:"taker_marketplace,omitempty"`
+	Collection       Collection    `json:"collection"`
+	CollectionFee    *float64      `json:"collection_fee,omitempty"`
+	State            BuyOfferState `json:"state"`
+	Memo             string        `json:"memo"`
+	DeclineMemo      string        `json:"decline_memo"`
 
 	UpdatedAtBlock string        `json:"updated_at_block"`
 	UpdatedAtTime  unixtime.Time `json:"updated_at_time"`
@@ -44,7 +80,7 @@ const (
 )
 
 type BuyOffersRequestParams struct {
-	State               SalesState         `qs:"state,omitempty"`
+	State               BuyOfferState      `qs:"state,omitempty"`
 	MaxAssets           int                `qs:"max_assets,omitempty"`
 	MinAssets           int                `qs:"min_assets,omitempty"`
 	ShowSellerContract  string             `qs:"show_seller_contract,omitempty"`
@@ -134,7 +170,7 @@ func (c *Client) GetBuyOfferLogs(buyoffer_id int, params LogRequestParams) (Logs
 }
 
 // GetBuyOffers fetches "/atomicassets/v1/buyoffers" from API
-func (c *Client) GetBuyOffers(params AuctionsRequestParams) (BuyOffersResponse, error) {
+func (c *Client) GetBuyOffers(params BuyOffersRequestParams) (BuyOffersResponse, error) {
 	var resp BuyOffersResponse
 
 	r, err := c.fetch("GET", "/atomicmarket/v1/buyoffers", params, &resp.APIResponse)
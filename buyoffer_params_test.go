@@ -16,11 +16,11 @@ func TestRequest_BuyOffersRequestParams(t *testing.T) {
 	}{
 		{"Empty", BuyOffersRequestParams{}, url.Values{}},
 
-		{"StateWaiting", BuyOffersRequestParams{State: SalesStateWaiting}, url.Values{"state": []string{"0"}}},
-		{"StateListed", BuyOffersRequestParams{State: SalesStateListed}, url.Values{"state": []string{"1"}}},
-		{"StateCanceled", BuyOffersRequestParams{State: SalesStateCanceled}, url.Values{"state": []string{"2"}}},
-		{"StateSold", BuyOffersRequestParams{State: SalesStateSold}, url.Values{"state": []string{"3"}}},
-		{"StateInvalid", BuyOffersRequestParams{State: SalesStateInvalid}, url.Values{"state": []string{"4"}}},
+		{"StatePending", BuyOffersRequestParams{State: BuyOfferStatePending}, url.Values{"state": []string{"0"}}},
+		{"StateDeclined", BuyOffersRequestParams{State: BuyOfferStateDeclined}, url.Values{"state": []string{"1"}}},
+		{"StateCanceled", BuyOffersRequestParams{State: BuyOfferStateCanceled}, url.Values{"state": []string{"2"}}},
+		{"StateAccepted", BuyOffersRequestParams{State: BuyOfferStateAccepted}, url.Values{"state": []string{"3"}}},
+		{"StateInvalid", BuyOffersRequestParams{State: BuyOfferStateInvalid}, url.Values{"state": []string{"4"}}},
 
 		{"MaxAssets", BuyOffersRequestParams{MaxAssets: 25}, url.Values{"max_assets": []string{"25"}}},
 		{"MinAssets", BuyOffersRequestParams{MinAssets: 30}, url.Values{"min_assets": []string{"30"}}},
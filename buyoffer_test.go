@@ -323,7 +323,7 @@ func TestGetBuyOffer(t *testing.T) {
 		UpdatedAtTime:    unixtime.Time(1639882183500),
 		CreatedAtBlock:   "156667517",
 		CreatedAtTime:    unixtime.Time(1639788773000),
-		State:            SalesStateCanceled,
+		State:            BuyOfferStateCanceled,
 	}
 
 	assert.Equal(t, expected, res.Data)
@@ -694,7 +694,7 @@ func TestGetBuyOffers(t *testing.T) {
 
 	client := New(srv.URL)
 
-	res, err := client.GetBuyOffers(AuctionsRequestParams{Limit: 1, Page: 1})
+	res, err := client.GetBuyOffers(BuyOffersRequestParams{Limit: 1, Page: 1})
 
 	require.NoError(t, err)
 	assert.Equal(t, 200, res.HTTPStatusCode)
@@ -802,7 +802,7 @@ func TestGetBuyOffers(t *testing.T) {
 		UpdatedAtTime:    unixtime.Time(1671457786000),
 		CreatedAtBlock:   "219983297",
 		CreatedAtTime:    unixtime.Time(1671457786000),
-		State:            SalesStateWaiting,
+		State:            BuyOfferStatePending,
 	}
 
 	assert.Equal(t, []BuyOffer{expected}, res.Data)
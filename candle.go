@@ -0,0 +1,228 @@
+package atomicasset
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// Types
+
+// Candle intervals.
+const (
+	CandleHour   = time.Hour
+	Candle4Hours = 4 * time.Hour
+	CandleDay    = 24 * time.Hour
+	CandleWeek   = 7 * CandleDay
+)
+
+// Candle is the open, high, low and close price and the volume
+// of all sales of a token within one interval.
+type Candle struct {
+	Start  time.Time
+	Open   Token
+	High   Token
+	Low    Token
+	Close  Token
+	Volume Token
+	Sales  int
+}
+
+// candleState holds the candle values as integer units.
+type candleState struct {
+	open, high, low, close, volume int64
+	openTime, closeTime            time.Time
+	sales                          int
+}
+
+// CandleAggregator aggregates PriceSale rows into candles per token.
+//
+// Sales can be added in any order and more than once, which makes it
+// possible to update the candles by adding the latest sales from the API.
+type CandleAggregator struct {
+	interval time.Duration
+	tokens   map[PriceToken]map[int64]*candleState
+	seen     map[string]bool
+}
+
+// NewCandleAggregator creates an aggregator for interval.
+// Candles are aligned to midnight UTC and weekly candles start on monday.
+func NewCandleAggregator(interval time.Duration) (*CandleAggregator, error) {
+	if interval <= 0 || CandleWeek%interval != 0 {
+		return nil, fmt.Errorf("invalid candle interval %s", interval)
+	}
+
+	return &CandleAggregator{
+		interval: interval,
+		tokens:   map[PriceToken]map[int64]*candleState{},
+		seen:     map[string]bool{},
+	}, nil
+}
+
+// Interval returns the candle interval.
+func (a *CandleAggregator) Interval() time.Duration {
+	return a.interval
+}
+
+// start returns the start of the candle t belongs to.
+//
+// Truncate rounds relative to the zero time, which is a monday at midnight UTC.
+func (a *CandleAggregator) start(t time.Time) time.Time {
+	return t.Truncate(a.interval).UTC()
+}
+
+// saleKey identifies a sale so it is only counted once.
+func saleKey(s PriceSale) string {
+	return s.SaleID + "/" + s.AuctionID + "/" + s.BuyofferID + "/" + strconv.FormatInt(int64(s.BlockTime), 10)
+}
+
+// Add adds sales to the candles. Sales that were already added are ignored.
+func (a *CandleAggregator) Add(sales ...PriceSale) error {
+	for _, s := range sales {
+		key := saleKey(s)
+		if a.seen[key] {
+			continue
+		}
+
+		price, err := strconv.ParseInt(s.Price, 10, 64)
+		if err != nil || price < 0 {
+			return fmt.Errorf("invalid sale price '%s'", s.Price)
+		}
+		a.seen[key] = true
+
+		token := PriceToken{Contract: s.TokenContract, Symbol: s.TokenSymbol, Precision: int(s.TokenPrecision)}
+		candles, ok := a.tokens[token]
+		if !ok {
+			candles = map[int64]*candleState{}
+			a.tokens[token] = candles
+		}
+
+		t := s.BlockTime.Time()
+		start := a.start(t).Unix()
+
+		c, ok := candles[start]
+		if !ok {
+			candles[start] = &candleState{
+				open: price, high: price, low: price, close: price, volume: price,
+				openTime: t, closeTime: t, sales: 1,
+			}
+			continue
+		}
+
+		if t.Before(c.openTime) {
+			c.open, c.openTime = price, t
+		}
+		if !t.Before(c.closeTime) {
+			c.close, c.closeTime = price, t
+		}
+		if price > c.high {
+			c.high = price
+		}
+		if price < c.low {
+			c.low = price
+		}
+		c.volume += price
+		c.sales++
+	}
+	return nil
+}
+
+// Tokens returns the tokens that have sales, sorted by symbol.
+func (a *CandleAggregator) Tokens() []PriceToken {
+	tokens := []PriceToken{}
+	for t := range a.tokens {
+		tokens = append(tokens, t)
+	}
+
+	sort.Slice(tokens, func(i, j int) bool {
+		if tokens[i].Symbol != tokens[j].Symbol {
+			return tokens[i].Symbol < tokens[j].Symbol
+		}
+		return tokens[i].Contract < tokens[j].Contract
+	})
+	return tokens
+}
+
+// Candles returns the candles for token between from and to (inclusive).
+// A zero from or to means the first or last candle with sales.
+//
+// Intervals without sales are filled with a candle at the previous close
+// and zero volume. Intervals before the first sale are left out.
+func (a *CandleAggregator) Candles(token PriceToken, from, to time.Time) []Candle {
+	candles := a.tokens[token]
+
+	starts := []int64{}
+	for start := range candles {
+		starts = append(starts, start)
+	}
+	sort.Slice(starts, func(i, j int) bool { return starts[i] < starts[j] })
+
+	result := []Candle{}
+	if len(starts) < 1 {
+		return result
+	}
+
+	first, last := time.Unix(starts[0], 0).UTC(), time.Unix(starts[len(starts)-1], 0).UTC()
+	if !to.IsZero() {
+		last = a.start(to)
+	}
+
+	// The candle before from is used to fill leading empty intervals.
+	var prev *candleState
+	if !from.IsZero() {
+		first = a.start(from)
+		for _, start := range starts {
+			if start < first.Unix() {
+				prev = candles[start]
+			}
+		}
+	}
+
+	tok := Token{Contract: token.Contract, Symbol: token.Symbol, Precision: token.Precision}
+	for t := first; !t.After(last); t = a.start(t.Add(a.interval)) {
+		c, ok := candles[t.Unix()]
+		if !ok {
+			if prev == nil {
+				continue
+			}
+
+			result = append(result, Candle{
+				Start:  t,
+				Open:   tok.withUnits(prev.close),
+				High:   tok.withUnits(prev.close),
+				Low:    tok.withUnits(prev.close),
+				Close:  tok.withUnits(prev.close),
+				Volume: tok.withUnits(0),
+			})
+			continue
+		}
+
+		result = append(result, Candle{
+			Start:  t,
+			Open:   tok.withUnits(c.open),
+			High:   tok.withUnits(c.high),
+			Low:    tok.withUnits(c.low),
+			Close:  tok.withUnits(c.close),
+			Volume: tok.withUnits(c.volume),
+			Sales:  c.sales,
+		})
+		prev = c
+	}
+	return result
+}
+
+// SaleCandles fetches the sale prices matching params and aggregates them into candles.
+func (c *Client) SaleCandles(ctx context.Context, params PriceSalesRequestParams, interval time.Duration) (*CandleAggregator, error) {
+	agg, err := NewCandleAggregator(interval)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.WithContext(ctx).GetSalePrices(params)
+	if err != nil {
+		return nil, err
+	}
+	return agg, agg.Add(resp.Data...)
+}
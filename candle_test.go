@@ -0,0 +1,150 @@
+package atomicasset
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/eosswedenorg-go/unixtime"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var candleBase = time.Date(2023, time.January, 2, 0, 0, 0, 0, time.UTC) // monday
+
+func candleSale(id string, price string, at time.Duration) PriceSale {
+	return PriceSale{
+		SaleID:         id,
+		Price:          price,
+		TokenSymbol:    "WAX",
+		TokenPrecision: 8,
+		TokenContract:  "eosio.token",
+		BlockTime:      unixtime.Time(candleBase.Add(at).UnixMilli()),
+	}
+}
+
+var candleWAX = PriceToken{Contract: "eosio.token", Symbol: "WAX", Precision: 8}
+
+func candleValues(c Candle) []string {
+	return []string{c.Open.Amount, c.High.Amount, c.Low.Amount, c.Close.Amount, c.Volume.Amount}
+}
+
+func TestCandleAggregator(t *testing.T) {
+	agg, err := NewCandleAggregator(CandleHour)
+	require.NoError(t, err)
+
+	// Added out of order.
+	require.NoError(t, agg.Add(
+		candleSale("3", "300", 40*time.Minute),
+		candleSale("1", "200", 5*time.Minute),
+		candleSale("2", "500", 20*time.Minute),
+		candleSale("4", "100", 3*time.Hour+10*time.Minute),
+	))
+
+	candles := agg.Candles(candleWAX, time.Time{}, time.Time{})
+	require.Len(t, candles, 4)
+
+	assert.Equal(t, candleBase, candles[0].Start)
+	assert.Equal(t, []string{"200", "500", "200", "300", "1000"}, candleValues(candles[0]))
+	assert.Equal(t, 3, candles[0].Sales)
+	assert.Equal(t, "0.00000200 WAX", candles[0].Open.String())
+
+	// Gaps are filled with the previous close.
+	assert.Equal(t, candleBase.Add(time.Hour), candles[1].Start)
+	assert.Equal(t, []string{"300", "300", "300", "300", "0"}, candleValues(candles[1]))
+	assert.Equal(t, 0, candles[1].Sales)
+	assert.Equal(t, []string{"300", "300", "300", "300", "0"}, candleValues(candles[2]))
+
+	assert.Equal(t, []string{"100", "100", "100", "100", "100"}, candleValues(candles[3]))
+}
+
+func TestCandleAggregator_Incremental(t *testing.T) {
+	agg, err := NewCandleAggregator(CandleDay)
+	require.NoError(t, err)
+
+	require.NoError(t, agg.Add(candleSale("1", "100", time.Hour)))
+	require.NoError(t, agg.Add(candleSale("1", "100", time.Hour), candleSale("2", "150", 2*time.Hour)))
+
+	candles := agg.Candles(candleWAX, time.Time{}, time.Time{})
+	require.Len(t, candles, 1)
+	assert.Equal(t, []string{"100", "150", "100", "150", "250"}, candleValues(candles[0]))
+	assert.Equal(t, 2, candles[0].Sales)
+}
+
+func TestCandleAggregator_Range(t *testing.T) {
+	agg, err := NewCandleAggregator(Candle4Hours)
+	require.NoError(t, err)
+
+	require.NoError(t, agg.Add(
+		candleSale("1", "100", time.Hour),
+		candleSale("2", "200", 9*time.Hour),
+	))
+
+	// From after the first candle starts at the previous close.
+	candles := agg.Candles(candleWAX, candleBase.Add(4*time.Hour), candleBase.Add(16*time.Hour))
+	require.Len(t, candles, 4)
+	assert.Equal(t, []string{"100", "100", "100", "100", "0"}, candleValues(candles[0]))
+	assert.Equal(t, []string{"200", "200", "200", "200", "200"}, candleValues(candles[1]))
+	assert.Equal(t, candleBase.Add(16*time.Hour), candles[3].Start)
+
+	// Intervals before the first sale are left out.
+	candles = agg.Candles(candleWAX, candleBase.Add(-8*time.Hour), candleBase.Add(4*time.Hour))
+	require.Len(t, candles, 2)
+	assert.Equal(t, candleBase, candles[0].Start)
+}
+
+func TestCandleAggregator_Week(t *testing.T) {
+	agg, err := NewCandleAggregator(CandleWeek)
+	require.NoError(t, err)
+
+	require.NoError(t, agg.Add(candleSale("1", "100", 3*CandleDay), candleSale("2", "50", 8*CandleDay)))
+
+	candles := agg.Candles(candleWAX, time.Time{}, time.Time{})
+	require.Len(t, candles, 2)
+	assert.Equal(t, candleBase, candles[0].Start)
+	assert.Equal(t, time.Monday, candles[1].Start.Weekday())
+}
+
+func TestCandleAggregator_Tokens(t *testing.T) {
+	agg, err := NewCandleAggregator(CandleDay)
+	require.NoError(t, err)
+
+	other := candleSale("2", "5", time.Hour)
+	other.TokenSymbol, other.TokenContract, other.TokenPrecision = "TLM", "alien.worlds", 4
+
+	require.NoError(t, agg.Add(candleSale("1", "100", time.Hour), other))
+	assert.Equal(t, []PriceToken{{Contract: "alien.worlds", Symbol: "TLM", Precision: 4}, candleWAX}, agg.Tokens())
+	assert.Equal(t, "0.0005 TLM", agg.Candles(agg.Tokens()[0], time.Time{}, time.Time{})[0].Close.String())
+	assert.Equal(t, []Candle{}, agg.Candles(PriceToken{Symbol: "USD"}, time.Time{}, time.Time{}))
+}
+
+func TestCandleAggregator_Errors(t *testing.T) {
+	_, err := NewCandleAggregator(5 * 24 * time.Hour)
+	assert.EqualError(t, err, "invalid candle interval 120h0m0s")
+
+	agg, err := NewCandleAggregator(CandleHour)
+	require.NoError(t, err)
+	assert.EqualError(t, agg.Add(candleSale("1", "abc", 0)), "invalid sale price 'abc'")
+}
+
+func TestClient_SaleCandles(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "/atomicassets/v1/prices/sales?template_id=42", req.URL.String())
+
+		res.Header().Add("Content-type", "application/json; charset=utf-8")
+		_, err := res.Write([]byte(`{"success": true, "data": [
+			{"sale_id": "1", "price": "100", "token_symbol": "WAX", "token_precision": 8, "token_contract": "eosio.token", "block_time": "1672617600000"}
+		], "query_time": 0}`))
+		assert.NoError(t, err)
+	}))
+	defer srv.Close()
+
+	agg, err := New(srv.URL).SaleCandles(context.Background(), PriceSalesRequestParams{TemplateID: 42}, CandleDay)
+	require.NoError(t, err)
+
+	candles := agg.Candles(candleWAX, time.Time{}, time.Time{})
+	require.Len(t, candles, 1)
+	assert.Equal(t, candleBase, candles[0].Start)
+}
@@ -0,0 +1,492 @@
+package chain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/eosswedenorg-go/atomicasset"
+	"github.com/eosswedenorg-go/atomicasset/actions"
+	"github.com/eosswedenorg-go/atomicasset/serialization"
+)
+
+// ErrNotFound is returned when a row does not exist in a table.
+var ErrNotFound = errors.New("not found in table")
+
+// Types
+
+// Tables reads the atomicassets contract tables through get_table_rows.
+//
+// The tables always hold the current state, so they can be used as a
+// fallback when the indexer behind the api lags (see atomicasset.ChainHealth).
+// Only the fields stored in the tables are set on the returned structs,
+// block numbers, timestamps and mint numbers are left empty and nested
+// collections only have their name and contract set.
+type Tables struct {
+	RPC TableRPC
+
+	// Contract is the atomicassets account, actions.DefaultAtomicAssetsContract if empty.
+	Contract string
+}
+
+// NewTables creates a reader of the atomicassets tables.
+func NewTables(rpc TableRPC) Tables {
+	return Tables{RPC: rpc}
+}
+
+func (t Tables) contract() string {
+	if len(t.Contract) < 1 {
+		return actions.DefaultAtomicAssetsContract
+	}
+	return t.Contract
+}
+
+func (t Tables) request(scope string, table string) TableRowsRequest {
+	return TableRowsRequest{Code: t.contract(), Scope: scope, Table: table}
+}
+
+// find reads the row with primary key key.
+func find[T any](ctx context.Context, t Tables, req TableRowsRequest, key string) (T, error) {
+	req.LowerBound, req.UpperBound, req.Limit = key, key, 1
+
+	var row T
+	rows, err := tableRows[T](ctx, t.RPC, req)
+	if err != nil {
+		return row, err
+	}
+
+	if len(rows) < 1 {
+		return row, ErrNotFound
+	}
+	return rows[0], nil
+}
+
+// Table rows
+
+type configRow struct {
+	CollectionFormat []atomicasset.SchemaFormat `json:"collection_format"`
+	SupportedTokens  []struct {
+		Contract string `json:"contract"`
+		Symbol   string `json:"sym"`
+	} `json:"supported_tokens"`
+}
+
+type collectionRow struct {
+	Name               string       `json:"collection_name"`
+	Author             string       `json:"author"`
+	AllowNotify        bool         `json:"allow_notify"`
+	AuthorizedAccounts []string     `json:"authorized_accounts"`
+	NotifyAccounts     []string     `json:"notify_accounts"`
+	MarketFee          numberString `json:"market_fee"`
+	Data               byteArray    `json:"serialized_data"`
+}
+
+type schemaRow struct {
+	Name   string                     `json:"schema_name"`
+	Format []atomicasset.SchemaFormat `json:"format"`
+}
+
+type templateRow struct {
+	ID            int32     `json:"template_id"`
+	Schema        string    `json:"schema_name"`
+	Transferable  bool      `json:"transferable"`
+	Burnable      bool      `json:"burnable"`
+	MaxSupply     uint32    `json:"max_supply"`
+	IssuedSupply  uint32    `json:"issued_supply"`
+	ImmutableData byteArray `json:"immutable_serialized_data"`
+}
+
+type assetRow struct {
+	ID            numberString `json:"asset_id"`
+	Collection    string       `json:"collection_name"`
+	Schema        string       `json:"schema_name"`
+	TemplateID    int32        `json:"template_id"`
+	BackedTokens  []string     `json:"backed_tokens"`
+	ImmutableData byteArray    `json:"immutable_serialized_data"`
+	MutableData   byteArray    `json:"mutable_serialized_data"`
+}
+
+type offerRow struct {
+	ID                numberString   `json:"offer_id"`
+	Sender            string         `json:"sender"`
+	Recipient         string         `json:"recipient"`
+	SenderAssetIDs    []numberString `json:"sender_asset_ids"`
+	RecipientAssetIDs []numberString `json:"recipient_asset_ids"`
+	Memo              string         `json:"memo"`
+}
+
+// Config
+
+// Config reads the config table.
+func (t Tables) Config(ctx context.Context) (atomicasset.AssetsConfig, error) {
+	row, err := find[configRow](ctx, t, t.request(t.contract(), "config"), "")
+	if err != nil {
+		return atomicasset.AssetsConfig{}, err
+	}
+
+	config := atomicasset.AssetsConfig{
+		Contract:         t.contract(),
+		CollectionFormat: row.CollectionFormat,
+		SupportedTokens:  []atomicasset.PriceToken{},
+	}
+
+	for _, token := range row.SupportedTokens {
+		precision, symbol, _ := strings.Cut(token.Symbol, ",")
+		p, err := strconv.Atoi(precision)
+		if err != nil {
+			return config, fmt.Errorf("invalid symbol '%s'", token.Symbol)
+		}
+		config.SupportedTokens = append(config.SupportedTokens, atomicasset.PriceToken{
+			Contract:  token.Contract,
+			Symbol:    symbol,
+			Precision: p,
+		})
+	}
+	return config, nil
+}
+
+// Collections
+
+// Collection reads a collection from the collections table.
+// The data is decoded with the collection format of the config table.
+func (t Tables) Collection(ctx context.Context, name string) (atomicasset.Collection, error) {
+	row, err := find[collectionRow](ctx, t, t.request(t.contract(), "collections"), name)
+	if err != nil {
+		return atomicasset.Collection{}, err
+	}
+
+	config, err := t.Config(ctx)
+	if err != nil {
+		return atomicasset.Collection{}, err
+	}
+
+	data, err := serialization.Deserialize(row.Data, config.CollectionFormat)
+	if err != nil {
+		return atomicasset.Collection{}, fmt.Errorf("collection %s: %w", name, err)
+	}
+
+	fee, _ := strconv.ParseFloat(string(row.MarketFee), 64)
+	c := atomicasset.Collection{
+		CollectionName:     row.Name,
+		Contract:           t.contract(),
+		Author:             row.Author,
+		AllowNotify:        row.AllowNotify,
+		AuthorizedAccounts: row.AuthorizedAccounts,
+		NotifyAccounts:     row.NotifyAccounts,
+		MarketFee:          fee,
+		Data:               data,
+	}
+	c.Name, _ = data["name"].(string)
+	c.Image, _ = data["img"].(string)
+	return c, nil
+}
+
+func (t Tables) collectionRef(name string) atomicasset.Collection {
+	return atomicasset.Collection{CollectionName: name, Contract: t.contract()}
+}
+
+// Schemas
+
+// Schemas reads the schemas of collection.
+func (t Tables) Schemas(ctx context.Context, collection string) ([]atomicasset.Schema, error) {
+	rows, err := tableRows[schemaRow](ctx, t.RPC, t.request(collection, "schemas"))
+	if err != nil {
+		return nil, err
+	}
+
+	schemas := []atomicasset.Schema{}
+	for _, row := range rows {
+		schemas = append(schemas, t.schema(collection, row))
+	}
+	return schemas, nil
+}
+
+// Schema reads a schema of collection.
+func (t Tables) Schema(ctx context.Context, collection string, name string) (atomicasset.Schema, error) {
+	row, err := find[schemaRow](ctx, t, t.request(collection, "schemas"), name)
+	if err != nil {
+		return atomicasset.Schema{}, err
+	}
+	return t.schema(collection, row), nil
+}
+
+func (t Tables) schema(collection string, row schemaRow) atomicasset.Schema {
+	return atomicasset.Schema{
+		Name:       row.Name,
+		Contract:   t.contract(),
+		Format:     row.Format,
+		Collection: t.collectionRef(collection),
+	}
+}
+
+// Templates
+
+// Templates reads the templates of collection.
+// The data is decoded with the schema of each template.
+func (t Tables) Templates(ctx context.Context, collection string) ([]atomicasset.Template, error) {
+	rows, err := tableRows[templateRow](ctx, t.RPC, t.request(collection, "templates"))
+	if err != nil {
+		return nil, err
+	}
+
+	r := t.newReader(ctx)
+	templates := []atomicasset.Template{}
+	for _, row := range rows {
+		tmpl, err := r.template(collection, row)
+		if err != nil {
+			return nil, err
+		}
+		templates = append(templates, tmpl)
+	}
+	return templates, nil
+}
+
+// Template reads a template of collection.
+func (t Tables) Template(ctx context.Context, collection string, id string) (atomicasset.Template, error) {
+	row, err := find[templateRow](ctx, t, t.request(collection, "templates"), id)
+	if err != nil {
+		return atomicasset.Template{}, err
+	}
+	return t.newReader(ctx).template(collection, row)
+}
+
+// Assets
+
+// Assets reads the assets owned by owner.
+// The data is decoded with the schema of each asset and merged with the
+// data of its template.
+func (t Tables) Assets(ctx context.Context, owner string) ([]atomicasset.Asset, error) {
+	rows, err := tableRows[assetRow](ctx, t.RPC, t.request(owner, "assets"))
+	if err != nil {
+		return nil, err
+	}
+
+	r := t.newReader(ctx)
+	assets := []atomicasset.Asset{}
+	for _, row := range rows {
+		asset, err := r.asset(owner, row)
+		if err != nil {
+			return nil, err
+		}
+		assets = append(assets, asset)
+	}
+	return assets, nil
+}
+
+// Asset reads an asset owned by owner.
+// ErrNotFound is returned if owner does not own the asset.
+func (t Tables) Asset(ctx context.Context, owner string, id string) (atomicasset.Asset, error) {
+	row, err := find[assetRow](ctx, t, t.request(owner, "assets"), id)
+	if err != nil {
+		return atomicasset.Asset{}, err
+	}
+	return t.newReader(ctx).asset(owner, row)
+}
+
+// Offers
+
+// Offers reads the offers table. All offers in the table are pending.
+// The assets of the offers only have their id, contract and owner set.
+func (t Tables) Offers(ctx context.Context) ([]atomicasset.Offer, error) {
+	rows, err := tableRows[offerRow](ctx, t.RPC, t.request(t.contract(), "offers"))
+	if err != nil {
+		return nil, err
+	}
+
+	offers := []atomicasset.Offer{}
+	for _, row := range rows {
+		offers = append(offers, t.offer(row))
+	}
+	return offers, nil
+}
+
+// Offer reads an offer from the offers table.
+func (t Tables) Offer(ctx context.Context, id string) (atomicasset.Offer, error) {
+	row, err := find[offerRow](ctx, t, t.request(t.contract(), "offers"), id)
+	if err != nil {
+		return atomicasset.Offer{}, err
+	}
+	return t.offer(row), nil
+}
+
+func (t Tables) offer(row offerRow) atomicasset.Offer {
+	assets := func(owner string, ids []numberString) []atomicasset.Asset {
+		list := []atomicasset.Asset{}
+		for _, id := range ids {
+			list = append(list, atomicasset.Asset{ID: string(id), Contract: t.contract(), Owner: owner})
+		}
+		return list
+	}
+
+	return atomicasset.Offer{
+		ID:              string(row.ID),
+		Contract:        t.contract(),
+		Sender:          row.Sender,
+		Recipient:       row.Recipient,
+		Memo:            row.Memo,
+		State:           atomicasset.OfferStatePending,
+		SenderAssets:    assets(row.Sender, row.SenderAssetIDs),
+		RecipientAssets: assets(row.Recipient, row.RecipientAssetIDs),
+	}
+}
+
+// Reader
+
+// reader caches the schemas, templates and config needed to decode
+// several rows.
+type reader struct {
+	t         Tables
+	ctx       context.Context
+	config    *atomicasset.AssetsConfig
+	schemas   map[string]atomicasset.Schema
+	templates map[string]atomicasset.Template
+}
+
+func (t Tables) newReader(ctx context.Context) *reader {
+	return &reader{
+		t:         t,
+		ctx:       ctx,
+		schemas:   map[string]atomicasset.Schema{},
+		templates: map[string]atomicasset.Template{},
+	}
+}
+
+func (r *reader) schema(collection string, name string) (atomicasset.Schema, error) {
+	key := collection + "/" + name
+	if s, ok := r.schemas[key]; ok {
+		return s, nil
+	}
+
+	s, err := r.t.Schema(r.ctx, collection, name)
+	if err != nil {
+		return s, fmt.Errorf("schema %s/%s: %w", collection, name, err)
+	}
+	r.schemas[key] = s
+	return s, nil
+}
+
+func (r *reader) template(collection string, row templateRow) (atomicasset.Template, error) {
+	id := strconv.FormatInt(int64(row.ID), 10)
+	schema, err := r.schema(collection, row.Schema)
+	if err != nil {
+		return atomicasset.Template{}, err
+	}
+
+	data, err := serialization.Deserialize(row.ImmutableData, schema.Format)
+	if err != nil {
+		return atomicasset.Template{}, fmt.Errorf("template %s: %w", id, err)
+	}
+
+	tmpl := atomicasset.Template{
+		ID:             id,
+		Contract:       r.t.contract(),
+		MaxSupply:      strconv.FormatUint(uint64(row.MaxSupply), 10),
+		IssuedSupply:   strconv.FormatUint(uint64(row.IssuedSupply), 10),
+		IsTransferable: row.Transferable,
+		IsBurnable:     row.Burnable,
+		ImmutableData:  data,
+		Collection:     r.t.collectionRef(collection),
+		Schema:         atomicasset.InlineSchema{Name: schema.Name, Format: schema.Format},
+	}
+	r.templates[collection+"/"+id] = tmpl
+	return tmpl, nil
+}
+
+func (r *reader) findTemplate(collection string, id int32) (atomicasset.Template, error) {
+	key := collection + "/" + strconv.FormatInt(int64(id), 10)
+	if tmpl, ok := r.templates[key]; ok {
+		return tmpl, nil
+	}
+
+	req := r.t.request(collection, "templates")
+	row, err := find[templateRow](r.ctx, r.t, req, strconv.FormatInt(int64(id), 10))
+	if err != nil {
+		return atomicasset.Template{}, fmt.Errorf("template %d: %w", id, err)
+	}
+	return r.template(collection, row)
+}
+
+func (r *reader) tokenContract(symbol string) (string, error) {
+	if r.config == nil {
+		config, err := r.t.Config(r.ctx)
+		if err != nil {
+			return "", err
+		}
+		r.config = &config
+	}
+
+	for _, token := range r.config.SupportedTokens {
+		if token.Symbol == symbol {
+			return token.Contract, nil
+		}
+	}
+	return "", nil
+}
+
+func (r *reader) asset(owner string, row assetRow) (atomicasset.Asset, error) {
+	id := string(row.ID)
+	schema, err := r.schema(row.Collection, row.Schema)
+	if err != nil {
+		return atomicasset.Asset{}, err
+	}
+
+	immutable, err := serialization.Deserialize(row.ImmutableData, schema.Format)
+	if err != nil {
+		return atomicasset.Asset{}, fmt.Errorf("asset %s: %w", id, err)
+	}
+
+	mutable, err := serialization.Deserialize(row.MutableData, schema.Format)
+	if err != nil {
+		return atomicasset.Asset{}, fmt.Errorf("asset %s: %w", id, err)
+	}
+
+	asset := atomicasset.Asset{
+		ID:             id,
+		Contract:       r.t.contract(),
+		Owner:          owner,
+		IsTransferable: true,
+		IsBurnable:     true,
+		Collection:     r.t.collectionRef(row.Collection),
+		Schema:         atomicasset.InlineSchema{Name: schema.Name, Format: schema.Format},
+		BackedTokens:   []atomicasset.Token{},
+		ImmutableData:  immutable,
+		MutableData:    mutable,
+		Data:           map[string]interface{}{},
+	}
+
+	for _, s := range row.BackedTokens {
+		token, err := atomicasset.ParseAsset(s)
+		if err != nil {
+			return atomicasset.Asset{}, fmt.Errorf("asset %s: %w", id, err)
+		}
+
+		if token.Contract, err = r.tokenContract(token.Symbol); err != nil {
+			return atomicasset.Asset{}, err
+		}
+		asset.BackedTokens = append(asset.BackedTokens, token)
+	}
+
+	// Same precedence as the api and atomicasset.Asset.DecodeData, immutable
+	// data overrides mutable data and template data overrides asset data.
+	sources := []map[string]interface{}{mutable, immutable}
+	if row.TemplateID >= 0 {
+		tmpl, err := r.findTemplate(row.Collection, row.TemplateID)
+		if err != nil {
+			return atomicasset.Asset{}, fmt.Errorf("asset %s: %w", id, err)
+		}
+		asset.Template = tmpl
+		asset.IsTransferable = tmpl.IsTransferable
+		asset.IsBurnable = tmpl.IsBurnable
+		sources = append(sources, tmpl.ImmutableData)
+	}
+
+	for _, data := range sources {
+		for k, v := range data {
+			asset.Data[k] = v
+		}
+	}
+	asset.Name, _ = asset.Data["name"].(string)
+	return asset, nil
+}
@@ -0,0 +1,452 @@
+package chain
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"testing"
+
+	"github.com/eosswedenorg-go/atomicasset"
+	"github.com/eosswedenorg-go/atomicasset/eosio"
+	"github.com/eosswedenorg-go/atomicasset/serialization"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// tableRow is a row in the stand-in chain, stored by primary key.
+type tableRow struct {
+	key  uint64
+	data map[string]interface{}
+}
+
+// tableNode is a local stand-in for get_table_rows.
+type tableNode struct {
+	tables   map[string][]tableRow
+	requests []TableRowsRequest
+}
+
+// primaryKey converts a bound the same way nodeos does, as a number or a name.
+func primaryKey(t *testing.T, s string) uint64 {
+	if n, err := strconv.ParseUint(s, 10, 64); err == nil {
+		return n
+	}
+	n, err := eosio.NameToUint64(s)
+	require.NoError(t, err)
+	return n
+}
+
+func (n *tableNode) add(t *testing.T, scope string, table string, key string, data map[string]interface{}) {
+	id := scope + "/" + table
+	n.tables[id] = append(n.tables[id], tableRow{key: primaryKey(t, key), data: data})
+	sort.Slice(n.tables[id], func(i, j int) bool { return n.tables[id][i].key < n.tables[id][j].key })
+}
+
+func newTableNode(t *testing.T) (*tableNode, *httptest.Server) {
+	n := &tableNode{tables: map[string][]tableRow{}}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		res.Header().Set("Content-type", "application/json; charset=utf-8")
+		assert.Equal(t, "/v1/chain/get_table_rows", req.URL.Path)
+
+		var body struct {
+			TableRowsRequest
+			JSON bool `json:"json"`
+		}
+		require.NoError(t, json.NewDecoder(req.Body).Decode(&body))
+		assert.True(t, body.JSON)
+		assert.Equal(t, "atomicassets", body.Code)
+		n.requests = append(n.requests, body.TableRowsRequest)
+
+		lower, upper := uint64(0), ^uint64(0)
+		if len(body.LowerBound) > 0 {
+			lower = primaryKey(t, body.LowerBound)
+		}
+		if len(body.UpperBound) > 0 {
+			upper = primaryKey(t, body.UpperBound)
+		}
+
+		resp := TableRowsResponse{Rows: []json.RawMessage{}}
+		for _, row := range n.tables[body.Scope+"/"+body.Table] {
+			if row.key < lower || row.key > upper {
+				continue
+			}
+
+			if len(resp.Rows) == body.Limit {
+				resp.More = true
+				resp.NextKey = strconv.FormatUint(row.key, 10)
+				break
+			}
+
+			b, err := json.Marshal(row.data)
+			require.NoError(t, err)
+			resp.Rows = append(resp.Rows, b)
+		}
+		assert.NoError(t, json.NewEncoder(res).Encode(resp))
+	}))
+	return n, srv
+}
+
+// serialized returns data in the json format of a uint8[] column.
+func serialized(t *testing.T, data map[string]interface{}, format []atomicasset.SchemaFormat) []int {
+	b, err := serialization.Serialize(data, format)
+	require.NoError(t, err)
+
+	v := []int{}
+	for _, c := range b {
+		v = append(v, int(c))
+	}
+	return v
+}
+
+var (
+	testCollectionFormat = []atomicasset.SchemaFormat{
+		{Name: "name", Type: "string"},
+		{Name: "img", Type: "ipfs"},
+	}
+
+	testSchemaFormat = []atomicasset.SchemaFormat{
+		{Name: "name", Type: "string"},
+		{Name: "img", Type: "image"},
+		{Name: "level", Type: "uint8"},
+		{Name: "power", Type: "uint64"},
+	}
+)
+
+// newTestTables creates a stand-in chain with the collection "mycollection",
+// the schema "heroes", template 1 and assets owned by alice.
+func newTestTables(t *testing.T) (*tableNode, *httptest.Server) {
+	n, srv := newTableNode(t)
+
+	n.add(t, "atomicassets", "config", "config", map[string]interface{}{
+		"asset_counter":     "1099511627800",
+		"template_counter":  2,
+		"offer_counter":     "10",
+		"collection_format": testCollectionFormat,
+		"supported_tokens":  []interface{}{map[string]interface{}{"contract": "eosio.token", "sym": "8,WAX"}},
+	})
+
+	n.add(t, "atomicassets", "collections", "mycollection", map[string]interface{}{
+		"collection_name":     "mycollection",
+		"author":              "alice",
+		"allow_notify":        true,
+		"authorized_accounts": []string{"alice"},
+		"notify_accounts":     []string{},
+		"market_fee":          "0.05000000000000000",
+		"serialized_data": serialized(t, map[string]interface{}{
+			"name": "My Collection",
+			"img":  "QmZ4sYGSuTLHqk8ZxPNaKJv9c8n2D4KQqVHXNTrd5X1w8r",
+		}, testCollectionFormat),
+	})
+
+	n.add(t, "mycollection", "schemas", "heroes", map[string]interface{}{
+		"schema_name": "heroes",
+		"format":      testSchemaFormat,
+	})
+
+	n.add(t, "mycollection", "templates", "1", map[string]interface{}{
+		"template_id":   1,
+		"schema_name":   "heroes",
+		"transferable":  true,
+		"burnable":      false,
+		"max_supply":    100,
+		"issued_supply": 2,
+		"immutable_serialized_data": serialized(t, map[string]interface{}{
+			"name": "Hero",
+			"img":  "QmZ4sYGSuTLHqk8ZxPNaKJv9c8n2D4KQqVHXNTrd5X1w8r",
+		}, testSchemaFormat),
+	})
+
+	n.add(t, "alice", "assets", "1099511627776", map[string]interface{}{
+		"asset_id":                  "1099511627776",
+		"collection_name":           "mycollection",
+		"schema_name":               "heroes",
+		"template_id":               1,
+		"ram_payer":                 "alice",
+		"backed_tokens":             []string{"1.50000000 WAX"},
+		"immutable_serialized_data": serialized(t, map[string]interface{}{"name": "Ignored", "power": "18446744073709551615"}, testSchemaFormat),
+		"mutable_serialized_data":   serialized(t, map[string]interface{}{"level": 3}, testSchemaFormat),
+	})
+
+	n.add(t, "alice", "assets", "1099511627777", map[string]interface{}{
+		"asset_id":                  "1099511627777",
+		"collection_name":           "mycollection",
+		"schema_name":               "heroes",
+		"template_id":               -1,
+		"ram_payer":                 "alice",
+		"backed_tokens":             []string{},
+		"immutable_serialized_data": "0408536964656b69636b",
+		"mutable_serialized_data":   []int{},
+	})
+
+	n.add(t, "atomicassets", "offers", "7", map[string]interface{}{
+		"offer_id":            "7",
+		"sender":              "alice",
+		"recipient":           "bob",
+		"sender_asset_ids":    []string{"1099511627776"},
+		"recipient_asset_ids": []string{},
+		"memo":                "trade?",
+		"ram_payer":           "alice",
+	})
+	return n, srv
+}
+
+func TestTables_Collection(t *testing.T) {
+	_, srv := newTestTables(t)
+	defer srv.Close()
+
+	tables := NewTables(New(srv.URL))
+
+	config, err := tables.Config(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, atomicasset.AssetsConfig{
+		Contract:         "atomicassets",
+		CollectionFormat: testCollectionFormat,
+		SupportedTokens:  []atomicasset.PriceToken{{Contract: "eosio.token", Symbol: "WAX", Precision: 8}},
+	}, config)
+
+	c, err := tables.Collection(context.Background(), "mycollection")
+	require.NoError(t, err)
+	assert.Equal(t, atomicasset.Collection{
+		CollectionName:     "mycollection",
+		Contract:           "atomicassets",
+		Name:               "My Collection",
+		Image:              "QmZ4sYGSuTLHqk8ZxPNaKJv9c8n2D4KQqVHXNTrd5X1w8r",
+		Author:             "alice",
+		AllowNotify:        true,
+		AuthorizedAccounts: []string{"alice"},
+		NotifyAccounts:     []string{},
+		MarketFee:          0.05,
+		Data: map[string]interface{}{
+			"name": "My Collection",
+			"img":  "QmZ4sYGSuTLHqk8ZxPNaKJv9c8n2D4KQqVHXNTrd5X1w8r",
+		},
+	}, c)
+
+	_, err = tables.Collection(context.Background(), "unknown")
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestTables_Schemas(t *testing.T) {
+	_, srv := newTestTables(t)
+	defer srv.Close()
+
+	tables := NewTables(New(srv.URL))
+	expected := atomicasset.Schema{
+		Name:       "heroes",
+		Contract:   "atomicassets",
+		Format:     testSchemaFormat,
+		Collection: atomicasset.Collection{CollectionName: "mycollection", Contract: "atomicassets"},
+	}
+
+	schemas, err := tables.Schemas(context.Background(), "mycollection")
+	require.NoError(t, err)
+	assert.Equal(t, []atomicasset.Schema{expected}, schemas)
+
+	schema, err := tables.Schema(context.Background(), "mycollection", "heroes")
+	require.NoError(t, err)
+	assert.Equal(t, expected, schema)
+
+	_, err = tables.Schema(context.Background(), "mycollection", "villains")
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestTables_Templates(t *testing.T) {
+	_, srv := newTestTables(t)
+	defer srv.Close()
+
+	tables := NewTables(New(srv.URL))
+	expected := atomicasset.Template{
+		ID:             "1",
+		Contract:       "atomicassets",
+		MaxSupply:      "100",
+		IssuedSupply:   "2",
+		IsTransferable: true,
+		IsBurnable:     false,
+		ImmutableData: map[string]interface{}{
+			"name": "Hero",
+			"img":  "QmZ4sYGSuTLHqk8ZxPNaKJv9c8n2D4KQqVHXNTrd5X1w8r",
+		},
+		Collection: atomicasset.Collection{CollectionName: "mycollection", Contract: "atomicassets"},
+		Schema:     atomicasset.InlineSchema{Name: "heroes", Format: testSchemaFormat},
+	}
+
+	templates, err := tables.Templates(context.Background(), "mycollection")
+	require.NoError(t, err)
+	assert.Equal(t, []atomicasset.Template{expected}, templates)
+
+	tmpl, err := tables.Template(context.Background(), "mycollection", "1")
+	require.NoError(t, err)
+	assert.Equal(t, expected, tmpl)
+
+	_, err = tables.Template(context.Background(), "mycollection", "2")
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestTables_Assets(t *testing.T) {
+	n, srv := newTestTables(t)
+	defer srv.Close()
+
+	tables := NewTables(New(srv.URL))
+
+	assets, err := tables.Assets(context.Background(), "alice")
+	require.NoError(t, err)
+	require.Len(t, assets, 2)
+
+	a := assets[0]
+	assert.Equal(t, "1099511627776", a.ID)
+	assert.Equal(t, "atomicassets", a.Contract)
+	assert.Equal(t, "alice", a.Owner)
+	assert.Equal(t, "Hero", a.Name)
+	assert.True(t, a.IsTransferable)
+	assert.False(t, a.IsBurnable)
+	assert.Equal(t, "mycollection", a.Collection.CollectionName)
+	assert.Equal(t, atomicasset.InlineSchema{Name: "heroes", Format: testSchemaFormat}, a.Schema)
+	assert.Equal(t, "1", a.Template.ID)
+	assert.Equal(t, []atomicasset.Token{{Contract: "eosio.token", Symbol: "WAX", Precision: 8, Amount: "150000000"}}, a.BackedTokens)
+	assert.Equal(t, map[string]interface{}{"name": "Ignored", "power": uint64(18446744073709551615)}, a.ImmutableData)
+	assert.Equal(t, map[string]interface{}{"level": uint64(3)}, a.MutableData)
+
+	// Template data overrides asset data.
+	assert.Equal(t, map[string]interface{}{
+		"name":  "Hero",
+		"img":   "QmZ4sYGSuTLHqk8ZxPNaKJv9c8n2D4KQqVHXNTrd5X1w8r",
+		"level": uint64(3),
+		"power": uint64(18446744073709551615),
+	}, a.Data)
+
+	// Assets without a template, with data as hex.
+	a = assets[1]
+	assert.Equal(t, "1099511627777", a.ID)
+	assert.Equal(t, "Sidekick", a.Name)
+	assert.True(t, a.IsTransferable)
+	assert.True(t, a.IsBurnable)
+	assert.Equal(t, atomicasset.Template{}, a.Template)
+	assert.Equal(t, map[string]interface{}{}, a.MutableData)
+
+	a, err = tables.Asset(context.Background(), "alice", "1099511627777")
+	require.NoError(t, err)
+	assert.Equal(t, assets[1], a)
+
+	_, err = tables.Asset(context.Background(), "bob", "1099511627777")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	// The assets table is scoped by owner.
+	assert.Equal(t, TableRowsRequest{Code: "atomicassets", Scope: "alice", Table: "assets", Limit: 100}, n.requests[0])
+}
+
+func TestTables_DecodeData(t *testing.T) {
+	n, srv := newTestTables(t)
+	defer srv.Close()
+
+	n.add(t, "carol", "assets", "1099511627778", map[string]interface{}{
+		"asset_id":                  "1099511627778",
+		"collection_name":           "mycollection",
+		"schema_name":               "heroes",
+		"template_id":               1,
+		"immutable_serialized_data": serialized(t, map[string]interface{}{"name": "Immutable", "level": 2}, testSchemaFormat),
+		"mutable_serialized_data":   serialized(t, map[string]interface{}{"name": "Mutable", "level": 5, "power": 9}, testSchemaFormat),
+	})
+
+	a, err := NewTables(New(srv.URL)).Asset(context.Background(), "carol", "1099511627778")
+	require.NoError(t, err)
+
+	type hero struct {
+		Name  string `atomic:"name"`
+		Img   string `atomic:"img"`
+		Level uint8  `atomic:"level"`
+		Power uint64 `atomic:"power"`
+	}
+
+	var merged hero
+	require.NoError(t, atomicasset.UnmarshalAttributes(a.Data, a.Schema.Format, &merged))
+	assert.Equal(t, hero{Name: "Hero", Img: "QmZ4sYGSuTLHqk8ZxPNaKJv9c8n2D4KQqVHXNTrd5X1w8r", Level: 2, Power: 9}, merged)
+
+	// DecodeData merges the asset and template data the same way.
+	a.Data = nil
+	var decoded hero
+	require.NoError(t, a.DecodeData(&decoded))
+	assert.Equal(t, merged, decoded)
+}
+
+func TestTables_Pages(t *testing.T) {
+	n, srv := newTestTables(t)
+	defer srv.Close()
+
+	for i := 0; i < 250; i++ {
+		n.add(t, "bob", "assets", strconv.Itoa(i+1), map[string]interface{}{
+			"asset_id":        strconv.Itoa(i + 1),
+			"collection_name": "mycollection",
+			"schema_name":     "heroes",
+			"template_id":     1,
+			"backed_tokens":   []string{},
+		})
+	}
+
+	n.requests = nil
+	assets, err := NewTables(New(srv.URL)).Assets(context.Background(), "bob")
+	require.NoError(t, err)
+	require.Len(t, assets, 250)
+	assert.Equal(t, "250", assets[249].ID)
+
+	// 3 pages of assets, then the schema and template once.
+	require.Len(t, n.requests, 5)
+	assert.Equal(t, "101", n.requests[1].LowerBound)
+	assert.Equal(t, "201", n.requests[2].LowerBound)
+}
+
+func TestTables_Offers(t *testing.T) {
+	_, srv := newTestTables(t)
+	defer srv.Close()
+
+	tables := NewTables(New(srv.URL))
+	expected := atomicasset.Offer{
+		ID:              "7",
+		Contract:        "atomicassets",
+		Sender:          "alice",
+		Recipient:       "bob",
+		Memo:            "trade?",
+		State:           atomicasset.OfferStatePending,
+		SenderAssets:    []atomicasset.Asset{{ID: "1099511627776", Contract: "atomicassets", Owner: "alice"}},
+		RecipientAssets: []atomicasset.Asset{},
+	}
+
+	offers, err := tables.Offers(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []atomicasset.Offer{expected}, offers)
+
+	offer, err := tables.Offer(context.Background(), "7")
+	require.NoError(t, err)
+	assert.Equal(t, expected, offer)
+
+	_, err = tables.Offer(context.Background(), "8")
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestTables_InvalidData(t *testing.T) {
+	n, srv := newTestTables(t)
+	defer srv.Close()
+
+	n.add(t, "carol", "assets", "1", map[string]interface{}{
+		"asset_id":                  "1",
+		"collection_name":           "mycollection",
+		"schema_name":               "heroes",
+		"template_id":               -1,
+		"immutable_serialized_data": []int{42},
+	})
+
+	_, err := NewTables(New(srv.URL)).Assets(context.Background(), "carol")
+	assert.EqualError(t, err, "asset 1: serialization: unknown attribute identifier 42")
+
+	n.add(t, "dave", "assets", "1", map[string]interface{}{
+		"asset_id":        "1",
+		"collection_name": "mycollection",
+		"schema_name":     "villains",
+		"template_id":     -1,
+	})
+
+	_, err = NewTables(New(srv.URL)).Assets(context.Background(), "dave")
+	assert.EqualError(t, err, "schema mycollection/villains: not found in table")
+}
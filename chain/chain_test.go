@@ -0,0 +1,269 @@
+package chain
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/eosswedenorg-go/atomicasset"
+	"github.com/eosswedenorg-go/atomicasset/actions"
+	"github.com/eosswedenorg-go/atomicasset/eosio"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testKey     = "5KQwrPbwdL6PhXujxW37FSSQZ1JiwsST4cqQzDeyXtP79zkvFD3"
+	testChainID = "1064487b3cd1a897ce03ae5b6a865651747e2e152090f99c1d19d44e01aea5a4"
+	testBlockID = "0123abcd000000001122334400000000000000000000000000000000000000ff"
+)
+
+// node is a local stand-in for a nodeos chain api.
+type node struct {
+	t      *testing.T
+	info   Info
+	pushed []PackedTransaction
+	keys   []eosio.PublicKey
+}
+
+func newNode(t *testing.T) (*node, *httptest.Server) {
+	n := &node{
+		t: t,
+		info: Info{
+			ServerVersion:            "v3.1.0",
+			ChainID:                  testChainID,
+			HeadBlockNum:             19113950,
+			HeadBlockID:              "0123abce000000005566778800000000000000000000000000000000000000ff",
+			HeadBlockTime:            Time{time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)},
+			HeadBlockProducer:        "eosswedenorg",
+			LastIrreversibleBlockNum: 19113949,
+			LastIrreversibleBlockID:  testBlockID,
+		},
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		res.Header().Set("Content-type", "application/json; charset=utf-8")
+		assert.Equal(t, "POST", req.Method)
+
+		switch req.URL.Path {
+		case "/v1/chain/get_info":
+			assert.NoError(t, json.NewEncoder(res).Encode(n.info))
+		case "/v1/chain/push_transaction":
+			n.push(res, req)
+		default:
+			res.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	return n, srv
+}
+
+func (n *node) push(res http.ResponseWriter, req *http.Request) {
+	var tx PackedTransaction
+	require.NoError(n.t, json.NewDecoder(req.Body).Decode(&tx))
+	n.pushed = append(n.pushed, tx)
+
+	packed, err := hex.DecodeString(tx.PackedTrx)
+	require.NoError(n.t, err)
+
+	digest, err := Digest(n.info.ChainID, packed)
+	require.NoError(n.t, err)
+
+	// Recover the keys the same way nodeos does.
+	for _, sig := range tx.Signatures {
+		key, err := sig.PublicKey(digest)
+		require.NoError(n.t, err)
+		n.keys = append(n.keys, key)
+	}
+
+	if len(tx.Signatures) < 1 {
+		res.WriteHeader(http.StatusInternalServerError)
+		_, _ = res.Write([]byte(`{
+			"code": 500,
+			"message": "Internal Service Error",
+			"error": {
+				"code": 3090003,
+				"name": "unsatisfied_authorization",
+				"what": "Provided keys, permissions, and delays do not satisfy declared authorizations",
+				"details": [{"message": "transaction declares authority '{\"actor\":\"alice\",\"permission\":\"active\"}', but does not have signatures for it."}]
+			}
+		}`))
+		return
+	}
+
+	_, _ = res.Write([]byte(`{"transaction_id": "` + idOf(packed) + `", "processed": {"receipt": {"status": "executed"}}}`))
+}
+
+func idOf(packed []byte) string {
+	sum := sha256.Sum256(packed)
+	return hex.EncodeToString(sum[:])
+}
+
+func mustHex(t *testing.T, s string) []byte {
+	b, err := hex.DecodeString(s)
+	require.NoError(t, err)
+	return b
+}
+
+func TestTransaction_Pack(t *testing.T) {
+	tx := NewTransaction()
+	require.NoError(t, tx.SetTAPOS(testBlockID, time.Date(2020, 1, 1, 0, 0, 0, 500, time.UTC)))
+
+	assert.Equal(t, uint16(0xabcd), tx.RefBlockNum)
+	assert.Equal(t, uint32(0x44332211), tx.RefBlockPrefix)
+	assert.Equal(t, time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), tx.Expiration.Time)
+
+	b, err := eosio.Pack(tx)
+	require.NoError(t, err)
+	assert.Equal(t, "00e10b5e"+"cdab"+"11223344"+"00"+"00"+"00"+"00"+"00"+"00", hex.EncodeToString(b))
+
+	id, err := tx.ID()
+	require.NoError(t, err)
+	assert.Len(t, id, 64)
+
+	assert.EqualError(t, tx.SetTAPOS("0123", time.Now()), "invalid block id '0123'")
+
+	tx.Extensions = []Extension{{Type: 1, Data: "xyz"}}
+	_, err = eosio.Pack(tx)
+	assert.EqualError(t, err, "transaction extension 1: invalid hex data")
+}
+
+func TestTransaction_JSON(t *testing.T) {
+	tx := NewTransaction()
+	require.NoError(t, tx.SetTAPOS(testBlockID, time.Date(2020, 1, 1, 0, 0, 30, 0, time.UTC)))
+
+	b, err := json.Marshal(tx)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"expiration": "2020-01-01T00:00:30",
+		"ref_block_num": 43981,
+		"ref_block_prefix": 1144201745,
+		"max_net_usage_words": 0,
+		"max_cpu_usage_ms": 0,
+		"delay_sec": 0,
+		"context_free_actions": [],
+		"actions": null,
+		"transaction_extensions": []
+	}`, string(b))
+
+	var v Time
+	require.NoError(t, json.Unmarshal([]byte(`"2020-01-01T00:00:30.500"`), &v))
+	assert.Equal(t, time.Date(2020, 1, 1, 0, 0, 30, 0, time.UTC), v.Time)
+	assert.Error(t, json.Unmarshal([]byte(`"yesterday"`), &v))
+}
+
+func TestDigest(t *testing.T) {
+	_, err := Digest("abc", nil)
+	assert.EqualError(t, err, "invalid chain id 'abc'")
+
+	a, err := Digest(testChainID, []byte{1})
+	require.NoError(t, err)
+	b, err := Digest(testChainID, []byte{2})
+	require.NoError(t, err)
+	assert.NotEqual(t, a, b)
+}
+
+func TestSign(t *testing.T) {
+	signer, err := NewKeySigner(testKey)
+	require.NoError(t, err)
+
+	tx := NewTransaction()
+	require.NoError(t, tx.SetTAPOS(testBlockID, time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)))
+
+	signed, err := Sign(context.Background(), testChainID, tx, signer)
+	require.NoError(t, err)
+	require.Len(t, signed.Signatures, 1)
+
+	packed, err := eosio.Pack(tx)
+	require.NoError(t, err)
+	digest, err := Digest(testChainID, packed)
+	require.NoError(t, err)
+
+	key, err := signed.Signatures[0].PublicKey(digest)
+	require.NoError(t, err)
+	assert.Equal(t, "EOS6MRyAjQq8ud7hVNYcfnVPJqcVpscN5So8BhtHuGYqET5GDW5CV", key.String())
+
+	p, err := signed.Pack()
+	require.NoError(t, err)
+	assert.Equal(t, "none", p.Compression)
+	assert.Equal(t, hex.EncodeToString(packed), p.PackedTrx)
+
+	_, err = NewKeySigner("invalid")
+	assert.Error(t, err)
+
+	_, err = Sign(context.Background(), testChainID, tx, KeySigner{})
+	assert.EqualError(t, err, "no keys to sign with")
+}
+
+func TestTransact(t *testing.T) {
+	n, srv := newNode(t)
+	defer srv.Close()
+
+	signer, err := NewKeySigner(testKey)
+	require.NoError(t, err)
+
+	transfer, err := actions.AtomicAssets{}.Transfer("alice", "bob", []atomicasset.Asset{{ID: "1", Owner: "alice", IsTransferable: true}}, "")
+	require.NoError(t, err)
+
+	resp, err := Transact(context.Background(), New(srv.URL), signer, []eosio.Action{transfer}, Options{})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"receipt": {"status": "executed"}}`, string(resp.Processed))
+
+	require.Len(t, n.pushed, 1)
+	require.Len(t, n.keys, 1)
+	assert.Equal(t, idOf(mustHex(t, n.pushed[0].PackedTrx)), resp.TransactionID)
+	assert.Equal(t, signer[0].PublicKey(), n.keys[0])
+
+	// The transfer is packed in the transaction after the TAPOS header.
+	b, err := eosio.Pack(transfer)
+	require.NoError(t, err)
+	assert.Equal(t, "1ee10b5e"+"cdab"+"11223344"+"00"+"00"+"00"+"00"+"01"+hex.EncodeToString(b)+"00", n.pushed[0].PackedTrx)
+
+	// Reference the head block with a custom expiration.
+	_, err = Transact(context.Background(), New(srv.URL), signer, []eosio.Action{transfer}, Options{Expiration: time.Minute, UseHeadBlock: true})
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(n.pushed[1].PackedTrx, "3ce10b5e"+"ceab"+"55667788"))
+
+	_, err = Transact(context.Background(), New(srv.URL), signer, nil, Options{})
+	assert.EqualError(t, err, "transaction has no actions")
+}
+
+func TestTransact_SignerFunc(t *testing.T) {
+	_, srv := newNode(t)
+	defer srv.Close()
+
+	var req SignRequest
+	signer := SignerFunc(func(ctx context.Context, r SignRequest) ([]eosio.Signature, error) {
+		req = r
+		return nil, nil
+	})
+
+	transfer, err := actions.AtomicAssets{}.Transfer("alice", "bob", []atomicasset.Asset{{ID: "1", Owner: "alice", IsTransferable: true}}, "")
+	require.NoError(t, err)
+
+	_, err = Transact(context.Background(), New(srv.URL), signer, []eosio.Action{transfer}, Options{})
+	assert.EqualError(t, err, "chain api: Provided keys, permissions, and delays do not satisfy declared authorizations: "+
+		`transaction declares authority '{"actor":"alice","permission":"active"}', but does not have signatures for it. (500)`)
+
+	assert.Equal(t, testChainID, req.ChainID)
+	assert.Equal(t, uint16(0xabcd), req.Transaction.RefBlockNum)
+
+	digest, err := Digest(testChainID, req.Packed)
+	require.NoError(t, err)
+	assert.Equal(t, digest, req.Digest)
+}
+
+func TestClient_Status(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		res.WriteHeader(http.StatusBadGateway)
+	}))
+	defer srv.Close()
+
+	_, err := New(srv.URL).GetInfo(context.Background())
+	assert.EqualError(t, err, "chain api: '/v1/chain/get_info' returned status 502")
+}
@@ -0,0 +1,188 @@
+package chain
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/eosswedenorg-go/atomicasset/eosio"
+)
+
+// Types
+
+// Info is the response of get_info.
+type Info struct {
+	ServerVersion            string `json:"server_version"`
+	ChainID                  string `json:"chain_id"`
+	HeadBlockNum             uint32 `json:"head_block_num"`
+	HeadBlockID              string `json:"head_block_id"`
+	HeadBlockTime            Time   `json:"head_block_time"`
+	HeadBlockProducer        string `json:"head_block_producer"`
+	LastIrreversibleBlockNum uint32 `json:"last_irreversible_block_num"`
+	LastIrreversibleBlockID  string `json:"last_irreversible_block_id"`
+}
+
+// PushResponse is the response of push_transaction.
+type PushResponse struct {
+	TransactionID string          `json:"transaction_id"`
+	Processed     json.RawMessage `json:"processed"`
+}
+
+// APIError is an error returned by the chain api.
+type APIError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+	Err     struct {
+		Code    int    `json:"code"`
+		Name    string `json:"name"`
+		What    string `json:"what"`
+		Details []struct {
+			Message string `json:"message"`
+		} `json:"details"`
+	} `json:"error"`
+}
+
+func (e *APIError) Error() string {
+	msg := e.Message
+	if len(e.Err.What) > 0 {
+		msg = e.Err.What
+	}
+
+	if len(e.Err.Details) > 0 {
+		msg += ": " + e.Err.Details[0].Message
+	}
+	return fmt.Sprintf("chain api: %s (%d)", msg, e.Code)
+}
+
+// RPC is a chain api that transactions can be pushed to.
+type RPC interface {
+	GetInfo(ctx context.Context) (Info, error)
+	PushTransaction(ctx context.Context, tx PackedTransaction) (PushResponse, error)
+}
+
+// Client is an RPC that talks to a nodeos compatible http api.
+type Client struct {
+	URL string
+
+	// HTTPClient defaults to http.DefaultClient.
+	HTTPClient *http.Client
+}
+
+// New creates a client for the api at url.
+func New(url string) *Client {
+	return &Client{URL: url}
+}
+
+// GetInfo fetches "/v1/chain/get_info".
+func (c *Client) GetInfo(ctx context.Context) (Info, error) {
+	var info Info
+	err := c.post(ctx, "/v1/chain/get_info", struct{}{}, &info)
+	return info, err
+}
+
+// PushTransaction posts tx to "/v1/chain/push_transaction".
+func (c *Client) PushTransaction(ctx context.Context, tx PackedTransaction) (PushResponse, error) {
+	var resp PushResponse
+	err := c.post(ctx, "/v1/chain/push_transaction", tx, &resp)
+	return resp, err
+}
+
+func (c *Client) post(ctx context.Context, path string, body interface{}, v interface{}) error {
+	b, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", strings.TrimSuffix(c.URL, "/")+path, bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	client := c.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		apiErr := &APIError{Code: resp.StatusCode}
+		if err := json.NewDecoder(resp.Body).Decode(apiErr); err != nil || len(apiErr.Message) < 1 {
+			return fmt.Errorf("chain api: '%s' returned status %d", path, resp.StatusCode)
+		}
+		return apiErr
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+// Transacting
+
+// Options controls how transactions are created.
+type Options struct {
+	// Expiration of the transaction relative to the head block time,
+	// DefaultExpiration if zero.
+	Expiration time.Duration
+
+	// UseHeadBlock references the head block instead of the last
+	// irreversible block. The transaction is applied faster but can be
+	// dropped if the referenced block is forked out.
+	UseHeadBlock bool
+}
+
+// Prepare creates a transaction of actions with TAPOS from get_info.
+// It returns the transaction and the chain id to sign it for.
+func Prepare(ctx context.Context, rpc RPC, actions []eosio.Action, opts Options) (Transaction, string, error) {
+	info, err := rpc.GetInfo(ctx)
+	if err != nil {
+		return Transaction{}, "", err
+	}
+
+	expiration := opts.Expiration
+	if expiration == 0 {
+		expiration = DefaultExpiration
+	}
+
+	blockID := info.LastIrreversibleBlockID
+	if opts.UseHeadBlock || len(blockID) < 1 {
+		blockID = info.HeadBlockID
+	}
+
+	tx := NewTransaction(actions...)
+	if err := tx.SetTAPOS(blockID, info.HeadBlockTime.Add(expiration)); err != nil {
+		return Transaction{}, "", err
+	}
+	return tx, info.ChainID, nil
+}
+
+// Transact creates a transaction of actions, signs it with signer and
+// pushes it through rpc.
+func Transact(ctx context.Context, rpc RPC, signer Signer, actions []eosio.Action, opts Options) (PushResponse, error) {
+	if len(actions) < 1 {
+		return PushResponse{}, fmt.Errorf("transaction has no actions")
+	}
+
+	tx, chainID, err := Prepare(ctx, rpc, actions, opts)
+	if err != nil {
+		return PushResponse{}, err
+	}
+
+	signed, err := Sign(ctx, chainID, tx, signer)
+	if err != nil {
+		return PushResponse{}, err
+	}
+
+	packed, err := signed.Pack()
+	if err != nil {
+		return PushResponse{}, err
+	}
+	return rpc.PushTransaction(ctx, packed)
+}
@@ -0,0 +1,93 @@
+package chain
+
+import (
+	"context"
+	"errors"
+
+	"github.com/eosswedenorg-go/atomicasset/eosio"
+)
+
+// SignRequest holds a transaction to sign.
+type SignRequest struct {
+	ChainID     string
+	Transaction Transaction
+
+	// Packed is the serialized transaction.
+	Packed []byte
+
+	// Digest is the hash to sign, computed from the chain id and Packed.
+	Digest [32]byte
+}
+
+// Signer signs transactions.
+//
+// Signers that hold keys can sign Digest, external signers (wallets,
+// hardware or remote signing services) can use the transaction or its
+// packed form instead.
+type Signer interface {
+	Sign(ctx context.Context, req SignRequest) ([]eosio.Signature, error)
+}
+
+// SignerFunc calls a function to sign transactions.
+type SignerFunc func(ctx context.Context, req SignRequest) ([]eosio.Signature, error)
+
+func (f SignerFunc) Sign(ctx context.Context, req SignRequest) ([]eosio.Signature, error) {
+	return f(ctx, req)
+}
+
+// KeySigner signs transactions with private keys held in memory.
+// Each key adds one signature.
+type KeySigner []eosio.PrivateKey
+
+// NewKeySigner parses private keys (WIF or "PVT_K1_" format).
+func NewKeySigner(keys ...string) (KeySigner, error) {
+	s := KeySigner{}
+	for _, k := range keys {
+		key, err := eosio.ParsePrivateKey(k)
+		if err != nil {
+			return nil, err
+		}
+		s = append(s, key)
+	}
+	return s, nil
+}
+
+func (s KeySigner) Sign(ctx context.Context, req SignRequest) ([]eosio.Signature, error) {
+	if len(s) < 1 {
+		return nil, errors.New("no keys to sign with")
+	}
+
+	signatures := []eosio.Signature{}
+	for _, key := range s {
+		sig, err := key.Sign(req.Digest)
+		if err != nil {
+			return nil, err
+		}
+		signatures = append(signatures, sig)
+	}
+	return signatures, nil
+}
+
+// Sign serializes tx and signs it for the chain chainID.
+func Sign(ctx context.Context, chainID string, tx Transaction, signer Signer) (SignedTransaction, error) {
+	packed, err := eosio.Pack(tx)
+	if err != nil {
+		return SignedTransaction{}, err
+	}
+
+	digest, err := Digest(chainID, packed)
+	if err != nil {
+		return SignedTransaction{}, err
+	}
+
+	signatures, err := signer.Sign(ctx, SignRequest{
+		ChainID:     chainID,
+		Transaction: tx,
+		Packed:      packed,
+		Digest:      digest,
+	})
+	if err != nil {
+		return SignedTransaction{}, err
+	}
+	return SignedTransaction{Transaction: tx, Signatures: signatures}, nil
+}
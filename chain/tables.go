@@ -0,0 +1,129 @@
+package chain
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+// Number of rows requested per page when reading a whole table.
+const tableRowsLimit = 100
+
+// Types
+
+// TableRowsRequest is the body of get_table_rows.
+// Rows are always requested as json.
+type TableRowsRequest struct {
+	Code       string `json:"code"`
+	Scope      string `json:"scope"`
+	Table      string `json:"table"`
+	LowerBound string `json:"lower_bound,omitempty"`
+	UpperBound string `json:"upper_bound,omitempty"`
+	Limit      int    `json:"limit,omitempty"`
+	Reverse    bool   `json:"reverse,omitempty"`
+}
+
+// TableRowsResponse is the response of get_table_rows.
+type TableRowsResponse struct {
+	Rows    []json.RawMessage `json:"rows"`
+	More    bool              `json:"more"`
+	NextKey string            `json:"next_key"`
+}
+
+// TableRPC is a chain api that contract tables can be read from.
+type TableRPC interface {
+	GetTableRows(ctx context.Context, req TableRowsRequest) (TableRowsResponse, error)
+}
+
+// GetTableRows posts req to "/v1/chain/get_table_rows".
+func (c *Client) GetTableRows(ctx context.Context, req TableRowsRequest) (TableRowsResponse, error) {
+	var resp TableRowsResponse
+	body := struct {
+		TableRowsRequest
+		JSON bool `json:"json"`
+	}{req, true}
+
+	err := c.post(ctx, "/v1/chain/get_table_rows", body, &resp)
+	return resp, err
+}
+
+// tableRows reads the rows of req into T.
+// All pages are read if req.Limit is zero.
+func tableRows[T any](ctx context.Context, rpc TableRPC, req TableRowsRequest) ([]T, error) {
+	all := req.Limit == 0
+	if all {
+		req.Limit = tableRowsLimit
+	}
+
+	rows := []T{}
+	for {
+		resp, err := rpc.GetTableRows(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, raw := range resp.Rows {
+			var row T
+			if err := json.Unmarshal(raw, &row); err != nil {
+				return nil, fmt.Errorf("table %s: %s", req.Table, err)
+			}
+			rows = append(rows, row)
+		}
+
+		if !all || !resp.More || len(resp.NextKey) < 1 {
+			return rows, nil
+		}
+		req.LowerBound = resp.NextKey
+	}
+}
+
+// Table values
+
+// numberString is a number that can be encoded as a json number or string.
+// The chain api encodes 64 bit integers and doubles as strings.
+type numberString string
+
+func (n *numberString) UnmarshalJSON(b []byte) error {
+	var s string
+	if len(b) > 0 && b[0] == '"' {
+		if err := json.Unmarshal(b, &s); err != nil {
+			return err
+		}
+	} else {
+		s = string(b)
+	}
+
+	if _, err := strconv.ParseFloat(s, 64); err != nil {
+		return fmt.Errorf("invalid number '%s'", s)
+	}
+	*n = numberString(s)
+	return nil
+}
+
+// byteArray is a uint8[] encoded as a json array of numbers or a hex string.
+type byteArray []byte
+
+func (a *byteArray) UnmarshalJSON(b []byte) error {
+	if len(b) > 0 && b[0] == '"' {
+		var s string
+		if err := json.Unmarshal(b, &s); err != nil {
+			return err
+		}
+
+		v, err := hex.DecodeString(s)
+		if err != nil {
+			return fmt.Errorf("invalid hex data '%s'", s)
+		}
+		*a = v
+		return nil
+	}
+
+	var v []uint8
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+	*a = v
+	return nil
+}
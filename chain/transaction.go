@@ -0,0 +1,191 @@
+// Package chain serializes, signs and pushes transactions to a
+// nodeos compatible RPC.
+//
+// A transaction is built from actions (see the actions package) and
+// references a recent block (TAPOS) from get_info. It is then signed
+// by a Signer, either with keys held in memory or by an external signer,
+// and pushed through /v1/chain/push_transaction.
+//
+// Tables reads the atomicassets contract tables through
+// /v1/chain/get_table_rows, for when the indexer behind the api lags.
+package chain
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/eosswedenorg-go/atomicasset/eosio"
+)
+
+// DefaultExpiration is how long a transaction is valid if no expiration is given.
+const DefaultExpiration = 30 * time.Second
+
+// Time format used by the chain api (UTC without a timezone).
+const timeFormat = "2006-01-02T15:04:05"
+
+// Types
+
+// Time is a timestamp in the format used by the chain api.
+type Time struct {
+	time.Time
+}
+
+func (t Time) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.UTC().Format(timeFormat))
+}
+
+func (t *Time) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+
+	// Drop fractional seconds ("2006-01-02T15:04:05.500").
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		s = s[:i]
+	}
+
+	v, err := time.Parse(timeFormat, s)
+	if err != nil {
+		return err
+	}
+	t.Time = v
+	return nil
+}
+
+// Extension is a transaction extension.
+type Extension struct {
+	Type uint16 `json:"type"`
+	Data string `json:"data"`
+}
+
+// Transaction is an unsigned transaction.
+type Transaction struct {
+	Expiration         Time           `json:"expiration"`
+	RefBlockNum        uint16         `json:"ref_block_num"`
+	RefBlockPrefix     uint32         `json:"ref_block_prefix"`
+	MaxNetUsageWords   uint32         `json:"max_net_usage_words"`
+	MaxCPUUsageMS      uint8          `json:"max_cpu_usage_ms"`
+	DelaySec           uint32         `json:"delay_sec"`
+	ContextFreeActions []eosio.Action `json:"context_free_actions"`
+	Actions            []eosio.Action `json:"actions"`
+	Extensions         []Extension    `json:"transaction_extensions"`
+}
+
+// NewTransaction creates a transaction of actions.
+// TAPOS must be set before the transaction is signed.
+func NewTransaction(actions ...eosio.Action) Transaction {
+	return Transaction{
+		ContextFreeActions: []eosio.Action{},
+		Actions:            actions,
+		Extensions:         []Extension{},
+	}
+}
+
+// SetTAPOS makes the transaction reference the block blockID and expire
+// at expiration.
+func (tx *Transaction) SetTAPOS(blockID string, expiration time.Time) error {
+	id, err := hex.DecodeString(blockID)
+	if err != nil || len(id) != 32 {
+		return fmt.Errorf("invalid block id '%s'", blockID)
+	}
+
+	tx.RefBlockNum = uint16(binary.BigEndian.Uint32(id[0:4]))
+	tx.RefBlockPrefix = binary.LittleEndian.Uint32(id[8:12])
+	tx.Expiration = Time{expiration.UTC().Truncate(time.Second)}
+	return nil
+}
+
+func (tx Transaction) Pack(e *eosio.Encoder) {
+	e.Uint32(uint32(tx.Expiration.Unix()))
+	e.Uint16(tx.RefBlockNum)
+	e.Uint32(tx.RefBlockPrefix)
+	e.VarUint32(tx.MaxNetUsageWords)
+	e.Uint8(tx.MaxCPUUsageMS)
+	e.VarUint32(tx.DelaySec)
+
+	for _, actions := range [][]eosio.Action{tx.ContextFreeActions, tx.Actions} {
+		e.Length(len(actions))
+		for _, a := range actions {
+			a.Pack(e)
+		}
+	}
+
+	e.Length(len(tx.Extensions))
+	for _, ext := range tx.Extensions {
+		data, err := hex.DecodeString(ext.Data)
+		if err != nil {
+			e.SetError(fmt.Errorf("transaction extension %d: invalid hex data", ext.Type))
+			return
+		}
+		e.Uint16(ext.Type)
+		e.ByteArray(data)
+	}
+}
+
+// ID returns the transaction id (the sha256 hash of the packed transaction).
+func (tx Transaction) ID() (string, error) {
+	b, err := eosio.Pack(tx)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// Digest returns the digest that is signed for the chain chainID.
+func Digest(chainID string, packed []byte) ([32]byte, error) {
+	id, err := hex.DecodeString(chainID)
+	if err != nil || len(id) != 32 {
+		return [32]byte{}, fmt.Errorf("invalid chain id '%s'", chainID)
+	}
+
+	h := sha256.New()
+	h.Write(id)
+	h.Write(packed)
+
+	// Hash of the context free data, zero when there is none.
+	h.Write(make([]byte, 32))
+
+	var digest [32]byte
+	copy(digest[:], h.Sum(nil))
+	return digest, nil
+}
+
+// SignedTransaction is a transaction and its signatures.
+type SignedTransaction struct {
+	Transaction
+	Signatures []eosio.Signature `json:"signatures"`
+}
+
+// PackedTransaction is the body of push_transaction.
+type PackedTransaction struct {
+	Signatures            []eosio.Signature `json:"signatures"`
+	Compression           string            `json:"compression"`
+	PackedContextFreeData string            `json:"packed_context_free_data"`
+	PackedTrx             string            `json:"packed_trx"`
+}
+
+// Pack returns the transaction in the format accepted by push_transaction.
+func (tx SignedTransaction) Pack() (PackedTransaction, error) {
+	b, err := eosio.Pack(tx.Transaction)
+	if err != nil {
+		return PackedTransaction{}, err
+	}
+
+	signatures := tx.Signatures
+	if signatures == nil {
+		signatures = []eosio.Signature{}
+	}
+
+	return PackedTransaction{
+		Signatures:  signatures,
+		Compression: "none",
+		PackedTrx:   hex.EncodeToString(b),
+	}, nil
+}
@@ -83,3 +83,34 @@ func (c *Client) fetch(method string, url string, params interface{}, resp *APIR
 	}
 	return r, err
 }
+
+// WithContext returns a copy of the client that sends requests with ctx.
+func (c *Client) WithContext(ctx context.Context) *Client {
+	cp := *c
+	cp.ctx = ctx
+	return &cp
+}
+
+// pageLimit is the page size used when fetching every page of a list.
+const pageLimit = 100
+
+// fetchAll calls fetch for each page (starting at 1) until a page
+// returns less than pageLimit items and returns all items.
+func fetchAll[T any](ctx context.Context, fetch func(page int) ([]T, error)) ([]T, error) {
+	all := []T{}
+	for page := 1; ; page++ {
+		if ctx != nil && ctx.Err() != nil {
+			return all, ctx.Err()
+		}
+
+		items, err := fetch(page)
+		if err != nil {
+			return all, err
+		}
+
+		all = append(all, items...)
+		if len(items) < pageLimit {
+			return all, nil
+		}
+	}
+}
@@ -0,0 +1,97 @@
+// Command diffcheck compares the data of two API endpoints.
+//
+// Usage:
+//
+//	diffcheck -a https://wax.api.atomicassets.io -b http://localhost:9000 \
+//		-collection farmersworld -templates 260676,260677 -assets 1099667509880
+//
+// It exits with status 1 if the endpoints differ and 2 if a check failed.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+
+	"github.com/eosswedenorg-go/atomicasset"
+	"github.com/eosswedenorg-go/atomicasset/diffcheck"
+)
+
+func list(s string) []string {
+	out := []string{}
+	for _, v := range strings.Split(s, ",") {
+		if v = strings.TrimSpace(v); len(v) > 0 {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+func main() {
+	a := flag.String("a", "", "url of the first endpoint")
+	b := flag.String("b", "", "url of the second endpoint")
+	collection := flag.String("collection", "", "collection to compare assets, sales and stats of")
+	templates := flag.String("templates", "", "comma separated template ids to compare stats of (requires -collection)")
+	assets := flag.String("assets", "", "comma separated asset ids to compare")
+	limit := flag.Int("limit", 100, "number of assets and sales to compare")
+	ignore := flag.String("ignore", strings.Join(diffcheck.DefaultIgnore, ","), "comma separated fields to ignore")
+	flag.Parse()
+
+	if len(*a) < 1 || len(*b) < 1 {
+		fmt.Fprintln(os.Stderr, "diffcheck: -a and -b are required")
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	checks := []diffcheck.Check{}
+	for _, id := range list(*assets) {
+		checks = append(checks, diffcheck.Asset(id))
+	}
+
+	if len(*collection) > 0 {
+		checks = append(checks,
+			diffcheck.CollectionStats(*collection),
+			diffcheck.Assets(atomicasset.AssetsRequestParams{
+				CollectionName: *collection,
+				Limit:          *limit,
+				Order:          atomicasset.SortAscending,
+				Sort:           atomicasset.AssetSortID,
+			}),
+			diffcheck.Sales(atomicasset.SalesRequestParams{
+				CollectionName: *collection,
+				Limit:          *limit,
+				Order:          atomicasset.SortAscending,
+				Sort:           atomicasset.SaleSortID,
+			}),
+		)
+
+		for _, id := range list(*templates) {
+			checks = append(checks, diffcheck.TemplateStats(*collection, id))
+		}
+	}
+
+	if len(checks) < 1 {
+		fmt.Fprintln(os.Stderr, "diffcheck: nothing to compare, use -collection or -assets")
+		os.Exit(2)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	report := diffcheck.Compare(ctx, atomicasset.New(*a), atomicasset.New(*b), checks, diffcheck.Options{Ignore: list(*ignore)})
+	if err := report.WriteText(os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, "diffcheck:", err)
+		os.Exit(2)
+	}
+
+	if len(report.Errors) > 0 {
+		os.Exit(2)
+	}
+
+	if report.Drift() {
+		os.Exit(1)
+	}
+}
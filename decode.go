@@ -0,0 +1,188 @@
+package atomicasset
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// UnmarshalAttributes stores the attributes in data into the struct pointed to by v.
+//
+// Fields are mapped to attributes with the "atomic" struct tag and values are
+// converted to the field type using the type declared in format:
+//
+//	type CardStats struct {
+//		Attack uint32 `atomic:"attack,required"`
+//		Rarity string `atomic:"rarity"`
+//		Skip   string `atomic:"-"`
+//	}
+//
+// Fields without a tag are ignored. Fields tagged with "required" must have a
+// value in data. All problems are reported together as AttributeErrors.
+func UnmarshalAttributes(data map[string]interface{}, format []SchemaFormat, v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return errors.New("atomicasset: UnmarshalAttributes expects a non-nil pointer to a struct")
+	}
+
+	types := map[string]string{}
+	for _, f := range format {
+		types[f.Name] = f.Type
+	}
+
+	errs := AttributeErrors{}
+	rv = rv.Elem()
+	for i := 0; i < rv.NumField(); i++ {
+		field := rv.Type().Field(i)
+		name, required := parseAtomicTag(field.Tag.Get("atomic"))
+		if len(name) < 1 || !field.IsExported() {
+			continue
+		}
+
+		raw, ok := data[name]
+		if !ok {
+			if required {
+				errs = append(errs, &AttributeError{Name: name, Type: types[name], Msg: "missing required attribute"})
+			}
+			continue
+		}
+
+		typ, ok := types[name]
+		if !ok {
+			errs = append(errs, &AttributeError{Name: name, Value: raw, Msg: "not defined in schema"})
+			continue
+		}
+
+		value, err := convertAttribute(typ, raw)
+		if err == nil {
+			err = assignAttribute(rv.Field(i), value)
+		}
+
+		if err != nil {
+			msg := fmt.Sprintf("field %s: %s", field.Name, err)
+			errs = append(errs, &AttributeError{Name: name, Type: typ, Value: raw, Msg: msg})
+		}
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// DecodeData stores the asset's attributes into the struct pointed to by v.
+//
+// The data is merged the same way as the API does for the Data field, where
+// immutable data takes precedence over mutable data and the template's
+// immutable data takes precedence over the asset's. Mutable data does not
+// win, even though it is the more recent value, as that would make the
+// result differ from Data and from the chain package.
+// See UnmarshalAttributes for how fields are mapped.
+func (a Asset) DecodeData(v interface{}) error {
+	data := map[string]interface{}{}
+	for _, m := range []map[string]interface{}{a.Data, a.MutableData, a.ImmutableData, a.Template.ImmutableData} {
+		for k, v := range m {
+			data[k] = v
+		}
+	}
+	return UnmarshalAttributes(data, a.Schema.Format, v)
+}
+
+// DecodeImmutableData stores the template's immutable data into the struct pointed to by v.
+// See UnmarshalAttributes for how fields are mapped.
+func (t Template) DecodeImmutableData(v interface{}) error {
+	return UnmarshalAttributes(t.ImmutableData, t.Schema.Format, v)
+}
+
+// parseAtomicTag returns the attribute name and if it is required.
+func parseAtomicTag(tag string) (string, bool) {
+	if tag == "-" {
+		return "", false
+	}
+
+	parts := strings.Split(tag, ",")
+	required := false
+	for _, opt := range parts[1:] {
+		if opt == "required" {
+			required = true
+		}
+	}
+	return parts[0], required
+}
+
+// assignAttribute stores a value returned by convertAttribute in dst.
+func assignAttribute(dst reflect.Value, value interface{}) error {
+	if dst.Kind() == reflect.Interface && dst.NumMethod() == 0 {
+		dst.Set(reflect.ValueOf(value))
+		return nil
+	}
+
+	switch v := value.(type) {
+	case int64:
+		switch dst.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			if !dst.OverflowInt(v) {
+				dst.SetInt(v)
+				return nil
+			}
+			return fmt.Errorf("value %d overflows %s", v, dst.Type())
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			if v >= 0 && !dst.OverflowUint(uint64(v)) {
+				dst.SetUint(uint64(v))
+				return nil
+			}
+			return fmt.Errorf("value %d overflows %s", v, dst.Type())
+		}
+	case uint64:
+		switch dst.Kind() {
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			if !dst.OverflowUint(v) {
+				dst.SetUint(v)
+				return nil
+			}
+			return fmt.Errorf("value %d overflows %s", v, dst.Type())
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			if v <= 1<<63-1 && !dst.OverflowInt(int64(v)) {
+				dst.SetInt(int64(v))
+				return nil
+			}
+			return fmt.Errorf("value %d overflows %s", v, dst.Type())
+		}
+	case float64:
+		if dst.Kind() == reflect.Float32 || dst.Kind() == reflect.Float64 {
+			dst.SetFloat(v)
+			return nil
+		}
+	case string:
+		if dst.Kind() == reflect.String {
+			dst.SetString(v)
+			return nil
+		}
+	case bool:
+		if dst.Kind() == reflect.Bool {
+			dst.SetBool(v)
+			return nil
+		}
+	case []byte:
+		if dst.Kind() == reflect.Slice && dst.Type().Elem().Kind() == reflect.Uint8 {
+			dst.SetBytes(v)
+			return nil
+		}
+	}
+
+	// Arrays
+	src := reflect.ValueOf(value)
+	if src.Kind() == reflect.Slice && dst.Kind() == reflect.Slice {
+		s := reflect.MakeSlice(dst.Type(), src.Len(), src.Len())
+		for i := 0; i < src.Len(); i++ {
+			if err := assignAttribute(s.Index(i), src.Index(i).Interface()); err != nil {
+				return fmt.Errorf("index %d: %s", i, err)
+			}
+		}
+		dst.Set(s)
+		return nil
+	}
+
+	return fmt.Errorf("cannot assign %T to %s", value, dst.Type())
+}
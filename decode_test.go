@@ -0,0 +1,149 @@
+package atomicasset
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testCardStats struct {
+	Name    string   `atomic:"name,required"`
+	Attack  uint32   `atomic:"attack,required"`
+	Defense int      `atomic:"defense"`
+	Speed   float32  `atomic:"speed"`
+	Rarity  string   `atomic:"rarity"`
+	Shiny   bool     `atomic:"shiny"`
+	Tags    []string `atomic:"tags"`
+	Serial  uint64   `atomic:"serial"`
+	Extra   interface{}
+	Ignored string `atomic:"-"`
+}
+
+var cardFormat = []SchemaFormat{
+	{Name: "name", Type: "string"},
+	{Name: "attack", Type: "uint16"},
+	{Name: "defense", Type: "int8"},
+	{Name: "speed", Type: "float"},
+	{Name: "rarity", Type: "string"},
+	{Name: "shiny", Type: "bool"},
+	{Name: "tags", Type: "string[]"},
+	{Name: "serial", Type: "uint64"},
+}
+
+func TestUnmarshalAttributes(t *testing.T) {
+	data := map[string]interface{}{
+		"name":    "Dragon",
+		"attack":  float64(120),
+		"defense": float64(-4),
+		"speed":   float64(1.25),
+		"rarity":  "Epic",
+		"shiny":   true,
+		"tags":    []interface{}{"fire", "flying"},
+		"serial":  "18446744073709551615",
+	}
+
+	var stats testCardStats
+	require.NoError(t, UnmarshalAttributes(data, cardFormat, &stats))
+
+	expected := testCardStats{
+		Name:    "Dragon",
+		Attack:  120,
+		Defense: -4,
+		Speed:   1.25,
+		Rarity:  "Epic",
+		Shiny:   true,
+		Tags:    []string{"fire", "flying"},
+		Serial:  18446744073709551615,
+	}
+	assert.Equal(t, expected, stats)
+}
+
+func TestUnmarshalAttributes_Errors(t *testing.T) {
+	data := map[string]interface{}{
+		"defense": "strong",
+		"rarity":  float64(1),
+	}
+
+	var stats testCardStats
+	err := UnmarshalAttributes(data, cardFormat, &stats)
+
+	var errs AttributeErrors
+	require.ErrorAs(t, err, &errs)
+	require.Len(t, errs, 4)
+
+	assert.Equal(t, "attribute 'name' (string): missing required attribute", errs[0].Error())
+	assert.Equal(t, "attribute 'attack' (uint16): missing required attribute", errs[1].Error())
+	assert.Equal(t, "attribute 'defense' (int8): field Defense: 'strong' is not a valid int8", errs[2].Error())
+	assert.Equal(t, "attribute 'rarity' (string): field Rarity: expected string, got float64", errs[3].Error())
+}
+
+func TestUnmarshalAttributes_Overflow(t *testing.T) {
+	format := []SchemaFormat{{Name: "attack", Type: "uint64"}}
+
+	var stats struct {
+		Attack uint8 `atomic:"attack"`
+	}
+
+	err := UnmarshalAttributes(map[string]interface{}{"attack": "300"}, format, &stats)
+	assert.EqualError(t, err, "attribute 'attack' (uint64): field Attack: value 300 overflows uint8")
+}
+
+func TestUnmarshalAttributes_NotInSchema(t *testing.T) {
+	var v struct {
+		Power int `atomic:"power"`
+	}
+
+	err := UnmarshalAttributes(map[string]interface{}{"power": float64(1)}, cardFormat, &v)
+	assert.EqualError(t, err, "attribute 'power': not defined in schema")
+}
+
+func TestUnmarshalAttributes_InvalidTarget(t *testing.T) {
+	var stats testCardStats
+	assert.Error(t, UnmarshalAttributes(nil, cardFormat, stats))
+	assert.Error(t, UnmarshalAttributes(nil, cardFormat, nil))
+
+	var n int
+	assert.Error(t, UnmarshalAttributes(nil, cardFormat, &n))
+}
+
+func TestAsset_DecodeData(t *testing.T) {
+	asset := Asset{
+		Schema: InlineSchema{Format: cardFormat},
+		Template: Template{
+			ImmutableData: map[string]interface{}{
+				"name":   "Dragon",
+				"rarity": "Common",
+			},
+		},
+		ImmutableData: map[string]interface{}{
+			"attack": float64(10),
+			"rarity": "Rare",
+		},
+		MutableData: map[string]interface{}{
+			"attack": float64(25),
+		},
+	}
+
+	var stats testCardStats
+	require.NoError(t, asset.DecodeData(&stats))
+
+	// Immutable data overrides mutable data and template data overrides asset data.
+	assert.Equal(t, "Dragon", stats.Name)
+	assert.Equal(t, uint32(10), stats.Attack)
+	assert.Equal(t, "Common", stats.Rarity)
+}
+
+func TestTemplate_DecodeImmutableData(t *testing.T) {
+	tmpl := Template{
+		Schema: InlineSchema{Format: cardFormat},
+		ImmutableData: map[string]interface{}{
+			"name":   "Dragon",
+			"attack": float64(7),
+		},
+	}
+
+	var stats testCardStats
+	require.NoError(t, tmpl.DecodeImmutableData(&stats))
+	assert.Equal(t, testCardStats{Name: "Dragon", Attack: 7}, stats)
+}
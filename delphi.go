@@ -0,0 +1,191 @@
+package atomicasset
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+)
+
+// Types
+
+// DelphiMedian is a median price reported by the delphioracle contract
+// together with the precisions of the delphi pair.
+//
+// For a pair like "waxpusd" the base is WAXP and the quote is USD, and
+// the median is the price of one base unit in quote with MedianPrecision
+// decimals (595 with precision 4 means 0.0595 USD per WAX).
+type DelphiMedian struct {
+	Median          uint64
+	MedianPrecision int
+	BasePrecision   int
+	QuotePrecision  int
+}
+
+// ErrNoDelphiPair is returned for sales that are listed in a symbol
+// without a supported delphi pair.
+var ErrNoDelphiPair = errors.New("no delphi pair for listing and settlement symbol")
+
+// dataUint reads an unsigned integer from the pair data (numbers and numeric strings).
+func dataUint(data map[string]interface{}, key string) (uint64, error) {
+	n, err := toUint(data[key], 64)
+	if err != nil {
+		return 0, fmt.Errorf("delphi pair data '%s': %s", key, err)
+	}
+	return n, nil
+}
+
+// DelphiMedian returns the median included in the pair's data.
+func (p TokenPair) DelphiMedian() (DelphiMedian, error) {
+	values := []uint64{}
+	for _, key := range []string{"median", "median_precision", "base_precision", "quote_precision"} {
+		n, err := dataUint(p.Data, key)
+		if err != nil {
+			return DelphiMedian{}, err
+		}
+		values = append(values, n)
+	}
+
+	return DelphiMedian{
+		Median:          values[0],
+		MedianPrecision: int(values[1]),
+		BasePrecision:   int(values[2]),
+		QuotePrecision:  int(values[3]),
+	}, nil
+}
+
+// ListingPrecision returns the precision of the listing symbol.
+//
+// The listing symbol is the quote of the delphi pair, or the base if the pair is inverted.
+func (p TokenPair) ListingPrecision(m DelphiMedian) int {
+	if p.InvertDelphiPair {
+		return m.BasePrecision
+	}
+	return m.QuotePrecision
+}
+
+// SettlementPrecision returns the precision of the settlement symbol.
+//
+// The settlement symbol is the base of the delphi pair, or the quote if the pair is inverted.
+func (p TokenPair) SettlementPrecision(m DelphiMedian) int {
+	if p.InvertDelphiPair {
+		return m.QuotePrecision
+	}
+	return m.BasePrecision
+}
+
+// SettlementAmount converts an amount in listing symbol units to settlement symbol units.
+//
+// The calculation is done the same way as the atomicmarket contract,
+// using floating point math and truncating the result.
+func (p TokenPair) SettlementAmount(listing int64, m DelphiMedian) (int64, error) {
+	if m.Median == 0 {
+		return 0, errors.New("delphi median can not be zero")
+	}
+
+	if listing < 0 {
+		return 0, fmt.Errorf("invalid listing amount %d", listing)
+	}
+
+	var amount float64
+	if p.InvertDelphiPair {
+		exp := m.QuotePrecision - m.BasePrecision - m.MedianPrecision
+		amount = float64(listing) * float64(m.Median) * math.Pow(10, float64(exp))
+	} else {
+		exp := m.MedianPrecision + m.BasePrecision - m.QuotePrecision
+		amount = float64(listing) / float64(m.Median) * math.Pow(10, float64(exp))
+	}
+
+	if amount >= math.MaxInt64 {
+		return 0, fmt.Errorf("settlement amount overflows")
+	}
+	return int64(amount), nil
+}
+
+// FindPair returns the supported pair for the listing and settlement symbols.
+func (c MarketConfig) FindPair(listing, settlement string) (TokenPair, bool) {
+	for _, p := range c.SupportedPairs {
+		if p.ListingSymbol == listing && p.SettlementSymbol == settlement {
+			return p, true
+		}
+	}
+	return TokenPair{}, false
+}
+
+// FindToken returns the supported token with symbol.
+func (c MarketConfig) FindToken(symbol string) (PriceToken, bool) {
+	for _, t := range c.SupportedTokens {
+		if t.Symbol == symbol {
+			return t, true
+		}
+	}
+	return PriceToken{}, false
+}
+
+// UsesDelphi reports whether the sale is listed in a different symbol than it is settled in.
+func (s Sale) UsesDelphi() bool {
+	return len(s.ListingSymbol) > 0 && s.ListingSymbol != s.Price.Symbol
+}
+
+// SettlementPrice returns the price the buyer has to pay for the sale.
+//
+// For sales that use delphi, the listing price is converted using the given
+// median. If m is nil, the median from the pair data in the config is used.
+// Sales that do not use delphi simply return their price.
+func (c MarketConfig) SettlementPrice(s Sale, m *DelphiMedian) (Token, error) {
+	if !s.UsesDelphi() {
+		return s.Price, nil
+	}
+
+	pair, ok := c.FindPair(s.ListingSymbol, s.Price.Symbol)
+	if !ok {
+		return Token{}, ErrNoDelphiPair
+	}
+
+	if m == nil {
+		median, err := pair.DelphiMedian()
+		if err != nil {
+			return Token{}, err
+		}
+		m = &median
+	}
+
+	listing, err := strconv.ParseInt(s.ListingPrice.String(), 10, 64)
+	if err != nil {
+		return Token{}, fmt.Errorf("invalid listing price '%s'", s.ListingPrice)
+	}
+
+	amount, err := pair.SettlementAmount(listing, *m)
+	if err != nil {
+		return Token{}, err
+	}
+
+	token := s.Price
+	if t, ok := c.FindToken(pair.SettlementSymbol); ok {
+		token.Contract = t.Contract
+		token.Precision = t.Precision
+	} else {
+		token.Precision = pair.SettlementPrecision(*m)
+	}
+	return token.withUnits(amount), nil
+}
+
+// IntendedDelphiMedian returns the "intended_delphi_median" a buyer has to
+// pass to purchasesale. This is the current median from the pair data for
+// sales that use delphi and zero for all other sales.
+func (c MarketConfig) IntendedDelphiMedian(s Sale) (uint64, error) {
+	if !s.UsesDelphi() {
+		return 0, nil
+	}
+
+	pair, ok := c.FindPair(s.ListingSymbol, s.Price.Symbol)
+	if !ok {
+		return 0, ErrNoDelphiPair
+	}
+
+	m, err := pair.DelphiMedian()
+	if err != nil {
+		return 0, err
+	}
+	return m.Median, nil
+}
@@ -0,0 +1,109 @@
+package atomicasset
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var delphiTestConfig = MarketConfig{
+	SupportedTokens: []PriceToken{
+		{Contract: "eosio.token", Symbol: "WAX", Precision: 8},
+	},
+	SupportedPairs: []TokenPair{
+		{
+			ListingSymbol:    "USD",
+			SettlementSymbol: "WAX",
+			DelphiPairName:   "waxpusd",
+			Data: map[string]interface{}{
+				"median":           float64(595),
+				"base_precision":   float64(8),
+				"quote_precision":  float64(2),
+				"median_precision": float64(4),
+			},
+		},
+	},
+}
+
+func TestTokenPair_DelphiMedian(t *testing.T) {
+	m, err := delphiTestConfig.SupportedPairs[0].DelphiMedian()
+	require.NoError(t, err)
+	assert.Equal(t, DelphiMedian{Median: 595, MedianPrecision: 4, BasePrecision: 8, QuotePrecision: 2}, m)
+
+	_, err = TokenPair{Data: map[string]interface{}{}}.DelphiMedian()
+	assert.EqualError(t, err, "delphi pair data 'median': expected unsigned integer, got <nil>")
+}
+
+func TestTokenPair_SettlementAmount(t *testing.T) {
+	m := DelphiMedian{Median: 595, MedianPrecision: 4, BasePrecision: 8, QuotePrecision: 2}
+
+	// 1.00 USD at 0.0595 USD/WAX = 16.80672268 WAX (truncated)
+	n, err := TokenPair{}.SettlementAmount(100, m)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1680672268), n)
+
+	// Inverted: 1.00000000 WAX at 0.0595 USD/WAX = 0.05 USD (truncated)
+	n, err = TokenPair{InvertDelphiPair: true}.SettlementAmount(100000000, m)
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), n)
+
+	_, err = TokenPair{}.SettlementAmount(100, DelphiMedian{})
+	assert.EqualError(t, err, "delphi median can not be zero")
+
+	_, err = TokenPair{}.SettlementAmount(-1, m)
+	assert.EqualError(t, err, "invalid listing amount -1")
+}
+
+func TestTokenPair_Precision(t *testing.T) {
+	m := DelphiMedian{Median: 595, MedianPrecision: 4, BasePrecision: 8, QuotePrecision: 2}
+
+	assert.Equal(t, 2, TokenPair{}.ListingPrecision(m))
+	assert.Equal(t, 8, TokenPair{}.SettlementPrecision(m))
+	assert.Equal(t, 8, TokenPair{InvertDelphiPair: true}.ListingPrecision(m))
+	assert.Equal(t, 2, TokenPair{InvertDelphiPair: true}.SettlementPrecision(m))
+}
+
+func TestMarketConfig_SettlementPrice(t *testing.T) {
+	sale := Sale{
+		Price:         Token{Symbol: "WAX", Precision: 8, Amount: "0"},
+		ListingPrice:  "250",
+		ListingSymbol: "USD",
+	}
+
+	price, err := delphiTestConfig.SettlementPrice(sale, nil)
+	require.NoError(t, err)
+	assert.Equal(t, Token{Contract: "eosio.token", Symbol: "WAX", Precision: 8, Amount: "4201680672"}, price)
+	assert.Equal(t, "42.01680672 WAX", price.String())
+
+	// Explicit median.
+	price, err = delphiTestConfig.SettlementPrice(sale, &DelphiMedian{Median: 500, MedianPrecision: 4, BasePrecision: 8, QuotePrecision: 2})
+	require.NoError(t, err)
+	assert.Equal(t, "50.00000000 WAX", price.String())
+
+	// No delphi.
+	price, err = delphiTestConfig.SettlementPrice(sale_100420714, nil)
+	require.NoError(t, err)
+	assert.Equal(t, sale_100420714.Price, price)
+
+	// Unsupported pair.
+	sale.ListingSymbol = "EUR"
+	_, err = delphiTestConfig.SettlementPrice(sale, nil)
+	assert.ErrorIs(t, err, ErrNoDelphiPair)
+}
+
+func TestMarketConfig_IntendedDelphiMedian(t *testing.T) {
+	sale := Sale{
+		Price:         Token{Symbol: "WAX", Precision: 8},
+		ListingPrice:  "250",
+		ListingSymbol: "USD",
+	}
+
+	median, err := delphiTestConfig.IntendedDelphiMedian(sale)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(595), median)
+
+	median, err = delphiTestConfig.IntendedDelphiMedian(sale_100420714)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(0), median)
+}
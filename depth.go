@@ -0,0 +1,187 @@
+package atomicasset
+
+import (
+	"context"
+	"errors"
+	"sort"
+)
+
+// Types
+
+// PriceLevel is a price and the number of listings or offers at that price.
+type PriceLevel struct {
+	Price Token
+	Count int
+}
+
+// MarketDepth is an order book for a template in one token.
+//
+// Asks are the active sales sorted by lowest price first and bids are
+// the listed template buyoffers sorted by highest price first. Only sales
+// of a single asset are included. Buyoffers for a specific asset are not
+// bids on the template, so they are left out.
+type MarketDepth struct {
+	TemplateID int
+	Symbol     string
+	Asks       []PriceLevel
+	Bids       []PriceLevel
+}
+
+// Floor returns the lowest ask.
+func (d MarketDepth) Floor() (Token, bool) {
+	if len(d.Asks) < 1 {
+		return Token{}, false
+	}
+	return d.Asks[0].Price, true
+}
+
+// BestBid returns the highest bid.
+func (d MarketDepth) BestBid() (Token, bool) {
+	if len(d.Bids) < 1 {
+		return Token{}, false
+	}
+	return d.Bids[0].Price, true
+}
+
+// MarketDepth fetches all active sales and listed template buyoffers for
+// a template and aggregates them into price levels.
+func (c *Client) MarketDepth(ctx context.Context, templateID int, symbol string) (MarketDepth, error) {
+	c = c.WithContext(ctx)
+
+	if len(symbol) < 1 {
+		return MarketDepth{}, errors.New("market depth requires a symbol")
+	}
+
+	sales, err := fetchAll(ctx, func(page int) ([]Sale, error) {
+		resp, err := c.GetSales(SalesRequestParams{
+			State:      SalesStateListed,
+			TemplateID: templateID,
+			Symbol:     symbol,
+			MaxAssets:  1,
+			Page:       page,
+			Limit:      pageLimit,
+			Order:      SortAscending,
+			Sort:       SaleSortPrice,
+		})
+		return resp.Data, err
+	})
+	if err != nil {
+		return MarketDepth{}, err
+	}
+
+	offers, err := fetchAll(ctx, func(page int) ([]TemplateBuyOffer, error) {
+		resp, err := c.GetTemplateBuyOffers(TemplateBuyOffersRequestParams{
+			State:      TemplateBuyOfferStateListed,
+			TemplateID: templateID,
+			Symbol:     symbol,
+			Page:       page,
+			Limit:      pageLimit,
+			Order:      SortDescending,
+			Sort:       TemplateBuyOfferSortPrice,
+		})
+		return resp.Data, err
+	})
+	if err != nil {
+		return MarketDepth{}, err
+	}
+
+	asks := []Token{}
+	for _, s := range sales {
+		asks = append(asks, s.Price)
+	}
+
+	bids := []Token{}
+	for _, o := range offers {
+		bids = append(bids, o.Price)
+	}
+
+	return MarketDepth{
+		TemplateID: templateID,
+		Symbol:     symbol,
+		Asks:       priceLevels(asks, false),
+		Bids:       priceLevels(bids, true),
+	}, nil
+}
+
+// priceLevels groups prices by amount, sorted ascending or descending.
+// Prices with an invalid amount are skipped.
+func priceLevels(prices []Token, descending bool) []PriceLevel {
+	levels := []PriceLevel{}
+	units := []int64{}
+	index := map[int64]int{}
+
+	for _, p := range prices {
+		n, err := p.Units()
+		if err != nil {
+			continue
+		}
+
+		if i, ok := index[n]; ok {
+			levels[i].Count++
+			continue
+		}
+
+		index[n] = len(levels)
+		levels = append(levels, PriceLevel{Price: p, Count: 1})
+		units = append(units, n)
+	}
+
+	sort.Sort(priceLevelSorter{levels, units, descending})
+	return levels
+}
+
+type priceLevelSorter struct {
+	levels     []PriceLevel
+	units      []int64
+	descending bool
+}
+
+func (s priceLevelSorter) Len() int { return len(s.levels) }
+
+func (s priceLevelSorter) Less(i, j int) bool {
+	if s.descending {
+		return s.units[i] > s.units[j]
+	}
+	return s.units[i] < s.units[j]
+}
+
+func (s priceLevelSorter) Swap(i, j int) {
+	s.levels[i], s.levels[j] = s.levels[j], s.levels[i]
+	s.units[i], s.units[j] = s.units[j], s.units[i]
+}
+
+// FloorPrices returns the lowest active sale price in symbol for each
+// template in collection, keyed by template id.
+func (c *Client) FloorPrices(ctx context.Context, collection string, symbol string) (map[string]Token, error) {
+	c = c.WithContext(ctx)
+
+	if len(symbol) < 1 {
+		return nil, errors.New("floor prices requires a symbol")
+	}
+
+	sales, err := fetchAll(ctx, func(page int) ([]Sale, error) {
+		resp, err := c.GetSalesGroupByTemplate(SalesTemplateRequestParams{
+			CollectionName: collection,
+			Symbol:         symbol,
+			Page:           page,
+			Limit:          pageLimit,
+		})
+		return resp.Data, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	prices := map[templatePriceKey]Token{}
+	for _, s := range sales {
+		if len(s.Assets) > 0 && len(s.Assets[0].Template.ID) > 0 {
+			setFloorPrice(prices, templatePriceKey{s.Assets[0].Template.ID, s.Price.Symbol}, s.Price)
+		}
+	}
+
+	floors := map[string]Token{}
+	for key, price := range prices {
+		floors[key.TemplateID] = price
+	}
+	return floors, nil
+}
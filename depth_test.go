@@ -0,0 +1,124 @@
+package atomicasset
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func waxPrice(amount string) string {
+	return `{"token_contract": "eosio.token", "token_symbol": "WAX", "token_precision": 8, "amount": "` + amount + `"}`
+}
+
+func TestClient_MarketDepth(t *testing.T) {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/atomicmarket/v2/sales", func(res http.ResponseWriter, req *http.Request) {
+		q := req.URL.Query()
+		assert.Equal(t, "1", q.Get("state"))
+		assert.Equal(t, "42", q.Get("template_id"))
+		assert.Equal(t, "WAX", q.Get("symbol"))
+		assert.Equal(t, "1", q.Get("max_assets"))
+		assert.Equal(t, "price", q.Get("sort"))
+
+		res.Header().Add("Content-type", "application/json; charset=utf-8")
+		_, err := res.Write([]byte(`{"success": true, "data": [
+			{"sale_id": "1", "price": ` + waxPrice("300") + `},
+			{"sale_id": "2", "price": ` + waxPrice("100") + `},
+			{"sale_id": "3", "price": ` + waxPrice("100") + `},
+			{"sale_id": "4", "price": ` + waxPrice("200") + `}
+		], "query_time": 0}`))
+		assert.NoError(t, err)
+	})
+
+	mux.HandleFunc("/atomicmarket/v1/template_buyoffers", func(res http.ResponseWriter, req *http.Request) {
+		q := req.URL.Query()
+		assert.Equal(t, "0", q.Get("state"))
+		assert.Equal(t, "42", q.Get("template_id"))
+		assert.Equal(t, "WAX", q.Get("symbol"))
+		assert.Equal(t, "price", q.Get("sort"))
+		assert.Equal(t, "desc", q.Get("order"))
+
+		res.Header().Add("Content-type", "application/json; charset=utf-8")
+		_, err := res.Write([]byte(`{"success": true, "data": [
+			{"buyoffer_id": "1", "price": ` + waxPrice("50") + `},
+			{"buyoffer_id": "2", "price": ` + waxPrice("90") + `},
+			{"buyoffer_id": "3", "price": ` + waxPrice("50") + `}
+		], "query_time": 0}`))
+		assert.NoError(t, err)
+	})
+
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	depth, err := New(srv.URL).MarketDepth(context.Background(), 42, "WAX")
+	require.NoError(t, err)
+
+	assert.Equal(t, 42, depth.TemplateID)
+	assert.Equal(t, "WAX", depth.Symbol)
+
+	levels := func(levels []PriceLevel) map[string]int {
+		m := map[string]int{}
+		for _, l := range levels {
+			m[l.Price.Amount] = l.Count
+		}
+		return m
+	}
+
+	require.Len(t, depth.Asks, 3)
+	assert.Equal(t, []string{"100", "200", "300"}, []string{depth.Asks[0].Price.Amount, depth.Asks[1].Price.Amount, depth.Asks[2].Price.Amount})
+	assert.Equal(t, map[string]int{"100": 2, "200": 1, "300": 1}, levels(depth.Asks))
+
+	require.Len(t, depth.Bids, 2)
+	assert.Equal(t, []string{"90", "50"}, []string{depth.Bids[0].Price.Amount, depth.Bids[1].Price.Amount})
+	assert.Equal(t, map[string]int{"90": 1, "50": 2}, levels(depth.Bids))
+
+	floor, ok := depth.Floor()
+	require.True(t, ok)
+	assert.Equal(t, "0.00000100 WAX", floor.String())
+
+	bid, ok := depth.BestBid()
+	require.True(t, ok)
+	assert.Equal(t, "0.00000090 WAX", bid.String())
+
+	_, err = New(srv.URL).MarketDepth(context.Background(), 42, "")
+	assert.EqualError(t, err, "market depth requires a symbol")
+}
+
+func TestMarketDepth_Empty(t *testing.T) {
+	_, ok := MarketDepth{}.Floor()
+	assert.False(t, ok)
+
+	_, ok = MarketDepth{}.BestBid()
+	assert.False(t, ok)
+}
+
+func TestClient_FloorPrices(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "/atomicmarket/v1/sales/templates", req.URL.Path)
+		assert.Equal(t, "cola", req.URL.Query().Get("collection_name"))
+		assert.Equal(t, "WAX", req.URL.Query().Get("symbol"))
+
+		res.Header().Add("Content-type", "application/json; charset=utf-8")
+		_, err := res.Write([]byte(`{"success": true, "data": [
+			{"sale_id": "1", "price": ` + waxPrice("500") + `, "assets": [{"template": {"template_id": "10"}}]},
+			{"sale_id": "2", "price": ` + waxPrice("700") + `, "assets": [{"template": {"template_id": "11"}}]},
+			{"sale_id": "3", "price": ` + waxPrice("900") + `, "assets": [{"template": {"template_id": "10"}}]},
+			{"sale_id": "4", "price": ` + waxPrice("100") + `, "assets": []}
+		], "query_time": 0}`))
+		assert.NoError(t, err)
+	}))
+	defer srv.Close()
+
+	floors, err := New(srv.URL).FloorPrices(context.Background(), "cola", "WAX")
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]Token{
+		"10": {Contract: "eosio.token", Symbol: "WAX", Precision: 8, Amount: "500"},
+		"11": {Contract: "eosio.token", Symbol: "WAX", Precision: 8, Amount: "700"},
+	}, floors)
+}
@@ -0,0 +1,398 @@
+// Package diffcheck runs the same queries against two API endpoints and
+// reports where their data differs, for example to find out if a self
+// hosted indexer has drifted from a public one.
+package diffcheck
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/eosswedenorg-go/atomicasset"
+)
+
+// DefaultIgnore are fields of the response data that are expected to differ
+// between endpoints, as they depend on when each indexer processed the block.
+// The order of list checks does not need to be ignored, entities are matched
+// by Check.Key.
+var DefaultIgnore = []string{"updated_at_time", "updated_at_block"}
+
+// Check is a query that is run against both endpoints.
+type Check struct {
+	Name string
+
+	// Key is the field that identifies each entity if Fetch returns a list.
+	// Empty if Fetch returns a single entity.
+	Key string
+
+	// Fetch returns the data of the response.
+	Fetch func(c *atomicasset.Client) (interface{}, error)
+}
+
+// Asset compares a single asset.
+func Asset(assetID string) Check {
+	return Check{
+		Name: "asset " + assetID,
+		Fetch: func(c *atomicasset.Client) (interface{}, error) {
+			resp, err := c.GetAsset(assetID)
+			return resp.Data, err
+		},
+	}
+}
+
+// Assets compares a page of assets.
+func Assets(params atomicasset.AssetsRequestParams) Check {
+	return Check{
+		Name: "assets",
+		Key:  "asset_id",
+		Fetch: func(c *atomicasset.Client) (interface{}, error) {
+			resp, err := c.GetAssets(params)
+			return resp.Data, err
+		},
+	}
+}
+
+// Sales compares a page of sales.
+func Sales(params atomicasset.SalesRequestParams) Check {
+	return Check{
+		Name: "sales",
+		Key:  "sale_id",
+		Fetch: func(c *atomicasset.Client) (interface{}, error) {
+			resp, err := c.GetSales(params)
+			return resp.Data, err
+		},
+	}
+}
+
+// CollectionStats compares the stats of a collection.
+func CollectionStats(collection string) Check {
+	return Check{
+		Name: "collection stats " + collection,
+		Fetch: func(c *atomicasset.Client) (interface{}, error) {
+			resp, err := c.GetCollectionStats(collection)
+			return resp.Data, err
+		},
+	}
+}
+
+// TemplateStats compares the stats of a template.
+func TemplateStats(collection string, templateID string) Check {
+	return Check{
+		Name: "template stats " + collection + "/" + templateID,
+		Fetch: func(c *atomicasset.Client) (interface{}, error) {
+			resp, err := c.GetTemplateStats(collection, templateID)
+			return resp.Data, err
+		},
+	}
+}
+
+// Options controls how responses are compared.
+type Options struct {
+	// Ignore are field names that are removed (at any depth) before
+	// comparing. DefaultIgnore is used if nil.
+	Ignore []string
+}
+
+// FieldDiff is a field that differs between the endpoints.
+// A or B is nil if the field only exists on the other endpoint.
+type FieldDiff struct {
+	Path string
+	A, B interface{}
+}
+
+// EntityDiff holds the differences of one entity.
+type EntityDiff struct {
+	Check string
+
+	// ID of the entity, empty for single entity checks.
+	ID string
+
+	// Missing is "a" or "b" if the entity only exists on one endpoint.
+	Missing string
+
+	Fields []FieldDiff
+}
+
+// CheckError is a check that failed on one of the endpoints.
+type CheckError struct {
+	Check    string
+	Endpoint string
+	Err      error
+}
+
+func (e CheckError) Error() string {
+	if len(e.Endpoint) < 1 {
+		return fmt.Sprintf("%s: %s", e.Check, e.Err)
+	}
+	return fmt.Sprintf("%s (%s): %s", e.Check, e.Endpoint, e.Err)
+}
+
+// Report is the result of comparing two endpoints.
+type Report struct {
+	Checks   int
+	Entities int
+	Diffs    []EntityDiff
+	Errors   []CheckError
+}
+
+// Drift reports whether any entity differs.
+func (r Report) Drift() bool {
+	return len(r.Diffs) > 0
+}
+
+// Compare runs checks against a and b and compares the results.
+func Compare(ctx context.Context, a, b *atomicasset.Client, checks []Check, opts Options) Report {
+	a, b = a.WithContext(ctx), b.WithContext(ctx)
+
+	ignore := map[string]bool{}
+	fields := opts.Ignore
+	if fields == nil {
+		fields = DefaultIgnore
+	}
+	for _, f := range fields {
+		ignore[f] = true
+	}
+
+	r := Report{Diffs: []EntityDiff{}, Errors: []CheckError{}}
+	for _, check := range checks {
+		if ctx.Err() != nil {
+			r.Errors = append(r.Errors, CheckError{Check: check.Name, Err: ctx.Err()})
+			continue
+		}
+
+		r.Checks++
+
+		da, err := fetch(check, a, ignore)
+		if err != nil {
+			r.Errors = append(r.Errors, CheckError{Check: check.Name, Endpoint: "a", Err: err})
+			continue
+		}
+
+		db, err := fetch(check, b, ignore)
+		if err != nil {
+			r.Errors = append(r.Errors, CheckError{Check: check.Name, Endpoint: "b", Err: err})
+			continue
+		}
+
+		entities, diffs, err := compareCheck(check, da, db)
+		if err != nil {
+			r.Errors = append(r.Errors, CheckError{Check: check.Name, Err: err})
+			continue
+		}
+
+		r.Entities += entities
+		r.Diffs = append(r.Diffs, diffs...)
+	}
+	return r
+}
+
+// fetch runs the check and normalizes the result to plain json values.
+func fetch(check Check, c *atomicasset.Client, ignore map[string]bool) (interface{}, error) {
+	data, err := check.Fetch(c)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var v interface{}
+	if err := json.Unmarshal(b, &v); err != nil {
+		return nil, err
+	}
+	return normalize(v, ignore), nil
+}
+
+// normalize removes ignored fields.
+func normalize(v interface{}, ignore map[string]bool) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, e := range v {
+			if ignore[k] {
+				delete(v, k)
+				continue
+			}
+			v[k] = normalize(e, ignore)
+		}
+	case []interface{}:
+		for i, e := range v {
+			v[i] = normalize(e, ignore)
+		}
+	}
+	return v
+}
+
+// compareCheck compares the results of a check and returns the number
+// of compared entities and the ones that differ.
+func compareCheck(check Check, a, b interface{}) (int, []EntityDiff, error) {
+	diffs := []EntityDiff{}
+
+	if len(check.Key) < 1 {
+		if fields := diff("", a, b); len(fields) > 0 {
+			diffs = append(diffs, EntityDiff{Check: check.Name, Fields: fields})
+		}
+		return 1, diffs, nil
+	}
+
+	ea, err := entities(a, check.Key)
+	if err != nil {
+		return 0, nil, fmt.Errorf("endpoint a: %s", err)
+	}
+
+	eb, err := entities(b, check.Key)
+	if err != nil {
+		return 0, nil, fmt.Errorf("endpoint b: %s", err)
+	}
+
+	ids := []string{}
+	for id := range ea {
+		ids = append(ids, id)
+	}
+	for id := range eb {
+		if _, ok := ea[id]; !ok {
+			ids = append(ids, id)
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		if len(ids[i]) != len(ids[j]) {
+			return len(ids[i]) < len(ids[j])
+		}
+		return ids[i] < ids[j]
+	})
+
+	for _, id := range ids {
+		va, aok := ea[id]
+		vb, bok := eb[id]
+
+		switch {
+		case !aok:
+			diffs = append(diffs, EntityDiff{Check: check.Name, ID: id, Missing: "a"})
+		case !bok:
+			diffs = append(diffs, EntityDiff{Check: check.Name, ID: id, Missing: "b"})
+		default:
+			if fields := diff("", va, vb); len(fields) > 0 {
+				diffs = append(diffs, EntityDiff{Check: check.Name, ID: id, Fields: fields})
+			}
+		}
+	}
+	return len(ids), diffs, nil
+}
+
+// entities indexes a list by the key field.
+func entities(v interface{}, key string) (map[string]interface{}, error) {
+	list, ok := v.([]interface{})
+	if !ok && v != nil {
+		return nil, fmt.Errorf("expected a list, got %T", v)
+	}
+
+	out := map[string]interface{}{}
+	for _, e := range list {
+		obj, ok := e.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("expected an object, got %T", e)
+		}
+		out[fmt.Sprint(obj[key])] = e
+	}
+	return out, nil
+}
+
+// diff returns the fields that differ between a and b.
+func diff(path string, a, b interface{}) []FieldDiff {
+	switch av := a.(type) {
+	case map[string]interface{}:
+		bv, ok := b.(map[string]interface{})
+		if !ok {
+			break
+		}
+
+		keys := []string{}
+		for k := range av {
+			keys = append(keys, k)
+		}
+		for k := range bv {
+			if _, ok := av[k]; !ok {
+				keys = append(keys, k)
+			}
+		}
+		sort.Strings(keys)
+
+		out := []FieldDiff{}
+		for _, k := range keys {
+			out = append(out, diff(join(path, k), av[k], bv[k])...)
+		}
+		return out
+
+	case []interface{}:
+		bv, ok := b.([]interface{})
+		if !ok || len(av) != len(bv) {
+			break
+		}
+
+		out := []FieldDiff{}
+		for i := range av {
+			out = append(out, diff(fmt.Sprintf("%s[%d]", path, i), av[i], bv[i])...)
+		}
+		return out
+	}
+
+	if reflect.DeepEqual(a, b) {
+		return nil
+	}
+	return []FieldDiff{{Path: path, A: a, B: b}}
+}
+
+func join(path, key string) string {
+	if len(path) < 1 {
+		return key
+	}
+	return path + "." + key
+}
+
+// WriteText writes a human readable report followed by a summary.
+func (r Report) WriteText(w io.Writer) error {
+	var sb strings.Builder
+
+	for _, d := range r.Diffs {
+		name := d.Check
+		if len(d.ID) > 0 {
+			name += " " + d.ID
+		}
+
+		if len(d.Missing) > 0 {
+			fmt.Fprintf(&sb, "%s: missing on %s\n", name, d.Missing)
+			continue
+		}
+
+		fmt.Fprintf(&sb, "%s:\n", name)
+		for _, f := range d.Fields {
+			fmt.Fprintf(&sb, "  %s: %s != %s\n", f.Path, value(f.A), value(f.B))
+		}
+	}
+
+	for _, e := range r.Errors {
+		fmt.Fprintf(&sb, "error: %s\n", e)
+	}
+
+	fmt.Fprintf(&sb, "%d checks, %d entities, %d differ, %d errors\n", r.Checks, r.Entities, len(r.Diffs), len(r.Errors))
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+func value(v interface{}) string {
+	if v == nil {
+		return "<none>"
+	}
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(b)
+}
@@ -0,0 +1,131 @@
+package diffcheck
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/eosswedenorg-go/atomicasset"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func endpoint(t *testing.T, responses map[string]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		data, ok := responses[req.URL.Path]
+		if !ok {
+			res.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		res.Header().Add("Content-type", "application/json; charset=utf-8")
+		_, err := res.Write([]byte(`{"success": true, "data": ` + data + `, "query_time": 1645374772067}`))
+		assert.NoError(t, err)
+	}))
+}
+
+func TestCompare(t *testing.T) {
+	a := endpoint(t, map[string]string{
+		"/atomicassets/v1/assets/1":              `{"asset_id": "1", "owner": "bob", "backed_tokens": [{"amount": "1"}]}`,
+		"/atomicassets/v1/assets":                `[{"asset_id": "1", "owner": "bob"}, {"asset_id": "2", "owner": "alice"}, {"asset_id": "10", "owner": "carol"}]`,
+		"/atomicassets/v1/collection/col/stats":  `{"assets": "3", "burned": "0"}`,
+		"/atomicassets/v1/templates/col/5/stats": `{"assets": "1", "burned": "0"}`,
+	})
+	defer a.Close()
+
+	b := endpoint(t, map[string]string{
+		"/atomicassets/v1/assets/1":             `{"asset_id": "1", "owner": "dave", "backed_tokens": [{"amount": "2"}]}`,
+		"/atomicassets/v1/assets":               `[{"asset_id": "1", "owner": "bob"}, {"asset_id": "3", "owner": "alice"}, {"asset_id": "10", "owner": "carol"}]`,
+		"/atomicassets/v1/collection/col/stats": `{"assets": "3", "burned": "0"}`,
+	})
+	defer b.Close()
+
+	report := Compare(context.Background(), atomicasset.New(a.URL), atomicasset.New(b.URL), []Check{
+		Asset("1"),
+		Assets(atomicasset.AssetsRequestParams{CollectionName: "col"}),
+		CollectionStats("col"),
+		TemplateStats("col", "5"),
+	}, Options{})
+
+	assert.True(t, report.Drift())
+	assert.Equal(t, 4, report.Checks)
+	assert.Equal(t, 6, report.Entities)
+
+	assert.Equal(t, []EntityDiff{
+		{Check: "asset 1", Fields: []FieldDiff{
+			{Path: "backed_tokens[0].amount", A: "1", B: "2"},
+			{Path: "owner", A: "bob", B: "dave"},
+		}},
+		{Check: "assets", ID: "2", Missing: "b"},
+		{Check: "assets", ID: "3", Missing: "a"},
+	}, report.Diffs)
+
+	require.Len(t, report.Errors, 1)
+	assert.Equal(t, "template stats col/5", report.Errors[0].Check)
+	assert.Equal(t, "b", report.Errors[0].Endpoint)
+
+	var buf bytes.Buffer
+	require.NoError(t, report.WriteText(&buf))
+	assert.Equal(t, `asset 1:
+  backed_tokens[0].amount: "1" != "2"
+  owner: "bob" != "dave"
+assets 2: missing on b
+assets 3: missing on a
+error: template stats col/5 (b): `+report.Errors[0].Err.Error()+`
+4 checks, 6 entities, 3 differ, 1 errors
+`, buf.String())
+}
+
+func TestCompare_Ignore(t *testing.T) {
+	a := endpoint(t, map[string]string{"/atomicassets/v1/assets/1": `{"asset_id": "1", "owner": "bob", "name": "a"}`})
+	defer a.Close()
+
+	b := endpoint(t, map[string]string{"/atomicassets/v1/assets/1": `{"asset_id": "1", "owner": "bob", "name": "b"}`})
+	defer b.Close()
+
+	report := Compare(context.Background(), atomicasset.New(a.URL), atomicasset.New(b.URL), []Check{Asset("1")}, Options{Ignore: []string{"name"}})
+	assert.False(t, report.Drift())
+	assert.Empty(t, report.Errors)
+	assert.Equal(t, 1, report.Entities)
+}
+
+func TestCompare_DefaultIgnore(t *testing.T) {
+	a := endpoint(t, map[string]string{
+		"/atomicassets/v1/assets/1": `{"asset_id": "1", "owner": "bob", "updated_at_block": "100", "updated_at_time": "1645374772000"}`,
+		"/atomicassets/v1/assets": `[
+			{"asset_id": "1", "owner": "bob", "updated_at_block": "100", "updated_at_time": "1645374772000"},
+			{"asset_id": "2", "owner": "alice", "updated_at_block": "101", "updated_at_time": "1645374772500"}
+		]`,
+	})
+	defer a.Close()
+
+	b := endpoint(t, map[string]string{
+		"/atomicassets/v1/assets/1": `{"asset_id": "1", "owner": "bob", "updated_at_block": "102", "updated_at_time": "1645374773000"}`,
+		"/atomicassets/v1/assets": `[
+			{"asset_id": "2", "owner": "alice", "updated_at_block": "103", "updated_at_time": "1645374773500"},
+			{"asset_id": "1", "owner": "bob", "updated_at_block": "102", "updated_at_time": "1645374773000"}
+		]`,
+	})
+	defer b.Close()
+
+	report := Compare(context.Background(), atomicasset.New(a.URL), atomicasset.New(b.URL), []Check{
+		Asset("1"),
+		Assets(atomicasset.AssetsRequestParams{CollectionName: "col"}),
+	}, Options{})
+	assert.False(t, report.Drift())
+	assert.Empty(t, report.Errors)
+	assert.Equal(t, 3, report.Entities)
+}
+
+func TestDiff(t *testing.T) {
+	a := map[string]interface{}{"x": []interface{}{1.0, 2.0}, "y": "only a"}
+	b := map[string]interface{}{"x": []interface{}{1.0}, "z": "only b"}
+
+	assert.Equal(t, []FieldDiff{
+		{Path: "x", A: []interface{}{1.0, 2.0}, B: []interface{}{1.0}},
+		{Path: "y", A: "only a", B: nil},
+		{Path: "z", A: nil, B: "only b"},
+	}, diff("", a, b))
+}
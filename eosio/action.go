@@ -0,0 +1,69 @@
+package eosio
+
+import (
+	"encoding/hex"
+	"fmt"
+)
+
+// PermissionLevel is an account and permission that authorizes an action.
+type PermissionLevel struct {
+	Actor      string `json:"actor"`
+	Permission string `json:"permission"`
+}
+
+func (p PermissionLevel) Pack(e *Encoder) {
+	e.Name(p.Actor)
+	e.Name(p.Permission)
+}
+
+// Action is a contract action in the format used by the chain api.
+//
+// Data holds the action data in its json form and HexData the same
+// data serialized with the contract's ABI.
+type Action struct {
+	Account       string            `json:"account"`
+	Name          string            `json:"name"`
+	Authorization []PermissionLevel `json:"authorization"`
+	Data          interface{}       `json:"data"`
+	HexData       string            `json:"hex_data"`
+}
+
+// NewAction creates an action and serializes data.
+func NewAction(account string, name string, authorization []PermissionLevel, data Packer) (Action, error) {
+	b, err := Pack(data)
+	if err != nil {
+		return Action{}, fmt.Errorf("action %s::%s: %s", account, name, err)
+	}
+
+	return Action{
+		Account:       account,
+		Name:          name,
+		Authorization: authorization,
+		Data:          data,
+		HexData:       hex.EncodeToString(b),
+	}, nil
+}
+
+// WithAuthorization returns a copy of the action authorized by levels.
+func (a Action) WithAuthorization(levels ...PermissionLevel) Action {
+	a.Authorization = append([]PermissionLevel{}, levels...)
+	return a
+}
+
+// Pack writes the action as it is stored in a transaction.
+func (a Action) Pack(e *Encoder) {
+	e.Name(a.Account)
+	e.Name(a.Name)
+
+	e.Length(len(a.Authorization))
+	for _, p := range a.Authorization {
+		p.Pack(e)
+	}
+
+	data, err := hex.DecodeString(a.HexData)
+	if err != nil {
+		e.SetError(fmt.Errorf("action %s::%s: invalid hex data: %s", a.Account, a.Name, err))
+		return
+	}
+	e.ByteArray(data)
+}
@@ -0,0 +1,157 @@
+package eosio
+
+import (
+	"encoding/binary"
+	"math"
+)
+
+// Encoder writes values in the EOSIO binary (ABI) format.
+//
+// The first error is kept and returned by Bytes, so values can be
+// written without checking each call.
+type Encoder struct {
+	buf []byte
+	err error
+}
+
+// Packer is implemented by types that can be written in the binary format.
+type Packer interface {
+	Pack(e *Encoder)
+}
+
+// Pack encodes v.
+func Pack(v Packer) ([]byte, error) {
+	e := &Encoder{}
+	v.Pack(e)
+	return e.Bytes()
+}
+
+// Bytes returns the encoded data or the first error.
+func (e *Encoder) Bytes() ([]byte, error) {
+	if e.err != nil {
+		return nil, e.err
+	}
+	return e.buf, nil
+}
+
+// SetError records err if no error has been recorded yet.
+func (e *Encoder) SetError(err error) {
+	if e.err == nil {
+		e.err = err
+	}
+}
+
+// Raw writes b without a length prefix.
+func (e *Encoder) Raw(b []byte) {
+	e.buf = append(e.buf, b...)
+}
+
+func (e *Encoder) Bool(v bool) {
+	if v {
+		e.Uint8(1)
+	} else {
+		e.Uint8(0)
+	}
+}
+
+func (e *Encoder) Uint8(v uint8) {
+	e.buf = append(e.buf, v)
+}
+
+func (e *Encoder) Uint16(v uint16) {
+	var b [2]byte
+	binary.LittleEndian.PutUint16(b[:], v)
+	e.Raw(b[:])
+}
+
+func (e *Encoder) Uint32(v uint32) {
+	var b [4]byte
+	binary.LittleEndian.PutUint32(b[:], v)
+	e.Raw(b[:])
+}
+
+func (e *Encoder) Uint64(v uint64) {
+	var b [8]byte
+	binary.LittleEndian.PutUint64(b[:], v)
+	e.Raw(b[:])
+}
+
+func (e *Encoder) Int8(v int8) {
+	e.Uint8(uint8(v))
+}
+
+func (e *Encoder) Int16(v int16) {
+	e.Uint16(uint16(v))
+}
+
+func (e *Encoder) Int32(v int32) {
+	e.Uint32(uint32(v))
+}
+
+func (e *Encoder) Int64(v int64) {
+	e.Uint64(uint64(v))
+}
+
+func (e *Encoder) Float32(v float32) {
+	e.Uint32(math.Float32bits(v))
+}
+
+func (e *Encoder) Float64(v float64) {
+	e.Uint64(math.Float64bits(v))
+}
+
+// VarUint32 writes v as a LEB128 varint.
+func (e *Encoder) VarUint32(v uint32) {
+	var b [binary.MaxVarintLen32]byte
+	e.Raw(b[:binary.PutUvarint(b[:], uint64(v))])
+}
+
+// Length writes the length prefix of a vector, string or byte array.
+func (e *Encoder) Length(n int) {
+	e.VarUint32(uint32(n))
+}
+
+// ByteArray writes b with a length prefix.
+func (e *Encoder) ByteArray(b []byte) {
+	e.Length(len(b))
+	e.Raw(b)
+}
+
+func (e *Encoder) String(s string) {
+	e.Length(len(s))
+	e.buf = append(e.buf, s...)
+}
+
+// Name writes an EOSIO name.
+func (e *Encoder) Name(s string) {
+	n, err := NameToUint64(s)
+	if err != nil {
+		e.SetError(err)
+		return
+	}
+	e.Uint64(n)
+}
+
+// Symbol writes a symbol.
+func (e *Encoder) Symbol(precision int, code string) {
+	n, err := SymbolToUint64(precision, code)
+	if err != nil {
+		e.SetError(err)
+		return
+	}
+	e.Uint64(n)
+}
+
+// Asset writes an asset with amount in the smallest unit of the symbol.
+func (e *Encoder) Asset(amount int64, precision int, code string) {
+	e.Int64(amount)
+	e.Symbol(precision, code)
+}
+
+// Names writes a vector of names.
+func (e *Encoder) Names(names []string) {
+	e.Length(len(names))
+	for _, n := range names {
+		e.Name(n)
+	}
+}
@@ -0,0 +1,204 @@
+package eosio
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestName(t *testing.T) {
+	tests := []struct {
+		name  string
+		value uint64
+	}{
+		{"", 0},
+		{"eosio", 6138663577826885632},
+		{"eosio.token", 6138663591592764928},
+		{"atomicassets", 3920707972631802752},
+		{"active", 3617214756542218240},
+		{"zzzzzzzzzzzzj", 18446744073709551615},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n, err := NameToUint64(tt.name)
+			require.NoError(t, err)
+			assert.Equal(t, tt.value, n)
+			assert.Equal(t, tt.name, NameFromUint64(n))
+		})
+	}
+}
+
+func TestName_Invalid(t *testing.T) {
+	for _, name := range []string{"Eosio", "eosio6", "toolongname.aaa", "zzzzzzzzzzzzz", "eosio.", "a b"} {
+		t.Run(name, func(t *testing.T) {
+			_, err := NameToUint64(name)
+			assert.Error(t, err)
+			assert.False(t, ValidName(name))
+		})
+	}
+	assert.False(t, ValidName(""))
+}
+
+func TestSymbol(t *testing.T) {
+	n, err := SymbolToUint64(8, "WAX")
+	require.NoError(t, err)
+	assert.Equal(t, uint64(0x58415708), n)
+
+	p, code := SymbolFromUint64(n)
+	assert.Equal(t, 8, p)
+	assert.Equal(t, "WAX", code)
+
+	_, err = SymbolToUint64(4, "wax")
+	assert.EqualError(t, err, "invalid symbol code 'wax'")
+
+	_, err = SymbolToUint64(19, "WAX")
+	assert.EqualError(t, err, "invalid symbol precision 19")
+}
+
+func TestEncoder(t *testing.T) {
+	e := &Encoder{}
+	e.Bool(true)
+	e.Uint8(1)
+	e.Int16(-2)
+	e.Uint32(3)
+	e.Int64(-4)
+	e.Float32(1.5)
+	e.Float64(-2.5)
+	e.VarUint32(300)
+	e.String("hi")
+	e.ByteArray([]byte{0xff})
+	e.Name("eosio")
+	e.Asset(150000000, 8, "WAX")
+
+	b, err := e.Bytes()
+	require.NoError(t, err)
+	assert.Equal(t, "01"+"01"+"feff"+"03000000"+"fcffffffffffffff"+"0000c03f"+"00000000000004c0"+
+		"ac02"+"026869"+"01ff"+"0000000000ea3055"+"80d1f00800000000"+"0857415800000000", hex.EncodeToString(b))
+
+	e.Name("INVALID")
+	e.Name("also.Invalid")
+	_, err = e.Bytes()
+	assert.EqualError(t, err, "invalid name 'INVALID': invalid character 'I'")
+
+	e = &Encoder{}
+	e.SetError(errors.New("first"))
+	e.SetError(errors.New("second"))
+	_, err = e.Bytes()
+	assert.EqualError(t, err, "first")
+}
+
+type testData struct {
+	From string `json:"from"`
+	Memo string `json:"memo"`
+}
+
+func (d testData) Pack(e *Encoder) {
+	e.Name(d.From)
+	e.String(d.Memo)
+}
+
+func TestAction(t *testing.T) {
+	auth := []PermissionLevel{{Actor: "alice", Permission: "active"}}
+
+	a, err := NewAction("eosio.token", "transfer", auth, testData{From: "alice", Memo: "hi"})
+	require.NoError(t, err)
+
+	assert.Equal(t, "0000000000855c34"+"026869", a.HexData)
+
+	b, err := json.Marshal(a)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"account": "eosio.token",
+		"name": "transfer",
+		"authorization": [{"actor": "alice", "permission": "active"}],
+		"data": {"from": "alice", "memo": "hi"},
+		"hex_data": "0000000000855c34026869"
+	}`, string(b))
+
+	packed, err := Pack(a)
+	require.NoError(t, err)
+	assert.Equal(t, "00a6823403ea3055"+"000000572d3ccdcd"+"01"+"0000000000855c34"+"00000000a8ed3232"+"0b"+"0000000000855c34026869", hex.EncodeToString(packed))
+
+	other := a.WithAuthorization(PermissionLevel{Actor: "bob", Permission: "owner"})
+	assert.Equal(t, []PermissionLevel{{Actor: "bob", Permission: "owner"}}, other.Authorization)
+	assert.Equal(t, auth, a.Authorization)
+
+	_, err = NewAction("eosio.token", "transfer", auth, testData{From: "Alice"})
+	assert.EqualError(t, err, "action eosio.token::transfer: invalid name 'Alice': invalid character 'A'")
+}
+
+func TestKeys(t *testing.T) {
+	priv, err := ParsePrivateKey("5KQwrPbwdL6PhXujxW37FSSQZ1JiwsST4cqQzDeyXtP79zkvFD3")
+	require.NoError(t, err)
+	assert.Equal(t, "5KQwrPbwdL6PhXujxW37FSSQZ1JiwsST4cqQzDeyXtP79zkvFD3", priv.String())
+
+	pub := priv.PublicKey()
+	assert.Equal(t, "EOS6MRyAjQq8ud7hVNYcfnVPJqcVpscN5So8BhtHuGYqET5GDW5CV", pub.String())
+	assert.Equal(t, "PUB_K1_6MRyAjQq8ud7hVNYcfnVPJqcVpscN5So8BhtHuGYqET5BoDq63", pub.K1String())
+
+	for _, s := range []string{pub.String(), pub.K1String()} {
+		parsed, err := ParsePublicKey(s)
+		require.NoError(t, err)
+		assert.Equal(t, pub, parsed)
+	}
+
+	parsed, err := ParsePrivateKey("PVT_K1_2bfGi9rYsXQSXXTvJbDAPhHLQUojjaNLomdm3cEJ1XTzMqUt3V")
+	require.NoError(t, err)
+	assert.Equal(t, priv, parsed)
+
+	_, err = ParsePrivateKey("5KQwrPbwdL6PhXujxW37FSSQZ1JiwsST4cqQzDeyXtP79zkvFD4")
+	assert.EqualError(t, err, "invalid private key: checksum mismatch")
+
+	_, err = ParsePublicKey("EOS6MRyAjQq8ud7hVNYcfnVPJqcVpscN5So8BhtHuGYqET5GDW5CW")
+	assert.EqualError(t, err, "invalid public key: checksum mismatch")
+
+	_, err = ParsePublicKey("PUB_R1_6MRyAjQq8ud7hVNYcfnVPJqcVpscN5So8BhtHuGYqET5BoDq63")
+	assert.EqualError(t, err, "invalid public key: unknown format")
+
+	b, err := Pack(pub)
+	require.NoError(t, err)
+	assert.Equal(t, "00"+hex.EncodeToString(pub[:]), hex.EncodeToString(b))
+}
+
+func TestNewPrivateKey(t *testing.T) {
+	a, err := NewPrivateKey()
+	require.NoError(t, err)
+
+	b, err := NewPrivateKey()
+	require.NoError(t, err)
+	assert.NotEqual(t, a, b)
+
+	parsed, err := ParsePrivateKey(a.String())
+	require.NoError(t, err)
+	assert.Equal(t, a, parsed)
+}
+
+func TestSign(t *testing.T) {
+	priv, err := ParsePrivateKey("5KQwrPbwdL6PhXujxW37FSSQZ1JiwsST4cqQzDeyXtP79zkvFD3")
+	require.NoError(t, err)
+
+	for i := 0; i < 32; i++ {
+		digest := sha256.Sum256([]byte{byte(i)})
+
+		sig, err := priv.Sign(digest)
+		require.NoError(t, err)
+		assert.True(t, sig.IsCanonical())
+
+		pub, err := sig.PublicKey(digest)
+		require.NoError(t, err)
+		assert.Equal(t, priv.PublicKey(), pub)
+
+		parsed, err := ParseSignature(sig.String())
+		require.NoError(t, err)
+		assert.Equal(t, sig, parsed)
+	}
+
+	_, err = PrivateKey{}.Sign([32]byte{})
+	assert.EqualError(t, err, "invalid private key")
+}
@@ -0,0 +1,301 @@
+package eosio
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+	"golang.org/x/crypto/ripemd160"
+
+	"github.com/eosswedenorg-go/atomicasset/internal/base58"
+)
+
+// Key type index of K1 keys and signatures in the binary format.
+const keyTypeK1 = 0
+
+// Types
+
+// PrivateKey is a K1 (secp256k1) private key.
+type PrivateKey [32]byte
+
+// PublicKey is a compressed K1 (secp256k1) public key.
+type PublicKey [33]byte
+
+// Signature is a compact K1 signature (recovery byte, r and s).
+type Signature [65]byte
+
+// Keys
+
+// NewPrivateKey generates a random private key.
+func NewPrivateKey() (PrivateKey, error) {
+	return NewPrivateKeyFromRand(rand.Reader)
+}
+
+// NewPrivateKeyFromRand generates a private key from r, which must be
+// a cryptographically secure source of randomness.
+func NewPrivateKeyFromRand(r io.Reader) (PrivateKey, error) {
+	k, err := secp256k1.GeneratePrivateKeyFromRand(r)
+	if err != nil {
+		return PrivateKey{}, err
+	}
+
+	var key PrivateKey
+	copy(key[:], k.Serialize())
+	return key, nil
+}
+
+// ParsePrivateKey parses a private key in WIF ("5...") or "PVT_K1_" format.
+func ParsePrivateKey(s string) (PrivateKey, error) {
+	var key PrivateKey
+
+	if data := strings.TrimPrefix(s, "PVT_K1_"); len(data) < len(s) {
+		b, err := decodeKeyData(data, len(key), "K1")
+		if err != nil {
+			return key, fmt.Errorf("invalid private key: %s", err)
+		}
+		copy(key[:], b)
+		return key, nil
+	}
+
+	b, err := base58.Decode(s)
+	if err != nil || len(b) != 1+len(key)+4 || b[0] != 0x80 {
+		return key, errors.New("invalid private key")
+	}
+
+	sum := doubleSha256(b[:len(b)-4])
+	if !bytes.Equal(sum[:4], b[len(b)-4:]) {
+		return key, errors.New("invalid private key: checksum mismatch")
+	}
+
+	copy(key[:], b[1:len(b)-4])
+	return key, nil
+}
+
+// String returns the key in WIF format.
+func (k PrivateKey) String() string {
+	b := append([]byte{0x80}, k[:]...)
+	sum := doubleSha256(b)
+	return base58.Encode(append(b, sum[:4]...))
+}
+
+// PublicKey returns the public key of k.
+func (k PrivateKey) PublicKey() PublicKey {
+	var pub PublicKey
+	copy(pub[:], secp256k1.PrivKeyFromBytes(k[:]).PubKey().SerializeCompressed())
+	return pub
+}
+
+// Sign signs a sha256 digest.
+//
+// The signature is canonical as required by the chain, so the nonce
+// is incremented until a canonical signature is found.
+func (k PrivateKey) Sign(digest [32]byte) (Signature, error) {
+	priv := secp256k1.PrivKeyFromBytes(k[:])
+	if priv.Key.IsZero() {
+		return Signature{}, errors.New("invalid private key")
+	}
+
+	var e secp256k1.ModNScalar
+	e.SetByteSlice(digest[:])
+
+	for i := uint32(0); i < 1000; i++ {
+		nonce := secp256k1.NonceRFC6979(k[:], digest[:], nil, nil, i)
+
+		var kG secp256k1.JacobianPoint
+		secp256k1.ScalarBaseMultNonConst(nonce, &kG)
+		kG.ToAffine()
+
+		var r secp256k1.ModNScalar
+		overflow := r.SetBytes(kG.X.Bytes())
+		if r.IsZero() {
+			continue
+		}
+		recovery := byte(overflow<<1) | byte(kG.Y.IsOddBit())
+
+		kinv := new(secp256k1.ModNScalar).InverseValNonConst(nonce)
+		s := new(secp256k1.ModNScalar).Mul2(&priv.Key, &r).Add(&e).Mul(kinv)
+		if s.IsZero() {
+			continue
+		}
+
+		if s.IsOverHalfOrder() {
+			s.Negate()
+			recovery ^= 0x01
+		}
+
+		var sig Signature
+		sig[0] = 27 + 4 + recovery
+		r.PutBytesUnchecked(sig[1:33])
+		s.PutBytesUnchecked(sig[33:65])
+
+		if sig.IsCanonical() {
+			return sig, nil
+		}
+	}
+	return Signature{}, errors.New("failed to create a canonical signature")
+}
+
+// ParsePublicKey parses a public key in legacy ("EOS...") or "PUB_K1_" format.
+func ParsePublicKey(s string) (PublicKey, error) {
+	var key PublicKey
+
+	var b []byte
+	var err error
+	switch {
+	case strings.HasPrefix(s, "PUB_K1_"):
+		b, err = decodeKeyData(strings.TrimPrefix(s, "PUB_K1_"), len(key), "K1")
+	case strings.HasPrefix(s, "EOS"):
+		b, err = decodeKeyData(strings.TrimPrefix(s, "EOS"), len(key), "")
+	default:
+		err = errors.New("unknown format")
+	}
+
+	if err != nil {
+		return key, fmt.Errorf("invalid public key: %s", err)
+	}
+
+	if _, err := secp256k1.ParsePubKey(b); err != nil {
+		return key, fmt.Errorf("invalid public key: %s", err)
+	}
+
+	copy(key[:], b)
+	return key, nil
+}
+
+// String returns the key in the legacy "EOS..." format.
+func (k PublicKey) String() string {
+	return "EOS" + encodeKeyData(k[:], "")
+}
+
+// K1String returns the key in "PUB_K1_" format.
+func (k PublicKey) K1String() string {
+	return "PUB_K1_" + encodeKeyData(k[:], "K1")
+}
+
+func (k PublicKey) Pack(e *Encoder) {
+	e.Uint8(keyTypeK1)
+	e.Raw(k[:])
+}
+
+func (k PublicKey) MarshalJSON() ([]byte, error) {
+	return json.Marshal(k.String())
+}
+
+func (k *PublicKey) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+
+	key, err := ParsePublicKey(s)
+	if err == nil {
+		*k = key
+	}
+	return err
+}
+
+// Signatures
+
+// ParseSignature parses a signature in "SIG_K1_" format.
+func ParseSignature(s string) (Signature, error) {
+	var sig Signature
+
+	if !strings.HasPrefix(s, "SIG_K1_") {
+		return sig, errors.New("invalid signature: unknown format")
+	}
+
+	b, err := decodeKeyData(strings.TrimPrefix(s, "SIG_K1_"), len(sig), "K1")
+	if err != nil {
+		return sig, fmt.Errorf("invalid signature: %s", err)
+	}
+
+	copy(sig[:], b)
+	return sig, nil
+}
+
+// String returns the signature in "SIG_K1_" format.
+func (s Signature) String() string {
+	return "SIG_K1_" + encodeKeyData(s[:], "K1")
+}
+
+// IsCanonical reports whether r and s are canonical as required by the chain.
+func (s Signature) IsCanonical() bool {
+	return s[1]&0x80 == 0 && !(s[1] == 0 && s[2]&0x80 == 0) &&
+		s[33]&0x80 == 0 && !(s[33] == 0 && s[34]&0x80 == 0)
+}
+
+// PublicKey recovers the public key that signed digest.
+func (s Signature) PublicKey(digest [32]byte) (PublicKey, error) {
+	pub, _, err := ecdsa.RecoverCompact(s[:], digest[:])
+	if err != nil {
+		return PublicKey{}, err
+	}
+
+	var key PublicKey
+	copy(key[:], pub.SerializeCompressed())
+	return key, nil
+}
+
+func (s Signature) Pack(e *Encoder) {
+	e.Uint8(keyTypeK1)
+	e.Raw(s[:])
+}
+
+func (s Signature) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.String())
+}
+
+func (s *Signature) UnmarshalJSON(b []byte) error {
+	var str string
+	if err := json.Unmarshal(b, &str); err != nil {
+		return err
+	}
+
+	sig, err := ParseSignature(str)
+	if err == nil {
+		*s = sig
+	}
+	return err
+}
+
+// Helpers
+
+// keyChecksum returns the ripemd160 checksum of data followed by suffix.
+func keyChecksum(data []byte, suffix string) []byte {
+	h := ripemd160.New()
+	h.Write(data)
+	h.Write([]byte(suffix))
+	return h.Sum(nil)[:4]
+}
+
+func encodeKeyData(data []byte, suffix string) string {
+	return base58.Encode(append(append([]byte{}, data...), keyChecksum(data, suffix)...))
+}
+
+func decodeKeyData(s string, size int, suffix string) ([]byte, error) {
+	b, err := base58.Decode(s)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(b) != size+4 {
+		return nil, errors.New("invalid length")
+	}
+
+	if !bytes.Equal(keyChecksum(b[:size], suffix), b[size:]) {
+		return nil, errors.New("checksum mismatch")
+	}
+	return b[:size], nil
+}
+
+func doubleSha256(b []byte) [32]byte {
+	sum := sha256.Sum256(b)
+	return sha256.Sum256(sum[:])
+}
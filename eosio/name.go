@@ -0,0 +1,108 @@
+// Package eosio implements the parts of the EOSIO protocol needed to
+// build actions: names, symbols, binary (ABI) serialization, the action
+// format and K1 keys and signatures.
+package eosio
+
+import (
+	"fmt"
+	"strings"
+)
+
+const nameChars = ".12345abcdefghijklmnopqrstuvwxyz"
+
+func nameSymbol(c byte) (uint64, bool) {
+	switch {
+	case c >= 'a' && c <= 'z':
+		return uint64(c-'a') + 6, true
+	case c >= '1' && c <= '5':
+		return uint64(c-'1') + 1, true
+	case c == '.':
+		return 0, true
+	}
+	return 0, false
+}
+
+// NameToUint64 converts an EOSIO name to its integer value.
+func NameToUint64(s string) (uint64, error) {
+	if len(s) > 13 {
+		return 0, fmt.Errorf("invalid name '%s': longer than 13 characters", s)
+	}
+
+	var n uint64
+	for i := 0; i < len(s); i++ {
+		c, ok := nameSymbol(s[i])
+		if !ok {
+			return 0, fmt.Errorf("invalid name '%s': invalid character '%c'", s, s[i])
+		}
+
+		if i < 12 {
+			n |= (c & 0x1f) << (64 - 5*(i+1))
+		} else {
+			if c > 0x0f {
+				return 0, fmt.Errorf("invalid name '%s': invalid 13th character '%c'", s, s[i])
+			}
+			n |= c
+		}
+	}
+
+	if NameFromUint64(n) != s {
+		return 0, fmt.Errorf("invalid name '%s'", s)
+	}
+	return n, nil
+}
+
+// NameFromUint64 converts the integer value of a name to a string.
+func NameFromUint64(n uint64) string {
+	b := make([]byte, 13)
+	for i := 0; i <= 12; i++ {
+		if i == 0 {
+			b[12] = nameChars[n&0x0f]
+			n >>= 4
+		} else {
+			b[12-i] = nameChars[n&0x1f]
+			n >>= 5
+		}
+	}
+	return strings.TrimRight(string(b), ".")
+}
+
+// ValidName reports whether s is a valid, non-empty EOSIO name.
+func ValidName(s string) bool {
+	if len(s) < 1 {
+		return false
+	}
+	_, err := NameToUint64(s)
+	return err == nil
+}
+
+// SymbolToUint64 converts a symbol (precision and code) to its integer value.
+func SymbolToUint64(precision int, code string) (uint64, error) {
+	if precision < 0 || precision > 18 {
+		return 0, fmt.Errorf("invalid symbol precision %d", precision)
+	}
+
+	if len(code) < 1 || len(code) > 7 {
+		return 0, fmt.Errorf("invalid symbol code '%s'", code)
+	}
+
+	n := uint64(precision)
+	for i := 0; i < len(code); i++ {
+		c := code[i]
+		if c < 'A' || c > 'Z' {
+			return 0, fmt.Errorf("invalid symbol code '%s'", code)
+		}
+		n |= uint64(c) << (8 * (i + 1))
+	}
+	return n, nil
+}
+
+// SymbolFromUint64 converts the integer value of a symbol to its precision and code.
+func SymbolFromUint64(n uint64) (int, string) {
+	precision := int(n & 0xff)
+
+	code := []byte{}
+	for n >>= 8; n > 0; n >>= 8 {
+		code = append(code, byte(n&0xff))
+	}
+	return precision, string(code)
+}
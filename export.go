@@ -0,0 +1,342 @@
+package atomicasset
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+)
+
+// Types
+
+// ErrExportCount is returned when the number of exported records does not
+// match the count reported by the API.
+var ErrExportCount = errors.New("export count mismatch")
+
+// ExportResource is a list resource that can be exported.
+//
+// Records are paged by id: Fetch must return up to limit records with an
+// id of at least lowerBound, sorted by id in ascending order.
+type ExportResource[T any] struct {
+	Fetch func(ctx context.Context, lowerBound string, limit int) ([]T, error)
+	ID    func(T) string
+
+	// Count returns the total number of records, optional.
+	Count func(ctx context.Context) (int64, error)
+}
+
+// ExportOptions controls where and how records are exported.
+type ExportOptions struct {
+	// Path of the NDJSON file.
+	Path string
+
+	// Gzip compresses the file. Each batch is written as a separate gzip
+	// member, the file can be read as a single stream with gzip.Reader.
+	Gzip bool
+
+	// Checkpoint is the path of the sidecar file that holds the export
+	// cursor. Defaults to Path + ".checkpoint".
+	Checkpoint string
+
+	// Limit is the number of records fetched per request. Defaults to 100.
+	Limit int
+}
+
+// ExportCheckpoint is the state of an export, saved after each batch.
+type ExportCheckpoint struct {
+	// Cursor is the lower bound of the next batch.
+	Cursor string `json:"cursor"`
+
+	// LastID is the id of the last exported record.
+	LastID string `json:"last_id"`
+
+	// Records is the number of exported records.
+	Records int64 `json:"records"`
+
+	// Offset is the size of the file after the last batch. Anything
+	// written after it is discarded when resuming.
+	Offset int64 `json:"offset"`
+
+	Done bool `json:"done"`
+}
+
+// ExportResult is the result of an export.
+type ExportResult struct {
+	Records int64
+
+	// Expected is the count reported by the API, -1 if the resource has no count.
+	Expected int64
+
+	// Resumed is true if the export continued from a checkpoint.
+	Resumed bool
+}
+
+// Export writes every record of resource as NDJSON.
+//
+// If a checkpoint from an earlier run exists, the export resumes after the
+// last record in it. When all records are written the count is verified
+// against the resource's Count and ErrExportCount is returned (together
+// with the result) if they differ.
+func Export[T any](ctx context.Context, resource ExportResource[T], opts ExportOptions) (ExportResult, error) {
+	result := ExportResult{Expected: -1}
+
+	if len(opts.Path) < 1 {
+		return result, errors.New("export requires a path")
+	}
+
+	if len(opts.Checkpoint) < 1 {
+		opts.Checkpoint = opts.Path + ".checkpoint"
+	}
+
+	if opts.Limit < 1 {
+		opts.Limit = pageLimit
+	}
+
+	cp, err := readExportCheckpoint(opts.Checkpoint)
+	if err != nil {
+		return result, err
+	}
+	result.Resumed = cp.Records > 0 || cp.Done
+
+	if !cp.Done {
+		if cp, err = exportRecords(ctx, resource, opts, cp); err != nil {
+			result.Records = cp.Records
+			return result, err
+		}
+	}
+	result.Records = cp.Records
+
+	if resource.Count != nil {
+		if result.Expected, err = resource.Count(ctx); err != nil {
+			return result, err
+		}
+
+		if result.Expected != result.Records {
+			return result, fmt.Errorf("%w: exported %d records, count is %d", ErrExportCount, result.Records, result.Expected)
+		}
+	}
+	return result, nil
+}
+
+// exportRecords appends batches to the file until all records are written.
+func exportRecords[T any](ctx context.Context, resource ExportResource[T], opts ExportOptions, cp ExportCheckpoint) (ExportCheckpoint, error) {
+	f, err := os.OpenFile(opts.Path, os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return cp, err
+	}
+	defer f.Close()
+
+	// Discard anything written after the last checkpoint.
+	if err := f.Truncate(cp.Offset); err != nil {
+		return cp, err
+	}
+
+	if _, err := f.Seek(cp.Offset, io.SeekStart); err != nil {
+		return cp, err
+	}
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return cp, err
+		}
+
+		items, err := resource.Fetch(ctx, cp.Cursor, opts.Limit)
+		if err != nil {
+			return cp, err
+		}
+
+		next := cp
+		batch := []T{}
+		for _, item := range items {
+			id := resource.ID(item)
+
+			// Skip records at or before the last one, in case
+			// the lower bound is inclusive of the previous batch.
+			if len(next.LastID) > 0 && compareIDs(id, next.LastID) <= 0 {
+				continue
+			}
+
+			next.LastID = id
+			batch = append(batch, item)
+		}
+
+		if len(batch) > 0 {
+			if err := writeExportBatch(f, batch, opts.Gzip); err != nil {
+				return cp, err
+			}
+
+			if next.Offset, err = f.Seek(0, io.SeekCurrent); err != nil {
+				return cp, err
+			}
+
+			if next.Cursor, err = nextID(next.LastID); err != nil {
+				return cp, err
+			}
+			next.Records += int64(len(batch))
+		}
+
+		next.Done = len(items) < opts.Limit || len(batch) < 1
+		if err := writeExportCheckpoint(opts.Checkpoint, next); err != nil {
+			return cp, err
+		}
+
+		cp = next
+		if cp.Done {
+			return cp, nil
+		}
+	}
+}
+
+// writeExportBatch writes a batch of records as NDJSON and syncs the file.
+func writeExportBatch[T any](f *os.File, batch []T, compress bool) error {
+	var w io.Writer = f
+	var gz *gzip.Writer
+	if compress {
+		gz = gzip.NewWriter(f)
+		w = gz
+	}
+
+	buf := bufio.NewWriter(w)
+	enc := json.NewEncoder(buf)
+	for _, item := range batch {
+		if err := enc.Encode(item); err != nil {
+			return err
+		}
+	}
+
+	if err := buf.Flush(); err != nil {
+		return err
+	}
+
+	if gz != nil {
+		if err := gz.Close(); err != nil {
+			return err
+		}
+	}
+	return f.Sync()
+}
+
+func readExportCheckpoint(path string) (ExportCheckpoint, error) {
+	var cp ExportCheckpoint
+
+	b, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return cp, nil
+	} else if err != nil {
+		return cp, err
+	}
+
+	if err := json.Unmarshal(b, &cp); err != nil {
+		return cp, fmt.Errorf("export checkpoint '%s': %s", path, err)
+	}
+	return cp, nil
+}
+
+// writeExportCheckpoint replaces the checkpoint file atomically.
+func writeExportCheckpoint(path string, cp ExportCheckpoint) error {
+	b, err := json.Marshal(cp)
+	if err != nil {
+		return err
+	}
+
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, b, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// compareIDs compares two numeric ids.
+func compareIDs(a, b string) int {
+	x, xok := new(big.Int).SetString(a, 10)
+	y, yok := new(big.Int).SetString(b, 10)
+	if !xok || !yok {
+		switch {
+		case a < b:
+			return -1
+		case a > b:
+			return 1
+		}
+		return 0
+	}
+	return x.Cmp(y)
+}
+
+// nextID returns id + 1.
+func nextID(id string) (string, error) {
+	n, ok := new(big.Int).SetString(id, 10)
+	if !ok {
+		return "", fmt.Errorf("invalid id '%s'", id)
+	}
+	return n.Add(n, big.NewInt(1)).String(), nil
+}
+
+// countData converts the data of a count response.
+func countData(resp CountResponse, err error) (int64, error) {
+	if err != nil {
+		return 0, err
+	}
+	return resp.Data.Int64()
+}
+
+// AssetExport exports the assets matching params.
+func AssetExport(c *Client, params AssetsRequestParams) ExportResource[Asset] {
+	params.Page, params.Limit, params.Order, params.Sort = 0, 0, "", ""
+	params.LowerBound, params.UpperBound = "", ""
+
+	return ExportResource[Asset]{
+		Fetch: func(ctx context.Context, lowerBound string, limit int) ([]Asset, error) {
+			p := params
+			p.LowerBound, p.Limit, p.Order, p.Sort = lowerBound, limit, SortAscending, AssetSortID
+			resp, err := c.WithContext(ctx).GetAssets(p)
+			return resp.Data, err
+		},
+		ID: func(a Asset) string { return a.ID },
+		Count: func(ctx context.Context) (int64, error) {
+			return countData(c.WithContext(ctx).GetAssetsCount(params))
+		},
+	}
+}
+
+// TransferExport exports the transfers matching params.
+func TransferExport(c *Client, params TransferRequestParams) ExportResource[Transfer] {
+	params.Page, params.Limit, params.Order, params.Sort = 0, 0, "", ""
+	params.LowerBound, params.UpperBound = "", ""
+
+	return ExportResource[Transfer]{
+		Fetch: func(ctx context.Context, lowerBound string, limit int) ([]Transfer, error) {
+			p := params
+			p.LowerBound, p.Limit, p.Order, p.Sort = lowerBound, limit, SortAscending, TransferSortCreated
+			resp, err := c.WithContext(ctx).GetTransfers(p)
+			return resp.Data, err
+		},
+		ID: func(t Transfer) string { return t.ID },
+		Count: func(ctx context.Context) (int64, error) {
+			return countData(c.WithContext(ctx).GetTransfersCount(params))
+		},
+	}
+}
+
+// SaleExport exports the sales matching params.
+func SaleExport(c *Client, params SalesRequestParams) ExportResource[Sale] {
+	params.Page, params.Limit, params.Order, params.Sort = 0, 0, "", ""
+	params.LowerBound, params.UpperBound = "", ""
+
+	return ExportResource[Sale]{
+		Fetch: func(ctx context.Context, lowerBound string, limit int) ([]Sale, error) {
+			p := params
+			p.LowerBound, p.Limit, p.Order, p.Sort = lowerBound, limit, SortAscending, SaleSortID
+			resp, err := c.WithContext(ctx).GetSales(p)
+			return resp.Data, err
+		},
+		ID: func(s Sale) string { return s.ID },
+		Count: func(ctx context.Context) (int64, error) {
+			return countData(c.WithContext(ctx).GetSalesCount(params))
+		},
+	}
+}
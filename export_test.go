@@ -0,0 +1,210 @@
+package atomicasset
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// exportServer serves assets 1 to total paged by lower_bound and
+// fails the request with number failAt (if not zero).
+type exportServer struct {
+	t        *testing.T
+	total    int
+	count    int
+	requests int
+	failAt   int
+}
+
+func (s *exportServer) ServeHTTP(res http.ResponseWriter, req *http.Request) {
+	q := req.URL.Query()
+	res.Header().Add("Content-type", "application/json; charset=utf-8")
+
+	if req.URL.Path == "/atomicassets/v1/assets/_count" {
+		assert.Equal(s.t, "mycol", q.Get("collection_name"))
+		assert.Equal(s.t, "", q.Get("limit"))
+		_, err := res.Write([]byte(fmt.Sprintf(`{"success": true, "data": "%d", "query_time": 0}`, s.count)))
+		assert.NoError(s.t, err)
+		return
+	}
+
+	assert.Equal(s.t, "/atomicassets/v1/assets", req.URL.Path)
+	assert.Equal(s.t, "mycol", q.Get("collection_name"))
+	assert.Equal(s.t, "asset_id", q.Get("sort"))
+	assert.Equal(s.t, "asc", q.Get("order"))
+	assert.Equal(s.t, "", q.Get("page"))
+
+	s.requests++
+	if s.requests == s.failAt {
+		res.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	lower, _ := strconv.Atoi(q.Get("lower_bound"))
+	limit, _ := strconv.Atoi(q.Get("limit"))
+
+	items := []string{}
+	for id := lower; id <= s.total && len(items) < limit; id++ {
+		if id > 0 {
+			items = append(items, fmt.Sprintf(`{"asset_id": "%d"}`, id))
+		}
+	}
+
+	_, err := res.Write([]byte(`{"success": true, "data": [` + strings.Join(items, ",") + `], "query_time": 0}`))
+	assert.NoError(s.t, err)
+}
+
+func readExportIDs(t *testing.T, path string, compressed bool) []string {
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+
+	var r io.Reader = f
+	if compressed {
+		gz, err := gzip.NewReader(f)
+		require.NoError(t, err)
+		r = gz
+	}
+
+	ids := []string{}
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		var a struct {
+			ID string `json:"asset_id"`
+		}
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &a))
+		ids = append(ids, a.ID)
+	}
+	require.NoError(t, scanner.Err())
+	return ids
+}
+
+func expectedIDs(n int) []string {
+	ids := []string{}
+	for i := 1; i <= n; i++ {
+		ids = append(ids, strconv.Itoa(i))
+	}
+	return ids
+}
+
+func TestExport(t *testing.T) {
+	for _, compressed := range []bool{false, true} {
+		t.Run(fmt.Sprintf("Gzip=%v", compressed), func(t *testing.T) {
+			api := &exportServer{t: t, total: 25, count: 25}
+			srv := httptest.NewServer(api)
+			defer srv.Close()
+
+			path := filepath.Join(t.TempDir(), "assets.ndjson")
+			resource := AssetExport(New(srv.URL), AssetsRequestParams{CollectionName: "mycol", Page: 3, Limit: 1000})
+
+			result, err := Export(context.Background(), resource, ExportOptions{Path: path, Gzip: compressed, Limit: 10})
+			require.NoError(t, err)
+
+			assert.Equal(t, ExportResult{Records: 25, Expected: 25}, result)
+			assert.Equal(t, 3, api.requests)
+			assert.Equal(t, expectedIDs(25), readExportIDs(t, path, compressed))
+
+			cp, err := readExportCheckpoint(path + ".checkpoint")
+			require.NoError(t, err)
+			assert.Equal(t, "26", cp.Cursor)
+			assert.Equal(t, "25", cp.LastID)
+			assert.True(t, cp.Done)
+
+			// A finished export is not fetched again.
+			result, err = Export(context.Background(), resource, ExportOptions{Path: path, Gzip: compressed, Limit: 10})
+			require.NoError(t, err)
+			assert.Equal(t, ExportResult{Records: 25, Expected: 25, Resumed: true}, result)
+			assert.Equal(t, 3, api.requests)
+		})
+	}
+}
+
+func TestExport_Resume(t *testing.T) {
+	for _, compressed := range []bool{false, true} {
+		t.Run(fmt.Sprintf("Gzip=%v", compressed), func(t *testing.T) {
+			api := &exportServer{t: t, total: 35, count: 35, failAt: 3}
+			srv := httptest.NewServer(api)
+			defer srv.Close()
+
+			path := filepath.Join(t.TempDir(), "assets.ndjson")
+			resource := AssetExport(New(srv.URL), AssetsRequestParams{CollectionName: "mycol"})
+			opts := ExportOptions{Path: path, Gzip: compressed, Limit: 10}
+
+			result, err := Export(context.Background(), resource, opts)
+			require.Error(t, err)
+			assert.Equal(t, int64(20), result.Records)
+
+			// Simulate a partial write after the checkpoint.
+			f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0o644)
+			require.NoError(t, err)
+			_, err = f.Write([]byte(`{"asset_id": "2`))
+			require.NoError(t, err)
+			require.NoError(t, f.Close())
+
+			result, err = Export(context.Background(), resource, opts)
+			require.NoError(t, err)
+			assert.Equal(t, ExportResult{Records: 35, Expected: 35, Resumed: true}, result)
+			assert.Equal(t, expectedIDs(35), readExportIDs(t, path, compressed))
+		})
+	}
+}
+
+func TestExport_CountMismatch(t *testing.T) {
+	api := &exportServer{t: t, total: 5, count: 6}
+	srv := httptest.NewServer(api)
+	defer srv.Close()
+
+	path := filepath.Join(t.TempDir(), "assets.ndjson")
+	result, err := Export(context.Background(), AssetExport(New(srv.URL), AssetsRequestParams{CollectionName: "mycol"}), ExportOptions{Path: path})
+
+	assert.True(t, errors.Is(err, ErrExportCount))
+	assert.EqualError(t, err, "export count mismatch: exported 5 records, count is 6")
+	assert.Equal(t, ExportResult{Records: 5, Expected: 6}, result)
+}
+
+func TestExport_Errors(t *testing.T) {
+	_, err := Export(context.Background(), ExportResource[Asset]{}, ExportOptions{})
+	assert.EqualError(t, err, "export requires a path")
+
+	path := filepath.Join(t.TempDir(), "assets.ndjson")
+	require.NoError(t, os.WriteFile(path+".checkpoint", []byte("{"), 0o644))
+
+	_, err = Export(context.Background(), ExportResource[Asset]{}, ExportOptions{Path: path})
+	assert.EqualError(t, err, "export checkpoint '"+path+".checkpoint': unexpected end of JSON input")
+}
+
+func TestTransferExport_Fetch(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		q := req.URL.Query()
+		assert.Equal(t, "/atomicassets/v1/transfers", req.URL.Path)
+		assert.Equal(t, "alice", q.Get("account"))
+		assert.Equal(t, "created", q.Get("sort"))
+		assert.Equal(t, "asc", q.Get("order"))
+		assert.Equal(t, "10", q.Get("lower_bound"))
+		assert.Equal(t, "", q.Get("page"))
+
+		res.Header().Add("Content-type", "application/json; charset=utf-8")
+		_, err := res.Write([]byte(`{"success": true, "data": [{"transfer_id": "10"}, {"transfer_id": "11"}], "query_time": 0}`))
+		assert.NoError(t, err)
+	}))
+	defer srv.Close()
+
+	export := TransferExport(New(srv.URL), TransferRequestParams{Account: ReqList[string]{"alice"}, Page: 3, Order: SortDescending})
+	transfers, err := export.Fetch(context.Background(), "10", 2)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"10", "11"}, []string{export.ID(transfers[0]), export.ID(transfers[1])})
+}
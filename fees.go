@@ -0,0 +1,99 @@
+package atomicasset
+
+import (
+	"fmt"
+)
+
+// Types
+
+// FeeBreakdown describes how the price of a sale, auction or buyoffer is
+// split between the marketplaces, the collection and the seller.
+type FeeBreakdown struct {
+	Price Token
+
+	MakerMarketplace string
+	MakerCut         Token
+
+	TakerMarketplace string
+	TakerCut         Token
+
+	// Royalty is paid to the collection author.
+	Collection string
+	Author     string
+	Royalty    Token
+
+	SellerNet Token
+}
+
+// feeCut returns the cut of amount the same way the atomicmarket contract
+// does, by multiplying as floating point and truncating the result.
+func feeCut(amount int64, fee float64) int64 {
+	return int64(float64(amount) * fee)
+}
+
+// Fees splits price using the market fees in config and the collection's market fee.
+func (c MarketConfig) Fees(price Token, collection Collection, maker, taker string) (FeeBreakdown, error) {
+	amount, err := price.Units()
+	if err != nil {
+		return FeeBreakdown{}, err
+	}
+
+	if amount < 0 {
+		return FeeBreakdown{}, fmt.Errorf("invalid price '%s'", price)
+	}
+
+	makerCut := feeCut(amount, c.MakerMarketFee)
+	takerCut := feeCut(amount, c.TakerMarketFee)
+	royalty := feeCut(amount, collection.MarketFee)
+
+	net := amount - makerCut - takerCut - royalty
+	if net < 0 {
+		return FeeBreakdown{}, fmt.Errorf("fees exceed price '%s'", price)
+	}
+
+	return FeeBreakdown{
+		Price:            price,
+		MakerMarketplace: maker,
+		MakerCut:         price.withUnits(makerCut),
+		TakerMarketplace: taker,
+		TakerCut:         price.withUnits(takerCut),
+		Collection:       collection.CollectionName,
+		Author:           collection.Author,
+		Royalty:          price.withUnits(royalty),
+		SellerNet:        price.withUnits(net),
+	}, nil
+}
+
+// listingCollection returns the collection with the fee stored on the listing.
+// The contract saves the collection fee when the listing is created, so a
+// later change of the collection's market fee does not apply to it.
+func listingCollection(c Collection, fee *float64) Collection {
+	if fee != nil {
+		c.MarketFee = *fee
+	}
+	return c
+}
+
+// SaleFees returns the fee breakdown for a sale.
+// Sales listed using delphi are converted to the settlement price first.
+// The collection fee of the sale is used, or the collection's market fee
+// if it is not set.
+func (c MarketConfig) SaleFees(s Sale) (FeeBreakdown, error) {
+	price, err := c.SettlementPrice(s, nil)
+	if err != nil {
+		return FeeBreakdown{}, err
+	}
+	return c.Fees(price, listingCollection(s.Collection, s.CollectionFee), s.MakerMarketplace, s.TakerMarketplace)
+}
+
+// AuctionFees returns the fee breakdown for an auction at its current price.
+// The collection fee is chosen the same way as in SaleFees.
+func (c MarketConfig) AuctionFees(a Auction) (FeeBreakdown, error) {
+	return c.Fees(a.Price, listingCollection(a.Collection, a.CollectionFee), a.MakerMarketplace, a.TakerMarketplace)
+}
+
+// BuyOfferFees returns the fee breakdown for a buyoffer.
+// The collection fee is chosen the same way as in SaleFees.
+func (c MarketConfig) BuyOfferFees(b BuyOffer) (FeeBreakdown, error) {
+	return c.Fees(b.Price, listingCollection(b.Collection, b.CollectionFee), b.MakerMarketplace, b.TakerMarketplace)
+}
@@ -0,0 +1,131 @@
+package atomicasset
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var feeTestConfig = MarketConfig{
+	MakerMarketFee:  0.01,
+	TakerMarketFee:  0.01,
+	SupportedTokens: delphiTestConfig.SupportedTokens,
+	SupportedPairs:  delphiTestConfig.SupportedPairs,
+}
+
+func waxTokenUnits(amount string) Token {
+	return Token{Contract: "eosio.token", Symbol: "WAX", Precision: 8, Amount: amount}
+}
+
+func TestMarketConfig_SaleFees(t *testing.T) {
+	fees, err := feeTestConfig.SaleFees(sale_100420714)
+	require.NoError(t, err)
+
+	assert.Equal(t, FeeBreakdown{
+		Price:            waxTokenUnits("50000000"),
+		MakerMarketplace: "nft.hive",
+		MakerCut:         waxTokenUnits("500000"),
+		TakerMarketplace: "",
+		TakerCut:         waxTokenUnits("500000"),
+		Collection:       "matrix.funko",
+		Author:           "matrix.funko",
+		Royalty:          waxTokenUnits("3000000"),
+		SellerNet:        waxTokenUnits("46000000"),
+	}, fees)
+}
+
+func TestMarketConfig_SaleFees_Delphi(t *testing.T) {
+	sale := Sale{
+		Price:         Token{Symbol: "WAX", Precision: 8},
+		ListingPrice:  "100",
+		ListingSymbol: "USD",
+		Collection:    Collection{MarketFee: 0.05},
+	}
+
+	fees, err := feeTestConfig.SaleFees(sale)
+	require.NoError(t, err)
+
+	assert.Equal(t, "1680672268", fees.Price.Amount)
+	assert.Equal(t, "16806722", fees.MakerCut.Amount)
+	assert.Equal(t, "16806722", fees.TakerCut.Amount)
+	assert.Equal(t, "84033613", fees.Royalty.Amount)
+	assert.Equal(t, "1563025211", fees.SellerNet.Amount)
+}
+
+func TestMarketConfig_AuctionFees(t *testing.T) {
+	auction := Auction{
+		Price:            waxTokenUnits("333"),
+		MakerMarketplace: "market.a",
+		TakerMarketplace: "market.b",
+		Collection:       Collection{CollectionName: "col", Author: "author", MarketFee: 0.06},
+	}
+
+	fees, err := feeTestConfig.AuctionFees(auction)
+	require.NoError(t, err)
+
+	// All cuts are truncated, the seller gets the remainder.
+	assert.Equal(t, "3", fees.MakerCut.Amount)
+	assert.Equal(t, "3", fees.TakerCut.Amount)
+	assert.Equal(t, "19", fees.Royalty.Amount)
+	assert.Equal(t, "308", fees.SellerNet.Amount)
+	assert.Equal(t, "0.00000308 WAX", fees.SellerNet.String())
+	assert.Equal(t, "market.a", fees.MakerMarketplace)
+	assert.Equal(t, "market.b", fees.TakerMarketplace)
+}
+
+func TestMarketConfig_BuyOfferFees(t *testing.T) {
+	offer := BuyOffer{
+		Price:      waxTokenUnits("100000000"),
+		Collection: Collection{MarketFee: 0.1},
+	}
+
+	fees, err := feeTestConfig.BuyOfferFees(offer)
+	require.NoError(t, err)
+	assert.Equal(t, "1000000", fees.MakerCut.Amount)
+	assert.Equal(t, "1000000", fees.TakerCut.Amount)
+	assert.Equal(t, "10000000", fees.Royalty.Amount)
+	assert.Equal(t, "88000000", fees.SellerNet.Amount)
+}
+
+func TestMarketConfig_Fees_CollectionFee(t *testing.T) {
+	fee, zero := 0.02, 0.0
+	collection := Collection{CollectionName: "col", MarketFee: 0.1}
+
+	// The fee stored on the listing is used over the current fee of the collection.
+	sale := Sale{Price: waxTokenUnits("100000000"), Collection: collection, CollectionFee: &fee}
+	fees, err := feeTestConfig.SaleFees(sale)
+	require.NoError(t, err)
+	assert.Equal(t, "2000000", fees.Royalty.Amount)
+	assert.Equal(t, "96000000", fees.SellerNet.Amount)
+
+	auction := Auction{Price: waxTokenUnits("100000000"), Collection: collection, CollectionFee: &zero}
+	fees, err = feeTestConfig.AuctionFees(auction)
+	require.NoError(t, err)
+	assert.Equal(t, "0", fees.Royalty.Amount)
+
+	offer := BuyOffer{Price: waxTokenUnits("100000000"), Collection: collection, CollectionFee: &fee}
+	fees, err = feeTestConfig.BuyOfferFees(offer)
+	require.NoError(t, err)
+	assert.Equal(t, "2000000", fees.Royalty.Amount)
+
+	var decoded Sale
+	require.NoError(t, json.Unmarshal([]byte(`{"collection_fee": 0.02}`), &decoded))
+	assert.Equal(t, &fee, decoded.CollectionFee)
+
+	decoded = Sale{}
+	require.NoError(t, json.Unmarshal([]byte(`{}`), &decoded))
+	assert.Nil(t, decoded.CollectionFee)
+}
+
+func TestMarketConfig_Fees_Errors(t *testing.T) {
+	_, err := feeTestConfig.Fees(Token{Amount: "abc"}, Collection{}, "", "")
+	assert.EqualError(t, err, "invalid token amount 'abc'")
+
+	_, err = feeTestConfig.Fees(waxTokenUnits("-1"), Collection{}, "", "")
+	assert.EqualError(t, err, "invalid price '-0.00000001 WAX'")
+
+	_, err = feeTestConfig.Fees(waxTokenUnits("100"), Collection{MarketFee: 1}, "", "")
+	assert.EqualError(t, err, "fees exceed price '0.00000100 WAX'")
+}
@@ -1,6 +1,12 @@
 package atomicasset
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
 	"github.com/eosswedenorg-go/unixtime"
 )
 
@@ -16,9 +22,54 @@ type RedisHealth struct {
 	Status string `json:"status"`
 }
 
+// ReaderState is the sync state of a postgres reader.
+type ReaderState string
+
+const (
+	ReaderLive       = ReaderState("live")
+	ReaderCatchingUp = ReaderState("catching_up")
+)
+
+// DefaultLiveBlockLag is the block lag at or below which a reader
+// that does not report its state is considered live.
+const DefaultLiveBlockLag int64 = 20
+
+// PostgresReader is a contract reader filling the database.
+type PostgresReader struct {
+	Name      string        `json:"name"`
+	BlockNum  int64         `json:"block_num"`
+	BlockTime unixtime.Time `json:"block_time"`
+
+	// Live is true if the reader reports that it follows the chain head.
+	Live bool `json:"live"`
+}
+
+// UnmarshalJSON accepts block_num as a string or a number.
+func (r *PostgresReader) UnmarshalJSON(b []byte) error {
+	type reader PostgresReader
+	var v struct {
+		reader
+		BlockNum json.Number `json:"block_num"`
+	}
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	*r = PostgresReader(v.reader)
+	if len(v.BlockNum) > 0 {
+		n, err := v.BlockNum.Int64()
+		if err != nil {
+			return fmt.Errorf("reader block_num: %s", err)
+		}
+		r.BlockNum = n
+	}
+	return nil
+}
+
 type PostgresHealth struct {
-	Status  string                   `json:"status"`
-	Readers []map[string]interface{} `json:"readers"`
+	Status  string           `json:"status"`
+	Readers []PostgresReader `json:"readers"`
 }
 
 type HealthData struct {
@@ -28,6 +79,107 @@ type HealthData struct {
 	Chain    ChainHealth    `json:"chain"`
 }
 
+// ReaderLag is how far a postgres reader is behind the chain head.
+type ReaderLag struct {
+	Name  string
+	State ReaderState
+
+	// Blocks behind the head block.
+	Blocks int64
+
+	// Time behind the head time, zero if the reader does not report its block time.
+	Time time.Duration
+}
+
+// Lag returns the lag of each postgres reader against the chain head.
+// Readers that do not report their state are live if they are at most
+// DefaultLiveBlockLag blocks behind.
+func (h HealthData) Lag() []ReaderLag {
+	lags := []ReaderLag{}
+	for _, r := range h.Postgres.Readers {
+		l := ReaderLag{
+			Name:   r.Name,
+			State:  ReaderCatchingUp,
+			Blocks: h.Chain.HeadBlock - r.BlockNum,
+		}
+
+		if l.Blocks < 0 {
+			l.Blocks = 0
+		}
+
+		if r.BlockTime > 0 && h.Chain.HeadTime > r.BlockTime {
+			l.Time = h.Chain.HeadTime.Time().Sub(r.BlockTime.Time())
+		}
+
+		if r.Live || l.Blocks <= DefaultLiveBlockLag {
+			l.State = ReaderLive
+		}
+		lags = append(lags, l)
+	}
+	return lags
+}
+
+// HealthThresholds are the limits used by HealthData.Healthy.
+// Zero values are not checked.
+type HealthThresholds struct {
+	// MaxBlockLag and MaxTimeLag are the maximum lag of any reader.
+	MaxBlockLag int64
+	MaxTimeLag  time.Duration
+
+	// IgnoreRedis does not require redis to be OK.
+	IgnoreRedis bool
+
+	// LiveBlockLag is the maximum lag of readers that do not report that
+	// they are live, readers behind it are catching up.
+	LiveBlockLag int64
+}
+
+// Healthy returns an error describing every problem if the services are
+// not OK or any reader lags more than the thresholds allow.
+func (h HealthData) Healthy(t HealthThresholds) error {
+	problems := []string{}
+
+	if h.Postgres.Status != "OK" {
+		problems = append(problems, fmt.Sprintf("postgres status is '%s'", h.Postgres.Status))
+	}
+
+	if !t.IgnoreRedis && h.Redis.Status != "OK" {
+		problems = append(problems, fmt.Sprintf("redis status is '%s'", h.Redis.Status))
+	}
+
+	if h.Chain.Status != "OK" {
+		problems = append(problems, fmt.Sprintf("chain status is '%s'", h.Chain.Status))
+	}
+
+	if (t.MaxBlockLag > 0 || t.MaxTimeLag > 0 || t.LiveBlockLag > 0) && len(h.Postgres.Readers) < 1 {
+		problems = append(problems, "no postgres readers")
+	}
+
+	for i, l := range h.Lag() {
+		name := l.Name
+		if len(name) < 1 {
+			name = fmt.Sprintf("#%d", i)
+		}
+
+		if t.LiveBlockLag > 0 && !h.Postgres.Readers[i].Live && l.Blocks > t.LiveBlockLag {
+			problems = append(problems, fmt.Sprintf("reader %s is catching up", name))
+		}
+
+		if t.MaxBlockLag > 0 && l.Blocks > t.MaxBlockLag {
+			problems = append(problems, fmt.Sprintf("reader %s is %d blocks behind", name, l.Blocks))
+		}
+
+		if t.MaxTimeLag > 0 && l.Time > t.MaxTimeLag {
+			problems = append(problems, fmt.Sprintf("reader %s is %s behind", name, l.Time))
+		}
+	}
+
+	if len(problems) > 0 {
+		return errors.New("unhealthy: " + strings.Join(problems, ", "))
+	}
+	return nil
+}
+
 // Responses
 
 type Health struct {
@@ -1,11 +1,13 @@
 package atomicasset
 
 import (
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 	"time"
 
+	"github.com/eosswedenorg-go/unixtime"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -61,6 +63,7 @@ func TestClient_GetHealth(t *testing.T) {
 
 	// Postgres
 	assert.Equal(t, "OK", h.Data.Postgres.Status)
+	assert.Equal(t, []PostgresReader{{BlockNum: 167836036}, {BlockNum: 167836034}}, h.Data.Postgres.Readers)
 
 	// Redis
 	assert.Equal(t, "OK", h.Data.Redis.Status)
@@ -115,6 +118,7 @@ func TestClient_GetHealthFailed(t *testing.T) {
 
 	// Postgres
 	assert.Equal(t, "ERROR", h.Data.Postgres.Status)
+	assert.Equal(t, []PostgresReader{}, h.Data.Postgres.Readers)
 
 	// Redis
 	assert.Equal(t, "ERROR", h.Data.Redis.Status)
@@ -125,3 +129,66 @@ func TestClient_GetHealthFailed(t *testing.T) {
 
 	assert.Equal(t, time.Unix(0, 0).UTC(), h.Data.Chain.HeadTime.Time())
 }
+
+func TestPostgresReader_UnmarshalJSON(t *testing.T) {
+	var readers []PostgresReader
+	err := json.Unmarshal([]byte(`[
+		{"name": "atomic-1", "block_num": "100", "block_time": "1645374771500", "live": true},
+		{"name": "atomic-2", "block_num": 90}
+	]`), &readers)
+	require.NoError(t, err)
+
+	assert.Equal(t, []PostgresReader{
+		{Name: "atomic-1", BlockNum: 100, BlockTime: unixtime.Time(1645374771500), Live: true},
+		{Name: "atomic-2", BlockNum: 90},
+	}, readers)
+
+	assert.Error(t, json.Unmarshal([]byte(`{"block_num": "1.5"}`), &readers[0]))
+}
+
+func TestHealthData_Lag(t *testing.T) {
+	h := HealthData{
+		Chain: ChainHealth{HeadBlock: 1000, HeadTime: unixtime.Time(1645374771500)},
+		Postgres: PostgresHealth{Readers: []PostgresReader{
+			{Name: "a", BlockNum: 1000, BlockTime: unixtime.Time(1645374771500)},
+			{Name: "b", BlockNum: 900, BlockTime: unixtime.Time(1645374721500)},
+			{Name: "c", BlockNum: 900, Live: true},
+			{Name: "d", BlockNum: 1001},
+		}},
+	}
+
+	assert.Equal(t, []ReaderLag{
+		{Name: "a", State: ReaderLive},
+		{Name: "b", State: ReaderCatchingUp, Blocks: 100, Time: 50 * time.Second},
+		{Name: "c", State: ReaderLive, Blocks: 100},
+		{Name: "d", State: ReaderLive},
+	}, h.Lag())
+}
+
+func TestHealthData_Healthy(t *testing.T) {
+	ok := HealthData{
+		Postgres: PostgresHealth{Status: "OK", Readers: []PostgresReader{
+			{Name: "a", BlockNum: 990, BlockTime: unixtime.Time(1645374766500)},
+		}},
+		Redis: RedisHealth{Status: "OK"},
+		Chain: ChainHealth{Status: "OK", HeadBlock: 1000, HeadTime: unixtime.Time(1645374771500)},
+	}
+
+	assert.NoError(t, ok.Healthy(HealthThresholds{}))
+	assert.NoError(t, ok.Healthy(HealthThresholds{MaxBlockLag: 10, MaxTimeLag: 5 * time.Second}))
+	assert.EqualError(t, ok.Healthy(HealthThresholds{MaxBlockLag: 5, MaxTimeLag: time.Second}),
+		"unhealthy: reader a is 10 blocks behind, reader a is 5s behind")
+
+	// Readers that report that they are live are not catching up.
+	live := ok
+	live.Postgres.Readers = []PostgresReader{{Name: "a", BlockNum: 990}, {Name: "b", BlockNum: 900, Live: true}, {Name: "c", BlockNum: 900}}
+	assert.NoError(t, live.Healthy(HealthThresholds{LiveBlockLag: 100}))
+	assert.EqualError(t, live.Healthy(HealthThresholds{LiveBlockLag: 10}), "unhealthy: reader c is catching up")
+
+	failed := ok
+	failed.Redis.Status = "ERROR"
+	failed.Postgres = PostgresHealth{Status: "OK"}
+
+	assert.EqualError(t, failed.Healthy(HealthThresholds{MaxBlockLag: 5}), "unhealthy: redis status is 'ERROR', no postgres readers")
+	assert.NoError(t, failed.Healthy(HealthThresholds{IgnoreRedis: true}))
+}
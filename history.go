@@ -0,0 +1,250 @@
+package atomicasset
+
+import (
+	"context"
+	"sort"
+	"strconv"
+
+	"github.com/eosswedenorg-go/unixtime"
+)
+
+// Types
+
+// AssetEventType is the kind of event in an asset's history.
+type AssetEventType string
+
+const (
+	AssetEventMint     = AssetEventType("mint")
+	AssetEventTransfer = AssetEventType("transfer")
+	AssetEventOffer    = AssetEventType("offer")
+	AssetEventSale     = AssetEventType("sale")
+	AssetEventAuction  = AssetEventType("auction")
+	AssetEventBuyOffer = AssetEventType("buyoffer")
+	AssetEventUpdate   = AssetEventType("update")
+	AssetEventBurn     = AssetEventType("burn")
+)
+
+// AssetEvent is a single event in an asset's history.
+//
+// From and To are the counterparties, for mints From is the minter and
+// for data updates and burns only From (the owner) is set.
+// Price is only set for sales, auctions and buyoffers.
+type AssetEvent struct {
+	Type AssetEventType
+
+	// ID of the transfer, offer, sale, auction or buyoffer. Empty for log events.
+	ID string
+
+	Block string
+	TxID  string
+	Time  unixtime.Time
+
+	From  string
+	To    string
+	Price *Token
+
+	// Log is the decoded log data for mint, update and burn events.
+	Log LogData
+}
+
+// AssetHistory fetches the logs, transfers, accepted trade offers and
+// sales of an asset and merges them into one chronological timeline.
+//
+// Transfers made by an accepted offer or a market trade are replaced by an
+// offer, sale, auction or buyoffer event that keeps the transfer's tx id.
+// Offers to a market contract are how sales are listed, they and their
+// transfer are covered by the sale event.
+// An error is returned if any of the logs can not be decoded.
+func (c *Client) AssetHistory(ctx context.Context, assetID string) ([]AssetEvent, error) {
+	c = c.WithContext(ctx)
+
+	id, err := strconv.Atoi(assetID)
+	if err != nil {
+		return nil, err
+	}
+
+	logs, err := fetchAll(ctx, func(page int) ([]Log, error) {
+		resp, err := c.GetAssetLog(assetID, LogRequestParams{Page: page, Limit: pageLimit, Order: SortAscending})
+		return resp.Data, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	transfers, err := fetchAll(ctx, func(page int) ([]Transfer, error) {
+		resp, err := c.GetTransfers(TransferRequestParams{AssetID: ReqList[int]{id}, Page: page, Limit: pageLimit, Order: SortAscending})
+		return resp.Data, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	offers, err := fetchAll(ctx, func(page int) ([]Offer, error) {
+		resp, err := c.GetOffers(OfferRequestParams{AssetID: ReqList[int]{id}, State: OfferStateAccepted, Page: page, Limit: pageLimit, Order: SortAscending})
+		return resp.Data, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// The sales endpoint is not paged and returns every sale.
+	sales, err := c.GetAssetSales(assetID, AssetSalesRequestParams{Order: SortAscending})
+	if err != nil {
+		return nil, err
+	}
+
+	return mergeAssetHistory(logs, transfers, offers, sales.Data)
+}
+
+// mergeAssetHistory builds the timeline from the different sources.
+func mergeAssetHistory(logs []Log, transfers []Transfer, offers []Offer, sales []AssetSale) ([]AssetEvent, error) {
+	events := []AssetEvent{}
+
+	for _, l := range logs {
+		e, ok, err := logEvent(l)
+		if err != nil {
+			return nil, err
+		}
+
+		if ok {
+			events = append(events, e)
+		}
+	}
+
+	markets := map[string]bool{}
+	for _, s := range sales {
+		markets[s.MarketContract] = true
+	}
+
+	matched := map[int]bool{}
+
+	// Offers are matched to the transfer in the block they were accepted in.
+	for _, o := range offers {
+		e := AssetEvent{
+			Type:  AssetEventOffer,
+			ID:    o.ID,
+			Block: o.UpdatedAtBlock,
+			Time:  o.UpdatedAtTime,
+			From:  o.Sender,
+			To:    o.Recipient,
+		}
+
+		for i, t := range transfers {
+			if !matched[i] && t.CreatedAtBlock == o.UpdatedAtBlock &&
+				((t.Sender == o.Sender && t.Recipient == o.Recipient) || (t.Sender == o.Recipient && t.Recipient == o.Sender)) {
+				matched[i] = true
+				e.From, e.To, e.TxID = t.Sender, t.Recipient, t.TxID
+				break
+			}
+		}
+
+		if !markets[o.Recipient] {
+			events = append(events, e)
+		}
+	}
+
+	// Sales are matched to the transfer to the buyer at the same time.
+	for _, s := range sales {
+		price := Token{
+			Contract:  s.TokenContract,
+			Symbol:    s.TokenSymbol,
+			Precision: int(s.TokenPrecision),
+			Amount:    s.Price,
+		}
+
+		e := AssetEvent{
+			Type:  AssetEventSale,
+			ID:    s.ID,
+			Time:  s.BlockTime,
+			From:  s.Seller,
+			To:    s.Buyer,
+			Price: &price,
+		}
+
+		if len(s.AuctionID) > 0 {
+			e.Type, e.ID = AssetEventAuction, s.AuctionID
+		} else if len(s.BuyOfferID) > 0 {
+			e.Type, e.ID = AssetEventBuyOffer, s.BuyOfferID
+		}
+
+		for i, t := range transfers {
+			if !matched[i] && t.CreatedAtTime == s.BlockTime && t.Recipient == s.Buyer {
+				matched[i] = true
+				e.Block, e.TxID = t.CreatedAtBlock, t.TxID
+				break
+			}
+		}
+		events = append(events, e)
+	}
+
+	for i, t := range transfers {
+		if matched[i] {
+			continue
+		}
+
+		events = append(events, AssetEvent{
+			Type:  AssetEventTransfer,
+			ID:    t.ID,
+			Block: t.CreatedAtBlock,
+			TxID:  t.TxID,
+			Time:  t.CreatedAtTime,
+			From:  t.Sender,
+			To:    t.Recipient,
+		})
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		a, b := events[i], events[j]
+		if a.Time != b.Time {
+			return a.Time < b.Time
+		}
+
+		// Events without a block number (unmatched sales) keep their order.
+		ab, aerr := strconv.ParseUint(a.Block, 10, 64)
+		bb, berr := strconv.ParseUint(b.Block, 10, 64)
+		if aerr == nil && berr == nil && ab != bb {
+			return ab < bb
+		}
+		return assetEventOrder(a.Type) < assetEventOrder(b.Type)
+	})
+	return events, nil
+}
+
+// assetEventOrder orders events that happen in the same block.
+func assetEventOrder(t AssetEventType) int {
+	switch t {
+	case AssetEventMint:
+		return 0
+	case AssetEventBurn:
+		return 2
+	}
+	return 1
+}
+
+// logEvent converts mint, data update and burn logs to events.
+// Other logs are skipped.
+func logEvent(l Log) (AssetEvent, bool, error) {
+	e := AssetEvent{
+		Block: l.CreatedAtBlock,
+		TxID:  l.TxID,
+		Time:  l.CreatedAtTime,
+	}
+
+	data, err := l.Decode()
+	if err != nil {
+		return e, false, err
+	}
+	e.Log = data
+
+	switch d := data.(type) {
+	case *LogMintData:
+		e.Type, e.From, e.To = AssetEventMint, d.AuthorizedMinter, d.NewAssetOwner
+	case *LogSetDataData:
+		e.Type, e.From = AssetEventUpdate, d.AssetOwner
+	case *LogBurnAssetData:
+		e.Type, e.From = AssetEventBurn, d.AssetOwner
+	default:
+		return e, false, nil
+	}
+	return e, true, nil
+}
@@ -0,0 +1,177 @@
+package atomicasset
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/eosswedenorg-go/unixtime"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_AssetHistory(t *testing.T) {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/atomicassets/v1/assets/1099511627776/logs", func(res http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "1", req.URL.Query().Get("page"))
+		assert.Equal(t, "asc", req.URL.Query().Get("order"))
+
+		res.Header().Add("Content-type", "application/json; charset=utf-8")
+		_, err := res.Write([]byte(`{
+			"success": true,
+			"data": [
+				{
+					"log_id": "1", "txid": "tx_mint", "name": "logmint",
+					"data": {"asset_id": "1099511627776", "authorized_minter": "minter", "new_asset_owner": "alice"},
+					"created_at_block": "100", "created_at_time": "1000000"
+				},
+				{
+					"log_id": "2", "txid": "tx_update", "name": "logsetdata",
+					"data": {"asset_id": "1099511627776", "asset_owner": "bob", "old_data": [], "new_data": []},
+					"created_at_block": "400", "created_at_time": "4000000"
+				},
+				{
+					"log_id": "3", "txid": "tx_burn", "name": "logburnasset",
+					"data": {"asset_id": "1099511627776", "asset_owner": "dave"},
+					"created_at_block": "600", "created_at_time": "6000000"
+				}
+			],
+			"query_time": 1000000
+		}`))
+		assert.NoError(t, err)
+	})
+
+	mux.HandleFunc("/atomicassets/v1/transfers", func(res http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "1099511627776", req.URL.Query().Get("asset_id"))
+
+		res.Header().Add("Content-type", "application/json; charset=utf-8")
+		_, err := res.Write([]byte(`{
+			"success": true,
+			"data": [
+				{
+					"transfer_id": "1", "sender_name": "alice", "recipient_name": "bob", "txid": "tx_transfer",
+					"created_at_block": "200", "created_at_time": "2000000"
+				},
+				{
+					"transfer_id": "2", "sender_name": "bob", "recipient_name": "atomicmarket", "txid": "tx_offer",
+					"created_at_block": "300", "created_at_time": "3000000"
+				},
+				{
+					"transfer_id": "3", "sender_name": "atomicmarket", "recipient_name": "carol", "txid": "tx_sale",
+					"created_at_block": "500", "created_at_time": "5000000"
+				},
+				{
+					"transfer_id": "4", "sender_name": "carol", "recipient_name": "dave", "txid": "tx_trade",
+					"created_at_block": "550", "created_at_time": "5500000"
+				}
+			],
+			"query_time": 1000000
+		}`))
+		assert.NoError(t, err)
+	})
+
+	mux.HandleFunc("/atomicassets/v1/offers", func(res http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "1099511627776", req.URL.Query().Get("asset_id"))
+		assert.Equal(t, "3", req.URL.Query().Get("state"))
+
+		res.Header().Add("Content-type", "application/json; charset=utf-8")
+		_, err := res.Write([]byte(`{
+			"success": true,
+			"data": [
+				{
+					"offer_id": "10", "sender_name": "bob", "recipient_name": "atomicmarket", "state": 3,
+					"updated_at_block": "300", "updated_at_time": "3000000",
+					"created_at_block": "250", "created_at_time": "2500000"
+				},
+				{
+					"offer_id": "11", "sender_name": "dave", "recipient_name": "carol", "state": 3,
+					"updated_at_block": "550", "updated_at_time": "5500000",
+					"created_at_block": "540", "created_at_time": "5400000"
+				}
+			],
+			"query_time": 1000000
+		}`))
+		assert.NoError(t, err)
+	})
+
+	mux.HandleFunc("/atomicmarket/v1/assets/1099511627776/sales", func(res http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "", req.URL.Query().Get("page"))
+		assert.Equal(t, "asc", req.URL.Query().Get("order"))
+
+		res.Header().Add("Content-type", "application/json; charset=utf-8")
+		_, err := res.Write([]byte(`{
+			"success": true,
+			"data": [
+				{
+					"sale_id": "20", "market_contract": "atomicmarket",
+					"price": "100000000", "token_symbol": "WAX", "token_precision": 8, "token_contract": "eosio.token",
+					"seller": "bob", "buyer": "carol", "block_time": "5000000"
+				}
+			],
+			"query_time": 1000000
+		}`))
+		assert.NoError(t, err)
+	})
+
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	events, err := New(srv.URL).AssetHistory(context.Background(), "1099511627776")
+	require.NoError(t, err)
+
+	// Clear decoded logs for easier comparison.
+	logs := []LogData{}
+	for i := range events {
+		if events[i].Log != nil {
+			logs = append(logs, events[i].Log)
+			events[i].Log = nil
+		}
+	}
+	require.Len(t, logs, 3)
+	assert.Equal(t, "logmint", logs[0].Action())
+
+	assert.Equal(t, []AssetEvent{
+		{Type: AssetEventMint, Block: "100", TxID: "tx_mint", Time: unixtime.Time(1000000), From: "minter", To: "alice"},
+		{Type: AssetEventTransfer, ID: "1", Block: "200", TxID: "tx_transfer", Time: unixtime.Time(2000000), From: "alice", To: "bob"},
+		{Type: AssetEventUpdate, Block: "400", TxID: "tx_update", Time: unixtime.Time(4000000), From: "bob"},
+		{
+			Type: AssetEventSale, ID: "20", Block: "500", TxID: "tx_sale", Time: unixtime.Time(5000000), From: "bob", To: "carol",
+			Price: &Token{Contract: "eosio.token", Symbol: "WAX", Precision: 8, Amount: "100000000"},
+		},
+		{Type: AssetEventOffer, ID: "11", Block: "550", TxID: "tx_trade", Time: unixtime.Time(5500000), From: "carol", To: "dave"},
+		{Type: AssetEventBurn, Block: "600", TxID: "tx_burn", Time: unixtime.Time(6000000), From: "dave"},
+	}, events)
+}
+
+func TestMergeAssetHistory_AuctionAndBuyOffer(t *testing.T) {
+	sales := []AssetSale{
+		{ID: "", AuctionID: "5", Price: "10", Seller: "a", Buyer: "b", BlockTime: unixtime.Time(1000)},
+		{ID: "", BuyOfferID: "7", Price: "20", Seller: "b", Buyer: "c", BlockTime: unixtime.Time(2000)},
+	}
+
+	events, err := mergeAssetHistory(nil, nil, nil, sales)
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	assert.Equal(t, AssetEventAuction, events[0].Type)
+	assert.Equal(t, "5", events[0].ID)
+	assert.Equal(t, AssetEventBuyOffer, events[1].Type)
+	assert.Equal(t, "7", events[1].ID)
+	assert.Equal(t, "20", events[1].Price.Amount)
+}
+
+func TestMergeAssetHistory_InvalidLog(t *testing.T) {
+	logs := []Log{
+		{ID: "1", Name: "logmint", Data: map[string]interface{}{"authorized_minter": "minter", "new_asset_owner": "alice"}},
+		{ID: "2", Name: "logburnasset", Data: map[string]interface{}{"asset_owner": float64(1)}},
+	}
+
+	_, err := mergeAssetHistory(logs, nil, nil, nil)
+	assert.ErrorContains(t, err, "log 'logburnasset'")
+}
+
+func TestClient_AssetHistory_InvalidID(t *testing.T) {
+	_, err := New("http://127.0.0.1").AssetHistory(context.Background(), "abc")
+	assert.Error(t, err)
+}
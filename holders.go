@@ -0,0 +1,219 @@
+package atomicasset
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// Types
+
+// DefaultHolderBlacklist are the market and tool contracts that hold
+// assets on behalf of other accounts.
+var DefaultHolderBlacklist = []string{
+	"atomicmarket",
+	"atomicdropsx",
+	"atomicpacksx",
+	"atomictoolsx",
+}
+
+// HolderFilter selects the assets included in a holder snapshot.
+type HolderFilter struct {
+	CollectionName string
+	SchemaName     string
+	TemplateID     int
+
+	// Blacklist are accounts that are excluded from the snapshot.
+	// DefaultHolderBlacklist is used if nil.
+	Blacklist []string
+
+	// WeightAttribute weights each asset by the numeric value of this
+	// data attribute instead of by count. Assets without it weigh 0.
+	WeightAttribute string
+}
+
+// Holder is an account and the assets it holds in a snapshot.
+type Holder struct {
+	Account string  `json:"account"`
+	Assets  int     `json:"assets"`
+	Weight  float64 `json:"weight"`
+}
+
+// HolderSnapshot is the holders of a collection, schema or template
+// sorted by weight (highest first) and then by account name.
+type HolderSnapshot struct {
+	// Block and Time is the chain head the snapshot is pinned to.
+	// Assets minted after Block are not included.
+	Block int64     `json:"block"`
+	Time  time.Time `json:"time"`
+
+	Holders     []Holder `json:"holders"`
+	TotalAssets int      `json:"total_assets"`
+	TotalWeight float64  `json:"total_weight"`
+
+	// Moved are the ids of assets transferred after Block while the
+	// snapshot was taken, they are counted for their current owner.
+	Moved []string `json:"moved"`
+}
+
+// HolderSnapshot fetches all assets matching filter and counts them per owner.
+//
+// Burned assets, assets in open trade offers and assets held by
+// blacklisted accounts are excluded.
+func (c *Client) HolderSnapshot(ctx context.Context, filter HolderFilter) (HolderSnapshot, error) {
+	c = c.WithContext(ctx)
+
+	if len(filter.CollectionName) < 1 && len(filter.SchemaName) < 1 && filter.TemplateID < 1 {
+		return HolderSnapshot{}, errors.New("holder snapshot requires a collection, schema or template")
+	}
+
+	health, err := c.GetHealth()
+	if err != nil {
+		return HolderSnapshot{}, err
+	}
+
+	block := health.Data.Chain.HeadBlock
+	head := health.Data.Chain.HeadTime
+	if block < 1 {
+		return HolderSnapshot{}, errors.New("holder snapshot requires the chain head block")
+	}
+
+	assets, err := fetchAll(ctx, func(page int) ([]Asset, error) {
+		resp, err := c.GetAssets(AssetsRequestParams{
+			CollectionName: filter.CollectionName,
+			SchemaName:     filter.SchemaName,
+			TemplateID:     filter.TemplateID,
+			HideOffers:     true,
+			Before:         int(head),
+			Page:           page,
+			Limit:          pageLimit,
+			Order:          SortAscending,
+			Sort:           AssetSortID,
+		})
+		return resp.Data, err
+	})
+	if err != nil {
+		return HolderSnapshot{}, err
+	}
+
+	s, err := snapshotHolders(assets, block, filter)
+	s.Time = head.Time()
+	return s, err
+}
+
+// snapshotHolders counts and weights assets per owner.
+func snapshotHolders(assets []Asset, block int64, filter HolderFilter) (HolderSnapshot, error) {
+	blacklist := filter.Blacklist
+	if blacklist == nil {
+		blacklist = DefaultHolderBlacklist
+	}
+
+	excluded := map[string]bool{}
+	for _, account := range blacklist {
+		excluded[account] = true
+	}
+
+	s := HolderSnapshot{Block: block, Holders: []Holder{}, Moved: []string{}}
+	index := map[string]int{}
+	seen := map[string]bool{}
+
+	for _, a := range assets {
+		// Pages can overlap if assets are burned while paginating.
+		if seen[a.ID] {
+			continue
+		}
+		seen[a.ID] = true
+
+		if len(a.Owner) < 1 || len(a.BurnedAtBlock) > 0 || excluded[a.Owner] {
+			continue
+		}
+
+		if n, err := strconv.ParseInt(a.MintedAtBlock, 10, 64); err == nil && n > block {
+			continue
+		}
+
+		weight := 1.0
+		if len(filter.WeightAttribute) > 0 {
+			w, err := attributeWeight(a.Data[filter.WeightAttribute])
+			if err != nil {
+				return HolderSnapshot{}, fmt.Errorf("asset %s attribute '%s': %s", a.ID, filter.WeightAttribute, err)
+			}
+			weight = w
+		}
+
+		if n, err := strconv.ParseInt(a.TransferedAtBlock, 10, 64); err == nil && n > block {
+			s.Moved = append(s.Moved, a.ID)
+		}
+
+		i, ok := index[a.Owner]
+		if !ok {
+			i = len(s.Holders)
+			index[a.Owner] = i
+			s.Holders = append(s.Holders, Holder{Account: a.Owner})
+		}
+
+		s.Holders[i].Assets++
+		s.Holders[i].Weight += weight
+		s.TotalAssets++
+		s.TotalWeight += weight
+	}
+
+	sort.Slice(s.Holders, func(i, j int) bool {
+		a, b := s.Holders[i], s.Holders[j]
+		if a.Weight != b.Weight {
+			return a.Weight > b.Weight
+		}
+		return a.Account < b.Account
+	})
+	return s, nil
+}
+
+// attributeWeight converts a decoded data attribute to a weight.
+func attributeWeight(v interface{}) (float64, error) {
+	switch v := v.(type) {
+	case nil:
+		return 0, nil
+	case float64:
+		return v, nil
+	case json.Number:
+		return v.Float64()
+	case string:
+		return strconv.ParseFloat(v, 64)
+	}
+	return 0, fmt.Errorf("not a number (%T)", v)
+}
+
+// WriteCSV writes the holders as csv with an "account,assets,weight" header.
+func (s HolderSnapshot) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"account", "assets", "weight"}); err != nil {
+		return err
+	}
+
+	for _, h := range s.Holders {
+		err := cw.Write([]string{
+			h.Account,
+			strconv.Itoa(h.Assets),
+			strconv.FormatFloat(h.Weight, 'f', -1, 64),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// WriteJSON writes the snapshot as indented json.
+func (s HolderSnapshot) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(s)
+}
@@ -0,0 +1,124 @@
+package atomicasset
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_HolderSnapshot(t *testing.T) {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/health", func(res http.ResponseWriter, req *http.Request) {
+		res.Header().Add("Content-type", "application/json; charset=utf-8")
+		_, err := res.Write([]byte(`{"success": true, "data": {
+			"chain": {"status": "OK", "head_block": 1000, "head_time": 1645374771500}
+		}, "query_time": 1645374772067}`))
+		assert.NoError(t, err)
+	})
+
+	mux.HandleFunc("/atomicassets/v1/assets", func(res http.ResponseWriter, req *http.Request) {
+		q := req.URL.Query()
+		assert.Equal(t, "mycollection", q.Get("collection_name"))
+		assert.Equal(t, "heroes", q.Get("schema_name"))
+		assert.Equal(t, "true", q.Get("hide_offers"))
+		assert.Equal(t, "1645374771500", q.Get("before"))
+		assert.Equal(t, "asset_id", q.Get("sort"))
+		assert.Equal(t, "asc", q.Get("order"))
+
+		res.Header().Add("Content-type", "application/json; charset=utf-8")
+		_, err := res.Write([]byte(`{"success": true, "data": [
+			{"asset_id": "1", "owner": "bob", "minted_at_block": "10", "transferred_at_block": "20", "data": {"power": "5"}},
+			{"asset_id": "2", "owner": "alice", "minted_at_block": "10", "transferred_at_block": "1001", "data": {"power": 10}},
+			{"asset_id": "3", "owner": "bob", "minted_at_block": "11", "data": {"power": 5}},
+			{"asset_id": "4", "owner": null, "burned_by_account": "carol", "burned_at_block": "500", "minted_at_block": "12"},
+			{"asset_id": "5", "owner": "atomicmarket", "minted_at_block": "13", "data": {"power": 100}},
+			{"asset_id": "6", "owner": "carol", "minted_at_block": "1001", "data": {"power": 100}},
+			{"asset_id": "7", "owner": "dave", "minted_at_block": "14"}
+		], "query_time": 1645374772067}`))
+		assert.NoError(t, err)
+	})
+
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	client := New(srv.URL)
+	filter := HolderFilter{CollectionName: "mycollection", SchemaName: "heroes"}
+
+	s, err := client.HolderSnapshot(context.Background(), filter)
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(1000), s.Block)
+	assert.Equal(t, time.Date(2022, time.February, 20, 16, 32, 51, int(time.Millisecond)*500, time.UTC), s.Time)
+	assert.Equal(t, []Holder{
+		{Account: "bob", Assets: 2, Weight: 2},
+		{Account: "alice", Assets: 1, Weight: 1},
+		{Account: "dave", Assets: 1, Weight: 1},
+	}, s.Holders)
+	assert.Equal(t, 4, s.TotalAssets)
+	assert.Equal(t, 4.0, s.TotalWeight)
+	assert.Equal(t, []string{"2"}, s.Moved)
+
+	// Weighted by attribute, with an empty blacklist.
+	filter.WeightAttribute = "power"
+	filter.Blacklist = []string{}
+
+	s, err = client.HolderSnapshot(context.Background(), filter)
+	require.NoError(t, err)
+
+	assert.Equal(t, []Holder{
+		{Account: "atomicmarket", Assets: 1, Weight: 100},
+		{Account: "alice", Assets: 1, Weight: 10},
+		{Account: "bob", Assets: 2, Weight: 10},
+		{Account: "dave", Assets: 1, Weight: 0},
+	}, s.Holders)
+	assert.Equal(t, 120.0, s.TotalWeight)
+}
+
+func TestClient_HolderSnapshot_Errors(t *testing.T) {
+	_, err := New("http://localhost").HolderSnapshot(context.Background(), HolderFilter{})
+	assert.EqualError(t, err, "holder snapshot requires a collection, schema or template")
+
+	_, err = snapshotHolders([]Asset{
+		{ID: "1", Owner: "bob", Data: map[string]interface{}{"power": true}},
+	}, 1, HolderFilter{WeightAttribute: "power"})
+	assert.EqualError(t, err, "asset 1 attribute 'power': not a number (bool)")
+}
+
+func TestHolderSnapshot_Export(t *testing.T) {
+	s := HolderSnapshot{
+		Block: 1000,
+		Time:  time.Date(2022, time.February, 20, 16, 32, 51, 0, time.UTC),
+		Holders: []Holder{
+			{Account: "bob", Assets: 2, Weight: 2.5},
+			{Account: "alice", Assets: 1, Weight: 1},
+		},
+		TotalAssets: 3,
+		TotalWeight: 3.5,
+		Moved:       []string{},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, s.WriteCSV(&buf))
+	assert.Equal(t, "account,assets,weight\nbob,2,2.5\nalice,1,1\n", buf.String())
+
+	buf.Reset()
+	require.NoError(t, s.WriteJSON(&buf))
+	assert.JSONEq(t, `{
+		"block": 1000,
+		"time": "2022-02-20T16:32:51Z",
+		"holders": [
+			{"account": "bob", "assets": 2, "weight": 2.5},
+			{"account": "alice", "assets": 1, "weight": 1}
+		],
+		"total_assets": 3,
+		"total_weight": 3.5,
+		"moved": []
+	}`, buf.String())
+}
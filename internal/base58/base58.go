@@ -0,0 +1,72 @@
+// Package base58 implements base58 encoding using the bitcoin alphabet.
+package base58
+
+import (
+	"errors"
+	"math/big"
+)
+
+const alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+var decodeMap [256]int
+
+func init() {
+	for i := range decodeMap {
+		decodeMap[i] = -1
+	}
+	for i := 0; i < len(alphabet); i++ {
+		decodeMap[alphabet[i]] = i
+	}
+}
+
+// ErrInvalidCharacter is returned when decoding a string
+// containing characters that are not part of the alphabet.
+var ErrInvalidCharacter = errors.New("base58: invalid character")
+
+// Encode encodes b as a base58 string.
+func Encode(b []byte) string {
+	n := new(big.Int).SetBytes(b)
+	radix := big.NewInt(58)
+	mod := new(big.Int)
+
+	out := []byte{}
+	for n.Sign() > 0 {
+		n.DivMod(n, radix, mod)
+		out = append(out, alphabet[mod.Int64()])
+	}
+
+	// Leading zero bytes are encoded as the first character in the alphabet.
+	for _, c := range b {
+		if c != 0 {
+			break
+		}
+		out = append(out, alphabet[0])
+	}
+
+	for i, j := 0, len(out)-1; i < j; i, j = i+1, j-1 {
+		out[i], out[j] = out[j], out[i]
+	}
+	return string(out)
+}
+
+// Decode decodes a base58 string.
+func Decode(s string) ([]byte, error) {
+	n := new(big.Int)
+	radix := big.NewInt(58)
+
+	zeros := 0
+	for zeros < len(s) && s[zeros] == alphabet[0] {
+		zeros++
+	}
+
+	for i := 0; i < len(s); i++ {
+		d := decodeMap[s[i]]
+		if d < 0 {
+			return nil, ErrInvalidCharacter
+		}
+		n.Mul(n, radix)
+		n.Add(n, big.NewInt(int64(d)))
+	}
+
+	return append(make([]byte, zeros), n.Bytes()...), nil
+}
@@ -0,0 +1,41 @@
+package base58
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncodeDecode(t *testing.T) {
+	tests := []struct {
+		name    string
+		hex     string
+		encoded string
+	}{
+		{"Empty", "", ""},
+		{"Zero", "00", "1"},
+		{"LeadingZeros", "00000102", "115T"},
+		{"HelloWorld", "48656c6c6f20576f726c6421", "2NEpo7TZRRrLZSi2U"},
+		{"CIDv0", "12207d5a99f603f231d53a4f39d1521f98d2e8bb279cf29bebfd0687dc98458e7f89", "QmWmyoMoctfbAaiEs2G46gpeUmhqFRDW6KWo64y5r581Vz"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := hex.DecodeString(tt.hex)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.encoded, Encode(b))
+
+			decoded, err := Decode(tt.encoded)
+			require.NoError(t, err)
+			assert.Equal(t, tt.hex, hex.EncodeToString(decoded))
+		})
+	}
+}
+
+func TestDecode_Invalid(t *testing.T) {
+	_, err := Decode("Qm0OIl")
+	assert.ErrorIs(t, err, ErrInvalidCharacter)
+}
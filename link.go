@@ -2,6 +2,7 @@ package atomicasset
 
 import (
 	"fmt"
+	"strconv"
 
 	"github.com/eosswedenorg-go/unixtime"
 )
@@ -17,6 +18,40 @@ const (
 	LinkStateClaimed  = LinkState(3)
 )
 
+var linkStateNames = []string{"waiting", "created", "canceled", "claimed"}
+
+// String returns the name of the state.
+func (s LinkState) String() string {
+	return stateString("LinkState", strconv.Itoa(int(s)), linkStateNames)
+}
+
+// Validate returns an error if s is not a known link state.
+func (s LinkState) Validate() error {
+	return validateState("link state", strconv.Itoa(int(s)), linkStateNames)
+}
+
+// EncodeParam encodes the state as its numeric code in request parameters.
+func (s LinkState) EncodeParam() (string, error) {
+	return strconv.Itoa(int(s)), nil
+}
+
+func (s LinkState) MarshalJSON() ([]byte, error) {
+	return marshalState(strconv.Itoa(int(s)))
+}
+
+func (s *LinkState) UnmarshalJSON(b []byte) error {
+	v, err := unmarshalState(b)
+	if err != nil || len(v) < 1 {
+		return err
+	}
+
+	n, err := strconv.Atoi(v)
+	if err == nil {
+		*s = LinkState(n)
+	}
+	return err
+}
+
 type Link struct {
 	ID             string    `json:"link_id"`
 	ToolsContract  string    `json:"tools_contract"`
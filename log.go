@@ -0,0 +1,718 @@
+package atomicasset
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Types
+
+// LogData is implemented by the typed data of every known log action.
+type LogData interface {
+	// Action returns the name of the contract action.
+	Action() string
+}
+
+// UnknownLogData holds the data of actions that have no typed struct.
+type UnknownLogData struct {
+	Name string
+	Data map[string]interface{}
+}
+
+func (d *UnknownLogData) Action() string { return d.Name }
+
+// ActionAttributes is an attribute map as passed to contract actions.
+//
+// Both the ABI form ([{"key": "name", "value": ["string", "value"]}])
+// and plain json objects are accepted when decoding.
+type ActionAttributes map[string]interface{}
+
+func (a *ActionAttributes) UnmarshalJSON(b []byte) error {
+	obj := map[string]interface{}{}
+	if json.Unmarshal(b, &obj) == nil {
+		*a = obj
+		return nil
+	}
+
+	var pairs []struct {
+		Key   string            `json:"key"`
+		Value []json.RawMessage `json:"value"`
+	}
+	if err := json.Unmarshal(b, &pairs); err != nil {
+		return err
+	}
+
+	attrs := ActionAttributes{}
+	for _, p := range pairs {
+		if len(p.Value) != 2 {
+			return fmt.Errorf("attribute '%s': expected [type, value] pair", p.Key)
+		}
+
+		var v interface{}
+		if err := json.Unmarshal(p.Value[1], &v); err != nil {
+			return err
+		}
+		attrs[p.Key] = v
+	}
+	*a = attrs
+	return nil
+}
+
+// Decoding
+
+// Decode returns the typed data for the log's action.
+//
+// The API only includes some of the action's fields in the log data, fields
+// that are not present are left at their zero value. Actions without a typed
+// struct are returned as *UnknownLogData.
+func (l Log) Decode() (LogData, error) {
+	ctor, ok := logDataTypes[l.Name]
+	if !ok {
+		return &UnknownLogData{Name: l.Name, Data: l.Data}, nil
+	}
+
+	data := ctor()
+	b, err := json.Marshal(l.Data)
+	if err == nil {
+		err = json.Unmarshal(b, data)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("log '%s': %s", l.Name, err)
+	}
+	return data, nil
+}
+
+var logDataTypes = map[string]func() LogData{
+	// atomicassets
+	"admincoledit": func() LogData { return &AdminColEditData{} },
+	"setversion":   func() LogData { return &SetVersionData{} },
+	"addconftoken": func() LogData { return &AddConfTokenData{} },
+	"transfer":     func() LogData { return &TransferData{} },
+	"createcol":    func() LogData { return &CreateColData{} },
+	"setcoldata":   func() LogData { return &SetColDataData{} },
+	"addcolauth":   func() LogData { return &AddColAuthData{} },
+	"remcolauth":   func() LogData { return &RemColAuthData{} },
+	"addnotifyacc": func() LogData { return &AddNotifyAccData{} },
+	"remnotifyacc": func() LogData { return &RemNotifyAccData{} },
+	"setmarketfee": func() LogData { return &SetMarketFeeData{} },
+	"forbidnotify": func() LogData { return &ForbidNotifyData{} },
+	"createschema": func() LogData { return &CreateSchemaData{} },
+	"extendschema": func() LogData { return &ExtendSchemaData{} },
+	"createtempl":  func() LogData { return &CreateTemplData{} },
+	"locktemplate": func() LogData { return &LockTemplateData{} },
+	"mintasset":    func() LogData { return &MintAssetData{} },
+	"setassetdata": func() LogData { return &SetAssetDataData{} },
+	"announcedepo": func() LogData { return &AnnounceDepoData{} },
+	"withdraw":     func() LogData { return &WithdrawData{} },
+	"backasset":    func() LogData { return &BackAssetData{} },
+	"burnasset":    func() LogData { return &BurnAssetData{} },
+	"createoffer":  func() LogData { return &CreateOfferData{} },
+	"canceloffer":  func() LogData { return &CancelOfferData{} },
+	"acceptoffer":  func() LogData { return &AcceptOfferData{} },
+	"declineoffer": func() LogData { return &DeclineOfferData{} },
+	"payofferram":  func() LogData { return &PayOfferRAMData{} },
+	"logtransfer":  func() LogData { return &LogTransferData{} },
+	"lognewoffer":  func() LogData { return &LogNewOfferData{} },
+	"lognewtempl":  func() LogData { return &LogNewTemplData{} },
+	"logmint":      func() LogData { return &LogMintData{} },
+	"logsetdata":   func() LogData { return &LogSetDataData{} },
+	"logbackasset": func() LogData { return &LogBackAssetData{} },
+	"logburnasset": func() LogData { return &LogBurnAssetData{} },
+	"announcesale": func() LogData { return &AnnounceSaleData{} },
+	"cancelsale":   func() LogData { return &CancelSaleData{} },
+	"purchasesale": func() LogData { return &PurchaseSaleData{} },
+	"assertsale":   func() LogData { return &AssertSaleData{} },
+	"announceauct": func() LogData { return &AnnounceAuctData{} },
+	"cancelauct":   func() LogData { return &CancelAuctData{} },
+	"auctionbid":   func() LogData { return &AuctionBidData{} },
+	"auctclaimbuy": func() LogData { return &AuctClaimBuyData{} },
+	"auctclaimsel": func() LogData { return &AuctClaimSelData{} },
+	"assertauct":   func() LogData { return &AssertAuctData{} },
+	"createbuyo":   func() LogData { return &CreateBuyoData{} },
+	"cancelbuyo":   func() LogData { return &CancelBuyoData{} },
+	"acceptbuyo":   func() LogData { return &AcceptBuyoData{} },
+	"declinebuyo":  func() LogData { return &DeclineBuyoData{} },
+	"paysaleram":   func() LogData { return &PaySaleRAMData{} },
+	"payauctram":   func() LogData { return &PayAuctRAMData{} },
+	"paybuyoram":   func() LogData { return &PayBuyoRAMData{} },
+	"regmarket":    func() LogData { return &RegMarketData{} },
+	"setminbidinc": func() LogData { return &SetMinBidIncData{} },
+	"adddelphi":    func() LogData { return &AddDelphiData{} },
+	"lognewsale":   func() LogData { return &LogNewSaleData{} },
+	"lognewauct":   func() LogData { return &LogNewAuctData{} },
+	"lognewbuyo":   func() LogData { return &LogNewBuyoData{} },
+	"logsalestart": func() LogData { return &LogSaleStartData{} },
+	"logauctstart": func() LogData { return &LogAuctStartData{} },
+	"logincbal":    func() LogData { return &LogIncBalData{} },
+	"logdecbal":    func() LogData { return &LogDecBalData{} },
+	"announcelink": func() LogData { return &AnnounceLinkData{} },
+	"cancellink":   func() LogData { return &CancelLinkData{} },
+	"claimlink":    func() LogData { return &ClaimLinkData{} },
+	"lognewlink":   func() LogData { return &LogNewLinkData{} },
+	"loglinkstart": func() LogData { return &LogLinkStartData{} },
+}
+
+// atomicassets actions
+
+type AdminColEditData struct {
+	CollectionFormatExtension []SchemaFormat `json:"collection_format_extension"`
+}
+
+func (d *AdminColEditData) Action() string { return "admincoledit" }
+
+type SetVersionData struct {
+	NewVersion string `json:"new_version"`
+}
+
+func (d *SetVersionData) Action() string { return "setversion" }
+
+type AddConfTokenData struct {
+	TokenContract string `json:"token_contract"`
+	TokenSymbol   string `json:"token_symbol"`
+}
+
+func (d *AddConfTokenData) Action() string { return "addconftoken" }
+
+type TransferData struct {
+	From     string        `json:"from"`
+	To       string        `json:"to"`
+	AssetIDs []json.Number `json:"asset_ids"`
+	Memo     string        `json:"memo"`
+}
+
+func (d *TransferData) Action() string { return "transfer" }
+
+type CreateColData struct {
+	Author             string           `json:"author"`
+	CollectionName     string           `json:"collection_name"`
+	AllowNotify        bool             `json:"allow_notify"`
+	AuthorizedAccounts []string         `json:"authorized_accounts"`
+	NotifyAccounts     []string         `json:"notify_accounts"`
+	MarketFee          float64          `json:"market_fee"`
+	Data               ActionAttributes `json:"data"`
+}
+
+func (d *CreateColData) Action() string { return "createcol" }
+
+type SetColDataData struct {
+	CollectionName string           `json:"collection_name"`
+	Data           ActionAttributes `json:"data"`
+}
+
+func (d *SetColDataData) Action() string { return "setcoldata" }
+
+type AddColAuthData struct {
+	CollectionName string `json:"collection_name"`
+	AccountToAdd   string `json:"account_to_add"`
+}
+
+func (d *AddColAuthData) Action() string { return "addcolauth" }
+
+type RemColAuthData struct {
+	CollectionName  string `json:"collection_name"`
+	AccountToRemove string `json:"account_to_remove"`
+}
+
+func (d *RemColAuthData) Action() string { return "remcolauth" }
+
+type AddNotifyAccData struct {
+	CollectionName string `json:"collection_name"`
+	AccountToAdd   string `json:"account_to_add"`
+}
+
+func (d *AddNotifyAccData) Action() string { return "addnotifyacc" }
+
+type RemNotifyAccData struct {
+	CollectionName  string `json:"collection_name"`
+	AccountToRemove string `json:"account_to_remove"`
+}
+
+func (d *RemNotifyAccData) Action() string { return "remnotifyacc" }
+
+// SetMarketFeeData is the atomicassets setmarketfee action.
+// (atomicmarket has an admin action with the same name that is not logged by the API)
+type SetMarketFeeData struct {
+	CollectionName string  `json:"collection_name"`
+	MarketFee      float64 `json:"market_fee"`
+}
+
+func (d *SetMarketFeeData) Action() string { return "setmarketfee" }
+
+type ForbidNotifyData struct {
+	CollectionName string `json:"collection_name"`
+}
+
+func (d *ForbidNotifyData) Action() string { return "forbidnotify" }
+
+type CreateSchemaData struct {
+	AuthorizedCreator string         `json:"authorized_creator"`
+	CollectionName    string         `json:"collection_name"`
+	SchemaName        string         `json:"schema_name"`
+	SchemaFormat      []SchemaFormat `json:"schema_format"`
+}
+
+func (d *CreateSchemaData) Action() string { return "createschema" }
+
+type ExtendSchemaData struct {
+	AuthorizedEditor      string         `json:"authorized_editor"`
+	CollectionName        string         `json:"collection_name"`
+	SchemaName            string         `json:"schema_name"`
+	SchemaFormatExtension []SchemaFormat `json:"schema_format_extension"`
+}
+
+func (d *ExtendSchemaData) Action() string { return "extendschema" }
+
+type CreateTemplData struct {
+	AuthorizedCreator string           `json:"authorized_creator"`
+	CollectionName    string           `json:"collection_name"`
+	SchemaName        string           `json:"schema_name"`
+	Transferable      bool             `json:"transferable"`
+	Burnable          bool             `json:"burnable"`
+	MaxSupply         json.Number      `json:"max_supply"`
+	ImmutableData     ActionAttributes `json:"immutable_data"`
+}
+
+func (d *CreateTemplData) Action() string { return "createtempl" }
+
+type LockTemplateData struct {
+	AuthorizedEditor string      `json:"authorized_editor"`
+	CollectionName   string      `json:"collection_name"`
+	TemplateID       json.Number `json:"template_id"`
+}
+
+func (d *LockTemplateData) Action() string { return "locktemplate" }
+
+type MintAssetData struct {
+	AuthorizedMinter string           `json:"authorized_minter"`
+	CollectionName   string           `json:"collection_name"`
+	SchemaName       string           `json:"schema_name"`
+	TemplateID       json.Number      `json:"template_id"`
+	NewAssetOwner    string           `json:"new_asset_owner"`
+	ImmutableData    ActionAttributes `json:"immutable_data"`
+	MutableData      ActionAttributes `json:"mutable_data"`
+	TokensToBack     []string         `json:"tokens_to_back"`
+}
+
+func (d *MintAssetData) Action() string { return "mintasset" }
+
+type SetAssetDataData struct {
+	AuthorizedEditor string           `json:"authorized_editor"`
+	AssetOwner       string           `json:"asset_owner"`
+	AssetID          json.Number      `json:"asset_id"`
+	NewMutableData   ActionAttributes `json:"new_mutable_data"`
+}
+
+func (d *SetAssetDataData) Action() string { return "setassetdata" }
+
+type AnnounceDepoData struct {
+	Owner            string `json:"owner"`
+	SymbolToAnnounce string `json:"symbol_to_announce"`
+}
+
+func (d *AnnounceDepoData) Action() string { return "announcedepo" }
+
+type WithdrawData struct {
+	Owner           string `json:"owner"`
+	TokenToWithdraw string `json:"token_to_withdraw"`
+}
+
+func (d *WithdrawData) Action() string { return "withdraw" }
+
+type BackAssetData struct {
+	Payer       string      `json:"payer"`
+	AssetOwner  string      `json:"asset_owner"`
+	AssetID     json.Number `json:"asset_id"`
+	TokenToBack string      `json:"token_to_back"`
+}
+
+func (d *BackAssetData) Action() string { return "backasset" }
+
+type BurnAssetData struct {
+	AssetOwner string      `json:"asset_owner"`
+	AssetID    json.Number `json:"asset_id"`
+}
+
+func (d *BurnAssetData) Action() string { return "burnasset" }
+
+type CreateOfferData struct {
+	Sender            string        `json:"sender"`
+	Recipient         string        `json:"recipient"`
+	SenderAssetIDs    []json.Number `json:"sender_asset_ids"`
+	RecipientAssetIDs []json.Number `json:"recipient_asset_ids"`
+	Memo              string        `json:"memo"`
+}
+
+func (d *CreateOfferData) Action() string { return "createoffer" }
+
+type CancelOfferData struct {
+	OfferID json.Number `json:"offer_id"`
+}
+
+func (d *CancelOfferData) Action() string { return "canceloffer" }
+
+type AcceptOfferData struct {
+	OfferID json.Number `json:"offer_id"`
+}
+
+func (d *AcceptOfferData) Action() string { return "acceptoffer" }
+
+type DeclineOfferData struct {
+	OfferID json.Number `json:"offer_id"`
+}
+
+func (d *DeclineOfferData) Action() string { return "declineoffer" }
+
+type PayOfferRAMData struct {
+	Payer   string      `json:"payer"`
+	OfferID json.Number `json:"offer_id"`
+}
+
+func (d *PayOfferRAMData) Action() string { return "payofferram" }
+
+type LogTransferData struct {
+	CollectionName string        `json:"collection_name"`
+	From           string        `json:"from"`
+	To             string        `json:"to"`
+	AssetIDs       []json.Number `json:"asset_ids"`
+	Memo           string        `json:"memo"`
+}
+
+func (d *LogTransferData) Action() string { return "logtransfer" }
+
+type LogNewOfferData struct {
+	OfferID           json.Number   `json:"offer_id"`
+	Sender            string        `json:"sender"`
+	Recipient         string        `json:"recipient"`
+	SenderAssetIDs    []json.Number `json:"sender_asset_ids"`
+	RecipientAssetIDs []json.Number `json:"recipient_asset_ids"`
+	Memo              string        `json:"memo"`
+}
+
+func (d *LogNewOfferData) Action() string { return "lognewoffer" }
+
+type LogNewTemplData struct {
+	TemplateID        json.Number      `json:"template_id"`
+	AuthorizedCreator string           `json:"authorized_creator"`
+	CollectionName    string           `json:"collection_name"`
+	SchemaName        string           `json:"schema_name"`
+	Transferable      bool             `json:"transferable"`
+	Burnable          bool             `json:"burnable"`
+	MaxSupply         json.Number      `json:"max_supply"`
+	ImmutableData     ActionAttributes `json:"immutable_data"`
+}
+
+func (d *LogNewTemplData) Action() string { return "lognewtempl" }
+
+type LogMintData struct {
+	AssetID               json.Number      `json:"asset_id"`
+	AuthorizedMinter      string           `json:"authorized_minter"`
+	CollectionName        string           `json:"collection_name"`
+	SchemaName            string           `json:"schema_name"`
+	TemplateID            json.Number      `json:"template_id"`
+	NewAssetOwner         string           `json:"new_asset_owner"`
+	ImmutableData         ActionAttributes `json:"immutable_data"`
+	MutableData           ActionAttributes `json:"mutable_data"`
+	BackedTokens          []string         `json:"backed_tokens"`
+	ImmutableTemplateData ActionAttributes `json:"immutable_template_data"`
+}
+
+func (d *LogMintData) Action() string { return "logmint" }
+
+type LogSetDataData struct {
+	AssetOwner string           `json:"asset_owner"`
+	AssetID    json.Number      `json:"asset_id"`
+	OldData    ActionAttributes `json:"old_data"`
+	NewData    ActionAttributes `json:"new_data"`
+}
+
+func (d *LogSetDataData) Action() string { return "logsetdata" }
+
+type LogBackAssetData struct {
+	AssetOwner  string      `json:"asset_owner"`
+	AssetID     json.Number `json:"asset_id"`
+	BackedToken string      `json:"backed_token"`
+}
+
+func (d *LogBackAssetData) Action() string { return "logbackasset" }
+
+type LogBurnAssetData struct {
+	AssetOwner       string           `json:"asset_owner"`
+	AssetID          json.Number      `json:"asset_id"`
+	CollectionName   string           `json:"collection_name"`
+	SchemaName       string           `json:"schema_name"`
+	TemplateID       json.Number      `json:"template_id"`
+	BackedTokens     []string         `json:"backed_tokens"`
+	OldImmutableData ActionAttributes `json:"old_immutable_data"`
+	OldMutableData   ActionAttributes `json:"old_mutable_data"`
+	AssetRAMPayer    string           `json:"asset_ram_payer"`
+}
+
+func (d *LogBurnAssetData) Action() string { return "logburnasset" }
+
+// atomicmarket actions
+
+type AnnounceSaleData struct {
+	Seller           string        `json:"seller"`
+	AssetIDs         []json.Number `json:"asset_ids"`
+	ListingPrice     string        `json:"listing_price"`
+	SettlementSymbol string        `json:"settlement_symbol"`
+	MakerMarketplace string        `json:"maker_marketplace"`
+}
+
+func (d *AnnounceSaleData) Action() string { return "announcesale" }
+
+type CancelSaleData struct {
+	SaleID json.Number `json:"sale_id"`
+}
+
+func (d *CancelSaleData) Action() string { return "cancelsale" }
+
+type PurchaseSaleData struct {
+	Buyer                string      `json:"buyer"`
+	SaleID               json.Number `json:"sale_id"`
+	IntendedDelphiMedian json.Number `json:"intended_delphi_median"`
+	TakerMarketplace     string      `json:"taker_marketplace"`
+}
+
+func (d *PurchaseSaleData) Action() string { return "purchasesale" }
+
+type AssertSaleData struct {
+	SaleID                   json.Number   `json:"sale_id"`
+	AssetIDsToAssert         []json.Number `json:"asset_ids_to_assert"`
+	ListingPriceToAssert     string        `json:"listing_price_to_assert"`
+	SettlementSymbolToAssert string        `json:"settlement_symbol_to_assert"`
+}
+
+func (d *AssertSaleData) Action() string { return "assertsale" }
+
+type AnnounceAuctData struct {
+	Seller           string        `json:"seller"`
+	AssetIDs         []json.Number `json:"asset_ids"`
+	StartingBid      string        `json:"starting_bid"`
+	Duration         json.Number   `json:"duration"`
+	MakerMarketplace string        `json:"maker_marketplace"`
+}
+
+func (d *AnnounceAuctData) Action() string { return "announceauct" }
+
+type CancelAuctData struct {
+	AuctionID json.Number `json:"auction_id"`
+}
+
+func (d *CancelAuctData) Action() string { return "cancelauct" }
+
+type AuctionBidData struct {
+	Bidder           string      `json:"bidder"`
+	AuctionID        json.Number `json:"auction_id"`
+	Bid              string      `json:"bid"`
+	TakerMarketplace string      `json:"taker_marketplace"`
+}
+
+func (d *AuctionBidData) Action() string { return "auctionbid" }
+
+type AuctClaimBuyData struct {
+	AuctionID json.Number `json:"auction_id"`
+}
+
+func (d *AuctClaimBuyData) Action() string { return "auctclaimbuy" }
+
+type AuctClaimSelData struct {
+	AuctionID json.Number `json:"auction_id"`
+}
+
+func (d *AuctClaimSelData) Action() string { return "auctclaimsel" }
+
+type AssertAuctData struct {
+	AuctionID        json.Number   `json:"auction_id"`
+	AssetIDsToAssert []json.Number `json:"asset_ids_to_assert"`
+}
+
+func (d *AssertAuctData) Action() string { return "assertauct" }
+
+type CreateBuyoData struct {
+	Buyer            string        `json:"buyer"`
+	Recipient        string        `json:"recipient"`
+	Price            string        `json:"price"`
+	AssetIDs         []json.Number `json:"asset_ids"`
+	Memo             string        `json:"memo"`
+	MakerMarketplace string        `json:"maker_marketplace"`
+}
+
+func (d *CreateBuyoData) Action() string { return "createbuyo" }
+
+type CancelBuyoData struct {
+	BuyofferID json.Number `json:"buyoffer_id"`
+}
+
+func (d *CancelBuyoData) Action() string { return "cancelbuyo" }
+
+type AcceptBuyoData struct {
+	BuyofferID       json.Number   `json:"buyoffer_id"`
+	ExpectedAssetIDs []json.Number `json:"expected_asset_ids"`
+	ExpectedPrice    string        `json:"expected_price"`
+	TakerMarketplace string        `json:"taker_marketplace"`
+}
+
+func (d *AcceptBuyoData) Action() string { return "acceptbuyo" }
+
+type DeclineBuyoData struct {
+	BuyofferID  json.Number `json:"buyoffer_id"`
+	DeclineMemo string      `json:"decline_memo"`
+}
+
+func (d *DeclineBuyoData) Action() string { return "declinebuyo" }
+
+type PaySaleRAMData struct {
+	Payer  string      `json:"payer"`
+	SaleID json.Number `json:"sale_id"`
+}
+
+func (d *PaySaleRAMData) Action() string { return "paysaleram" }
+
+type PayAuctRAMData struct {
+	Payer     string      `json:"payer"`
+	AuctionID json.Number `json:"auction_id"`
+}
+
+func (d *PayAuctRAMData) Action() string { return "payauctram" }
+
+type PayBuyoRAMData struct {
+	Payer      string      `json:"payer"`
+	BuyofferID json.Number `json:"buyoffer_id"`
+}
+
+func (d *PayBuyoRAMData) Action() string { return "paybuyoram" }
+
+type RegMarketData struct {
+	Creator         string `json:"creator"`
+	MarketplaceName string `json:"marketplace_name"`
+}
+
+func (d *RegMarketData) Action() string { return "regmarket" }
+
+type SetMinBidIncData struct {
+	MinimumBidIncrease float64 `json:"minimum_bid_increase"`
+}
+
+func (d *SetMinBidIncData) Action() string { return "setminbidinc" }
+
+type AddDelphiData struct {
+	DelphiPairName   string `json:"delphi_pair_name"`
+	InvertDelphiPair bool   `json:"invert_delphi_pair"`
+	ListingSymbol    string `json:"listing_symbol"`
+	SettlementSymbol string `json:"settlement_symbol"`
+}
+
+func (d *AddDelphiData) Action() string { return "adddelphi" }
+
+type LogNewSaleData struct {
+	SaleID           json.Number   `json:"sale_id"`
+	Seller           string        `json:"seller"`
+	AssetIDs         []json.Number `json:"asset_ids"`
+	ListingPrice     string        `json:"listing_price"`
+	SettlementSymbol string        `json:"settlement_symbol"`
+	MakerMarketplace string        `json:"maker_marketplace"`
+	CollectionName   string        `json:"collection_name"`
+	CollectionFee    float64       `json:"collection_fee"`
+}
+
+func (d *LogNewSaleData) Action() string { return "lognewsale" }
+
+type LogNewAuctData struct {
+	AuctionID        json.Number   `json:"auction_id"`
+	Seller           string        `json:"seller"`
+	AssetIDs         []json.Number `json:"asset_ids"`
+	StartingBid      string        `json:"starting_bid"`
+	Duration         json.Number   `json:"duration"`
+	EndTime          json.Number   `json:"end_time"`
+	MakerMarketplace string        `json:"maker_marketplace"`
+	CollectionName   string        `json:"collection_name"`
+	CollectionFee    float64       `json:"collection_fee"`
+}
+
+func (d *LogNewAuctData) Action() string { return "lognewauct" }
+
+type LogNewBuyoData struct {
+	BuyofferID       json.Number   `json:"buyoffer_id"`
+	Buyer            string        `json:"buyer"`
+	Recipient        string        `json:"recipient"`
+	Price            string        `json:"price"`
+	AssetIDs         []json.Number `json:"asset_ids"`
+	Memo             string        `json:"memo"`
+	MakerMarketplace string        `json:"maker_marketplace"`
+	CollectionName   string        `json:"collection_name"`
+	CollectionFee    float64       `json:"collection_fee"`
+}
+
+func (d *LogNewBuyoData) Action() string { return "lognewbuyo" }
+
+type LogSaleStartData struct {
+	SaleID  json.Number `json:"sale_id"`
+	OfferID json.Number `json:"offer_id"`
+}
+
+func (d *LogSaleStartData) Action() string { return "logsalestart" }
+
+type LogAuctStartData struct {
+	AuctionID json.Number `json:"auction_id"`
+}
+
+func (d *LogAuctStartData) Action() string { return "logauctstart" }
+
+type LogIncBalData struct {
+	User            string `json:"user"`
+	BalanceIncrease string `json:"balance_increase"`
+	Reason          string `json:"reason"`
+}
+
+func (d *LogIncBalData) Action() string { return "logincbal" }
+
+type LogDecBalData struct {
+	User            string `json:"user"`
+	BalanceDecrease string `json:"balance_decrease"`
+	Reason          string `json:"reason"`
+}
+
+func (d *LogDecBalData) Action() string { return "logdecbal" }
+
+// atomictools actions
+
+type AnnounceLinkData struct {
+	Creator  string        `json:"creator"`
+	Key      string        `json:"key"`
+	AssetIDs []json.Number `json:"asset_ids"`
+	Memo     string        `json:"memo"`
+}
+
+func (d *AnnounceLinkData) Action() string { return "announcelink" }
+
+type CancelLinkData struct {
+	LinkID json.Number `json:"link_id"`
+}
+
+func (d *CancelLinkData) Action() string { return "cancellink" }
+
+type ClaimLinkData struct {
+	LinkID           json.Number `json:"link_id"`
+	Claimer          string      `json:"claimer"`
+	ClaimerSignature string      `json:"claimer_signature"`
+}
+
+func (d *ClaimLinkData) Action() string { return "claimlink" }
+
+type LogNewLinkData struct {
+	LinkID   json.Number   `json:"link_id"`
+	Creator  string        `json:"creator"`
+	Key      string        `json:"key"`
+	AssetIDs []json.Number `json:"asset_ids"`
+	Memo     string        `json:"memo"`
+}
+
+func (d *LogNewLinkData) Action() string { return "lognewlink" }
+
+type LogLinkStartData struct {
+	LinkID json.Number `json:"link_id"`
+}
+
+func (d *LogLinkStartData) Action() string { return "loglinkstart" }
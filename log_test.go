@@ -0,0 +1,141 @@
+package atomicasset
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLog_Decode(t *testing.T) {
+	tests := []struct {
+		name     string
+		log      Log
+		expected LogData
+	}{
+		{
+			name: "logmint",
+			log: Log{Name: "logmint", Data: map[string]interface{}{
+				"new_asset_owner":   "farmersworld",
+				"authorized_minter": "farmersworld",
+			}},
+			expected: &LogMintData{NewAssetOwner: "farmersworld", AuthorizedMinter: "farmersworld"},
+		},
+		{
+			name: "lognewauct",
+			log: Log{Name: "lognewauct", Data: map[string]interface{}{
+				"starting_bid":      "3000.00000000 WAX",
+				"collection_fee":    0.06,
+				"maker_marketplace": "",
+			}},
+			expected: &LogNewAuctData{StartingBid: "3000.00000000 WAX", CollectionFee: 0.06},
+		},
+		{
+			name: "purchasesale",
+			log: Log{Name: "purchasesale", Data: map[string]interface{}{
+				"taker_marketplace":      "",
+				"intended_delphi_median": "0",
+			}},
+			expected: &PurchaseSaleData{IntendedDelphiMedian: "0"},
+		},
+		{
+			name:     "logsalestart",
+			log:      Log{Name: "logsalestart", Data: map[string]interface{}{"offer_id": "104058114"}},
+			expected: &LogSaleStartData{OfferID: "104058114"},
+		},
+		{
+			name:     "claimlink",
+			log:      Log{Name: "claimlink", Data: map[string]interface{}{"claimer_signature": "1693600"}},
+			expected: &ClaimLinkData{ClaimerSignature: "1693600"},
+		},
+		{
+			name: "logtransfer",
+			log: Log{Name: "logtransfer", Data: map[string]interface{}{
+				"from":      "alice",
+				"to":        "bob",
+				"asset_ids": []interface{}{"1099511627776", float64(2)},
+				"memo":      "gift",
+			}},
+			expected: &LogTransferData{From: "alice", To: "bob", AssetIDs: []json.Number{"1099511627776", "2"}, Memo: "gift"},
+		},
+		{
+			name: "auctionbid",
+			log: Log{Name: "auctionbid", Data: map[string]interface{}{
+				"bidder":     "alice",
+				"auction_id": "10",
+				"bid":        "10.00000000 WAX",
+			}},
+			expected: &AuctionBidData{Bidder: "alice", AuctionID: "10", Bid: "10.00000000 WAX"},
+		},
+		{
+			name: "createcol",
+			log: Log{Name: "createcol", Data: map[string]interface{}{
+				"data": []interface{}{
+					map[string]interface{}{"key": "name", "value": []interface{}{"string", "OUT OF THE MATRIX "}},
+					map[string]interface{}{"key": "img", "value": []interface{}{"string", "QmWfJDKaGXrXCQyCwQZs1PEzoTKU8VBmRW7X7jDv8Vw8ou"}},
+				},
+			}},
+			expected: &CreateColData{Data: ActionAttributes{
+				"name": "OUT OF THE MATRIX ",
+				"img":  "QmWfJDKaGXrXCQyCwQZs1PEzoTKU8VBmRW7X7jDv8Vw8ou",
+			}},
+		},
+		{
+			name: "logsetdata",
+			log: Log{Name: "logsetdata", Data: map[string]interface{}{
+				"old_data": map[string]interface{}{"level": float64(1)},
+				"new_data": map[string]interface{}{"level": float64(2)},
+			}},
+			expected: &LogSetDataData{
+				OldData: ActionAttributes{"level": float64(1)},
+				NewData: ActionAttributes{"level": float64(2)},
+			},
+		},
+		{
+			name:     "Empty",
+			log:      Log{Name: "cancelauct", Data: map[string]interface{}{}},
+			expected: &CancelAuctData{},
+		},
+		{
+			name:     "Unknown",
+			log:      Log{Name: "newaction", Data: map[string]interface{}{"field": "value"}},
+			expected: &UnknownLogData{Name: "newaction", Data: map[string]interface{}{"field": "value"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := tt.log.Decode()
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, data)
+			assert.Equal(t, tt.log.Name, data.Action())
+		})
+	}
+}
+
+func TestLog_DecodeError(t *testing.T) {
+	log := Log{Name: "lognewsale", Data: map[string]interface{}{"collection_fee": "high"}}
+
+	_, err := log.Decode()
+	assert.Error(t, err)
+}
+
+func TestLog_DecodeActionNames(t *testing.T) {
+	for name, ctor := range logDataTypes {
+		assert.Equal(t, name, ctor().Action())
+	}
+}
+
+func TestActionAttributes_UnmarshalJSON(t *testing.T) {
+	var attrs ActionAttributes
+
+	require.NoError(t, json.Unmarshal([]byte(`[{"key":"level","value":["uint8",5]}]`), &attrs))
+	assert.Equal(t, ActionAttributes{"level": float64(5)}, attrs)
+
+	require.NoError(t, json.Unmarshal([]byte(`{"level":5}`), &attrs))
+	assert.Equal(t, ActionAttributes{"level": float64(5)}, attrs)
+
+	assert.Error(t, json.Unmarshal([]byte(`[{"key":"level","value":[5]}]`), &attrs))
+	assert.Error(t, json.Unmarshal([]byte(`"string"`), &attrs))
+}
@@ -0,0 +1,314 @@
+package atomicasset
+
+import (
+	"encoding/base32"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/eosswedenorg-go/atomicasset/internal/base58"
+)
+
+// Types
+
+// Media is a normalized reference to an image or video.
+//
+// Media stored on IPFS has CID set (and optionally Path), media hosted
+// anywhere else only has URL set.
+type Media struct {
+	CID  string
+	Path string
+	URL  string
+}
+
+// MediaResolver builds URLs for media using a list of IPFS gateways.
+type MediaResolver struct {
+	// Gateways is an ordered list of gateway base urls, for example "https://ipfs.io/ipfs/".
+	Gateways []string
+
+	// Resizer is an optional url template for resized images.
+	// "{cid}", "{path}" and "{size}" are replaced with their values.
+	Resizer string
+}
+
+// DefaultGateway is used by resolvers that have no gateways configured.
+const DefaultGateway = "https://ipfs.io/ipfs/"
+
+var (
+	// ErrEmptyMedia is returned when parsing an empty media string.
+	ErrEmptyMedia = errors.New("empty media reference")
+
+	// ErrInvalidCID is returned for strings that are not a valid CID.
+	ErrInvalidCID = errors.New("invalid CID")
+)
+
+// CID validation
+
+var cidBase32 = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// ValidateCID checks that s is a valid CIDv0 or a base32/base58 encoded CIDv1.
+func ValidateCID(s string) error {
+	// CIDv0 is always a base58 encoded sha2-256 multihash.
+	if len(s) == 46 && strings.HasPrefix(s, "Qm") {
+		b, err := base58.Decode(s)
+		if err != nil || len(b) != 34 || b[0] != 0x12 || b[1] != 0x20 {
+			return ErrInvalidCID
+		}
+		return nil
+	}
+
+	if len(s) < 2 {
+		return ErrInvalidCID
+	}
+
+	var b []byte
+	var err error
+	switch s[0] {
+	case 'b':
+		b, err = cidBase32.DecodeString(strings.ToUpper(s[1:]))
+	case 'B':
+		b, err = cidBase32.DecodeString(s[1:])
+	case 'z':
+		b, err = base58.Decode(s[1:])
+	default:
+		return ErrInvalidCID
+	}
+	if err != nil {
+		return ErrInvalidCID
+	}
+
+	// <version><codec><multihash code><digest length><digest>
+	fields := []uint64{}
+	for i := 0; i < 4; i++ {
+		n, size := binary.Uvarint(b)
+		if size <= 0 {
+			return ErrInvalidCID
+		}
+		fields = append(fields, n)
+		b = b[size:]
+	}
+
+	if fields[0] != 1 || fields[3] != uint64(len(b)) {
+		return ErrInvalidCID
+	}
+	return nil
+}
+
+// IsCID reports whether s is a valid CID.
+func IsCID(s string) bool {
+	return ValidateCID(s) == nil
+}
+
+// Parsing
+
+// ParseMedia parses a bare CID, CID with path, "ipfs://" uri, "/ipfs/" path,
+// gateway url (path or subdomain style) or any other url.
+func ParseMedia(s string) (Media, error) {
+	s = strings.TrimSpace(s)
+	if len(s) < 1 {
+		return Media{}, ErrEmptyMedia
+	}
+
+	if strings.HasPrefix(s, "ipfs://") {
+		return parseIPFSPath(strings.TrimPrefix(strings.TrimPrefix(s, "ipfs://"), "ipfs/"))
+	}
+
+	if strings.HasPrefix(s, "/ipfs/") {
+		return parseIPFSPath(strings.TrimPrefix(s, "/ipfs/"))
+	}
+
+	if strings.HasPrefix(s, "http://") || strings.HasPrefix(s, "https://") {
+		u, err := url.Parse(s)
+		if err != nil {
+			return Media{}, err
+		}
+
+		// Path gateway: https://gateway/ipfs/<cid>/<path>
+		if p := strings.Index(u.Path, "/ipfs/"); p >= 0 {
+			if m, err := parseIPFSPath(u.Path[p+len("/ipfs/"):]); err == nil {
+				return m, nil
+			}
+		}
+
+		// Subdomain gateway: https://<cid>.ipfs.gateway/<path>
+		if labels := strings.SplitN(u.Hostname(), ".", 3); len(labels) == 3 && labels[1] == "ipfs" {
+			if m, err := parseIPFSPath(labels[0] + u.Path); err == nil {
+				return m, nil
+			}
+		}
+
+		return Media{URL: s}, nil
+	}
+
+	return parseIPFSPath(s)
+}
+
+// parseIPFSPath parses "<cid>[/path]"
+func parseIPFSPath(s string) (Media, error) {
+	cid, path := s, ""
+	if p := strings.IndexByte(s, '/'); p >= 0 {
+		cid, path = s[:p], s[p:]
+	}
+
+	if err := ValidateCID(cid); err != nil {
+		return Media{}, fmt.Errorf("%w: '%s'", err, cid)
+	}
+
+	// base32 is case insensitive, use the canonical lower case form.
+	if cid[0] == 'B' {
+		cid = strings.ToLower(cid)
+	}
+
+	if path == "/" {
+		path = ""
+	}
+	return Media{CID: cid, Path: path}, nil
+}
+
+// IsIPFS reports whether the media is stored on IPFS.
+func (m Media) IsIPFS() bool {
+	return len(m.CID) > 0
+}
+
+// String returns the normalized form of the media, "ipfs://<cid>[/path]" for
+// media stored on IPFS and the url for anything else.
+func (m Media) String() string {
+	if m.IsIPFS() {
+		return "ipfs://" + m.CID + m.Path
+	}
+	return m.URL
+}
+
+// Resolving
+
+// NewMediaResolver creates a resolver using gateways in the order they are given.
+func NewMediaResolver(gateways ...string) *MediaResolver {
+	return &MediaResolver{Gateways: gateways}
+}
+
+func (r *MediaResolver) gateways() []string {
+	if r == nil || len(r.Gateways) < 1 {
+		return []string{DefaultGateway}
+	}
+	return r.Gateways
+}
+
+// URLs returns one url per gateway for the media in s.
+// Media not stored on IPFS resolves to its own url.
+func (r *MediaResolver) URLs(s string) ([]string, error) {
+	m, err := ParseMedia(s)
+	if err != nil {
+		return nil, err
+	}
+
+	if !m.IsIPFS() {
+		return []string{m.URL}, nil
+	}
+
+	urls := []string{}
+	for _, gw := range r.gateways() {
+		urls = append(urls, strings.TrimSuffix(gw, "/")+"/"+m.CID+m.Path)
+	}
+	return urls, nil
+}
+
+// URL returns the url for the media in s using the first gateway.
+func (r *MediaResolver) URL(s string) (string, error) {
+	urls, err := r.URLs(s)
+	if err != nil {
+		return "", err
+	}
+	return urls[0], nil
+}
+
+// ResizedURL returns the url to a resized version of the media in s.
+// The normal url is returned if no resizer is configured or
+// if the media is not stored on IPFS.
+func (r *MediaResolver) ResizedURL(s string, size int) (string, error) {
+	m, err := ParseMedia(s)
+	if err != nil {
+		return "", err
+	}
+
+	if r == nil || len(r.Resizer) < 1 || !m.IsIPFS() {
+		return r.URL(s)
+	}
+
+	return strings.NewReplacer(
+		"{cid}", m.CID,
+		"{path}", m.Path,
+		"{size}", strconv.Itoa(size),
+	).Replace(r.Resizer), nil
+}
+
+// Accessors
+
+// schemaMediaURLs resolves all attributes in data declared as image or ipfs in format.
+func schemaMediaURLs(r *MediaResolver, data map[string]interface{}, format []SchemaFormat) map[string]string {
+	urls := map[string]string{}
+	for _, f := range format {
+		if f.Type != "image" && f.Type != "ipfs" {
+			continue
+		}
+
+		if s, ok := data[f.Name].(string); ok {
+			if u, err := r.URL(s); err == nil {
+				urls[f.Name] = u
+			}
+		}
+	}
+	return urls
+}
+
+func mediaURL(r *MediaResolver, data map[string]interface{}, name string) (string, error) {
+	s, ok := data[name].(string)
+	if !ok {
+		return "", fmt.Errorf("attribute '%s' not found", name)
+	}
+	return r.URL(s)
+}
+
+// MediaURLs returns urls for all attributes declared as image or ipfs in the asset's schema.
+// Attributes that can not be resolved are left out.
+func (a Asset) MediaURLs(r *MediaResolver) map[string]string {
+	return schemaMediaURLs(r, a.Data, a.Schema.Format)
+}
+
+// ImageURL returns the url for the asset's "img" attribute.
+func (a Asset) ImageURL(r *MediaResolver) (string, error) {
+	return mediaURL(r, a.Data, "img")
+}
+
+// VideoURL returns the url for the asset's "video" attribute.
+func (a Asset) VideoURL(r *MediaResolver) (string, error) {
+	return mediaURL(r, a.Data, "video")
+}
+
+// MediaURLs returns urls for all attributes declared as image or ipfs in the template's schema.
+// Attributes that can not be resolved are left out.
+func (t Template) MediaURLs(r *MediaResolver) map[string]string {
+	return schemaMediaURLs(r, t.ImmutableData, t.Schema.Format)
+}
+
+// ImageURL returns the url for the template's "img" attribute.
+func (t Template) ImageURL(r *MediaResolver) (string, error) {
+	return mediaURL(r, t.ImmutableData, "img")
+}
+
+// VideoURL returns the url for the template's "video" attribute.
+func (t Template) VideoURL(r *MediaResolver) (string, error) {
+	return mediaURL(r, t.ImmutableData, "video")
+}
+
+// ImageURL returns the url for the collection's image.
+func (c Collection) ImageURL(r *MediaResolver) (string, error) {
+	img := c.Image
+	if len(img) < 1 {
+		// The image is stored in data for most responses.
+		img, _ = c.Data["img"].(string)
+	}
+	return r.URL(img)
+}
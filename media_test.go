@@ -0,0 +1,191 @@
+package atomicasset
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testCIDv0 = "QmYUtzfGWAYrQ43eo1nNRfrYKpUS1cvCWmceCQYxjP7CkS"
+	testCIDv1 = "bafybeigdyrzt5sfp7udm7hu76uh7y26nf3efuylqabf3oclgtqy55fbzdi"
+)
+
+func TestValidateCID(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		valid bool
+	}{
+		{"CIDv0", testCIDv0, true},
+		{"CIDv1", testCIDv1, true},
+		{"CIDv1Upper", "BAFYBEIGDYRZT5SFP7UDM7HU76UH7Y26NF3EFUYLQABF3OCLGTQY55FBZDI", true},
+		{"Empty", "", false},
+		{"Short", "Qm", false},
+		{"CIDv0InvalidChar", "QmYUtzfGWAYrQ43eo1nNRfrYKpUS1cvCWmceCQYxjP7Ck0", false},
+		{"CIDv0Truncated", "QmYUtzfGWAYrQ43eo1nNRfrYKpUS1cvCWmceCQYxjP7Ck", false},
+		{"CIDv1Truncated", "bafybeigdyrzt5sfp7udm7hu76uh7y26nf3efuylqabf3oclgtqy55fbz", false},
+		{"UnknownBase", "fafybeigdyrzt5sfp7udm7hu76uh7y26nf3efuylqabf3oclgtqy55fbzdi", false},
+		{"Text", "hello world", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.valid, IsCID(tt.input))
+		})
+	}
+}
+
+func TestParseMedia(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected Media
+	}{
+		{"BareCID", testCIDv0, Media{CID: testCIDv0}},
+		{"BareCIDv1", testCIDv1, Media{CID: testCIDv1}},
+		{"CIDWithPath", testCIDv0 + "/images/1.png", Media{CID: testCIDv0, Path: "/images/1.png"}},
+		{"CIDTrailingSlash", testCIDv0 + "/", Media{CID: testCIDv0}},
+		{"IPFSURI", "ipfs://" + testCIDv0, Media{CID: testCIDv0}},
+		{"IPFSURIWithPrefix", "ipfs://ipfs/" + testCIDv0 + "/a.gif", Media{CID: testCIDv0, Path: "/a.gif"}},
+		{"IPFSPath", "/ipfs/" + testCIDv1, Media{CID: testCIDv1}},
+		{"PathGateway", "https://ipfs.io/ipfs/" + testCIDv0 + "/a.png", Media{CID: testCIDv0, Path: "/a.png"}},
+		{"SubdomainGateway", "https://" + testCIDv1 + ".ipfs.dweb.link/a.png", Media{CID: testCIDv1, Path: "/a.png"}},
+		{"Whitespace", "  " + testCIDv0 + "\n", Media{CID: testCIDv0}},
+		{"OtherURL", "https://example.com/image.png", Media{URL: "https://example.com/image.png"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := ParseMedia(tt.input)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, m)
+		})
+	}
+}
+
+func TestParseMedia_Errors(t *testing.T) {
+	_, err := ParseMedia("")
+	assert.ErrorIs(t, err, ErrEmptyMedia)
+
+	_, err = ParseMedia("not-a-cid/image.png")
+	assert.ErrorIs(t, err, ErrInvalidCID)
+
+	_, err = ParseMedia("ipfs://QmInvalid")
+	assert.ErrorIs(t, err, ErrInvalidCID)
+}
+
+func TestMedia_String(t *testing.T) {
+	assert.Equal(t, "ipfs://"+testCIDv0+"/a.png", Media{CID: testCIDv0, Path: "/a.png"}.String())
+	assert.Equal(t, "https://example.com/a.png", Media{URL: "https://example.com/a.png"}.String())
+}
+
+func TestMediaResolver_URLs(t *testing.T) {
+	r := NewMediaResolver("https://gateway1.example.com/ipfs", "https://gateway2.example.com/ipfs/")
+
+	urls, err := r.URLs("ipfs://" + testCIDv0 + "/a.png")
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"https://gateway1.example.com/ipfs/" + testCIDv0 + "/a.png",
+		"https://gateway2.example.com/ipfs/" + testCIDv0 + "/a.png",
+	}, urls)
+
+	urls, err = r.URLs("https://example.com/a.png")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"https://example.com/a.png"}, urls)
+
+	u, err := r.URL("https://cloudflare-ipfs.com/ipfs/" + testCIDv0)
+	require.NoError(t, err)
+	assert.Equal(t, "https://gateway1.example.com/ipfs/"+testCIDv0, u)
+}
+
+func TestMediaResolver_DefaultGateway(t *testing.T) {
+	var r *MediaResolver
+
+	u, err := r.URL(testCIDv0)
+	require.NoError(t, err)
+	assert.Equal(t, DefaultGateway+testCIDv0, u)
+}
+
+func TestMediaResolver_ResizedURL(t *testing.T) {
+	r := &MediaResolver{
+		Gateways: []string{"https://ipfs.example.com/ipfs/"},
+		Resizer:  "https://resizer.example.com/preview?ipfs={cid}{path}&size={size}",
+	}
+
+	u, err := r.ResizedURL(testCIDv0, 370)
+	require.NoError(t, err)
+	assert.Equal(t, "https://resizer.example.com/preview?ipfs="+testCIDv0+"&size=370", u)
+
+	u, err = r.ResizedURL("https://example.com/a.png", 370)
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com/a.png", u)
+
+	r.Resizer = ""
+	u, err = r.ResizedURL(testCIDv0, 370)
+	require.NoError(t, err)
+	assert.Equal(t, "https://ipfs.example.com/ipfs/"+testCIDv0, u)
+}
+
+func TestAsset_MediaURLs(t *testing.T) {
+	r := NewMediaResolver("https://ipfs.example.com/ipfs/")
+
+	asset := Asset{
+		Schema: InlineSchema{Format: []SchemaFormat{
+			{Name: "name", Type: "string"},
+			{Name: "img", Type: "image"},
+			{Name: "video", Type: "ipfs"},
+			{Name: "backimg", Type: "image"},
+		}},
+		Data: map[string]interface{}{
+			"name":    testCIDv1,
+			"img":     testCIDv0,
+			"video":   "ipfs://" + testCIDv1,
+			"backimg": "invalid",
+		},
+	}
+
+	assert.Equal(t, map[string]string{
+		"img":   "https://ipfs.example.com/ipfs/" + testCIDv0,
+		"video": "https://ipfs.example.com/ipfs/" + testCIDv1,
+	}, asset.MediaURLs(r))
+
+	img, err := asset.ImageURL(r)
+	require.NoError(t, err)
+	assert.Equal(t, "https://ipfs.example.com/ipfs/"+testCIDv0, img)
+
+	video, err := asset.VideoURL(r)
+	require.NoError(t, err)
+	assert.Equal(t, "https://ipfs.example.com/ipfs/"+testCIDv1, video)
+
+	_, err = Asset{}.ImageURL(r)
+	assert.EqualError(t, err, "attribute 'img' not found")
+}
+
+func TestTemplate_MediaURLs(t *testing.T) {
+	r := NewMediaResolver("https://ipfs.example.com/ipfs/")
+
+	tmpl := Template{
+		Schema:        InlineSchema{Format: []SchemaFormat{{Name: "img", Type: "image"}}},
+		ImmutableData: map[string]interface{}{"img": testCIDv0},
+	}
+
+	assert.Equal(t, map[string]string{"img": "https://ipfs.example.com/ipfs/" + testCIDv0}, tmpl.MediaURLs(r))
+
+	img, err := tmpl.ImageURL(r)
+	require.NoError(t, err)
+	assert.Equal(t, "https://ipfs.example.com/ipfs/"+testCIDv0, img)
+}
+
+func TestCollection_ImageURL(t *testing.T) {
+	r := NewMediaResolver("https://ipfs.example.com/ipfs/")
+
+	img, err := Collection{Image: testCIDv0}.ImageURL(r)
+	require.NoError(t, err)
+	assert.Equal(t, "https://ipfs.example.com/ipfs/"+testCIDv0, img)
+
+	img, err = Collection{Data: map[string]interface{}{"img": testCIDv1}}.ImageURL(r)
+	require.NoError(t, err)
+	assert.Equal(t, "https://ipfs.example.com/ipfs/"+testCIDv1, img)
+}
@@ -0,0 +1,173 @@
+// Package mirror keeps a local SQLite copy of one or more collections.
+//
+// Sync fetches the collection, its schemas, templates, assets, transfers
+// and sales from the API. After the first sync only what changed since the
+// last checkpoint is fetched. The copy can be queried with the functions in
+// this package or with SQL through DB.
+//
+// The database is opened with github.com/mattn/go-sqlite3, which needs cgo.
+// Programs using this package must be built with CGO_ENABLED=1 and a C
+// compiler.
+package mirror
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// ErrNotFound is returned when a row does not exist in the mirror.
+var ErrNotFound = errors.New("not found in mirror")
+
+// Checkpoint resources.
+const (
+	ResourceTemplates = "templates"
+	ResourceAssets    = "assets"
+	ResourceTransfers = "transfers"
+	ResourceSales     = "sales"
+)
+
+const schema = `
+CREATE TABLE IF NOT EXISTS collections (
+	collection_name TEXT PRIMARY KEY,
+	author          TEXT NOT NULL,
+	name            TEXT NOT NULL,
+	created_at_time INTEGER NOT NULL,
+	json            TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS schemas (
+	collection_name TEXT NOT NULL,
+	schema_name     TEXT NOT NULL,
+	created_at_time INTEGER NOT NULL,
+	json            TEXT NOT NULL,
+	PRIMARY KEY (collection_name, schema_name)
+);
+
+CREATE TABLE IF NOT EXISTS templates (
+	template_id     TEXT PRIMARY KEY,
+	collection_name TEXT NOT NULL,
+	schema_name     TEXT NOT NULL,
+	created_at_time INTEGER NOT NULL,
+	json            TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS assets (
+	asset_id            TEXT PRIMARY KEY,
+	collection_name     TEXT NOT NULL,
+	schema_name         TEXT NOT NULL,
+	template_id         TEXT NOT NULL,
+	owner               TEXT NOT NULL,
+	burned              INTEGER NOT NULL,
+	updated_at_time     INTEGER NOT NULL,
+	transferred_at_time INTEGER NOT NULL,
+	json                TEXT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS assets_owner ON assets (owner);
+CREATE INDEX IF NOT EXISTS assets_collection ON assets (collection_name, schema_name, template_id);
+
+CREATE TABLE IF NOT EXISTS transfers (
+	transfer_id     TEXT PRIMARY KEY,
+	collection_name TEXT NOT NULL,
+	sender          TEXT NOT NULL,
+	recipient       TEXT NOT NULL,
+	created_at_time INTEGER NOT NULL,
+	json            TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS transfer_assets (
+	transfer_id TEXT NOT NULL,
+	asset_id    TEXT NOT NULL,
+	PRIMARY KEY (transfer_id, asset_id)
+);
+
+CREATE INDEX IF NOT EXISTS transfer_assets_asset ON transfer_assets (asset_id);
+
+CREATE TABLE IF NOT EXISTS sales (
+	sale_id         TEXT PRIMARY KEY,
+	collection_name TEXT NOT NULL,
+	seller          TEXT NOT NULL,
+	buyer           TEXT NOT NULL,
+	state           TEXT NOT NULL,
+	updated_at_time INTEGER NOT NULL,
+	json            TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS checkpoints (
+	collection_name TEXT NOT NULL,
+	resource        TEXT NOT NULL,
+	time            INTEGER NOT NULL,
+	PRIMARY KEY (collection_name, resource)
+);
+`
+
+// Mirror is a local copy of collections stored in SQLite.
+type Mirror struct {
+	db *sql.DB
+}
+
+// Open opens (or creates) the SQLite database at path.
+func Open(path string) (*Mirror, error) {
+	db, err := sql.Open("sqlite3", path)
+	if err != nil {
+		return nil, err
+	}
+
+	// SQLite only allows one writer, and every connection
+	// to ":memory:" would get its own database.
+	db.SetMaxOpenConns(1)
+
+	m, err := New(db)
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	return m, nil
+}
+
+// New creates a mirror on an already opened SQLite database
+// and creates the tables if they do not exist.
+func New(db *sql.DB) (*Mirror, error) {
+	if _, err := db.Exec(schema); err != nil {
+		return nil, err
+	}
+	return &Mirror{db: db}, nil
+}
+
+// DB returns the underlying database for custom queries.
+func (m *Mirror) DB() *sql.DB {
+	return m.db
+}
+
+// Close closes the database.
+func (m *Mirror) Close() error {
+	return m.db.Close()
+}
+
+// Checkpoint returns the time up to which resource has been synced for
+// collection. The zero time is returned if it has never been synced.
+func (m *Mirror) Checkpoint(ctx context.Context, collection string, resource string) (time.Time, error) {
+	ms, err := checkpoint(ctx, m.db, collection, resource)
+	if err != nil || ms < 1 {
+		return time.Time{}, err
+	}
+	return time.UnixMilli(ms).UTC(), nil
+}
+
+// querier is implemented by *sql.DB and *sql.Tx.
+type querier interface {
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+func checkpoint(ctx context.Context, q querier, collection string, resource string) (int64, error) {
+	var ms int64
+	err := q.QueryRowContext(ctx, "SELECT time FROM checkpoints WHERE collection_name = ? AND resource = ?", collection, resource).Scan(&ms)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, nil
+	}
+	return ms, err
+}
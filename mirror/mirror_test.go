@@ -0,0 +1,232 @@
+package mirror
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/eosswedenorg-go/atomicasset"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeAPI serves a collection that changes between two syncs.
+type fakeAPI struct {
+	t     *testing.T
+	round int
+	fail  bool
+}
+
+func (f *fakeAPI) handler() http.Handler {
+	mux := http.NewServeMux()
+
+	write := func(res http.ResponseWriter, data string) {
+		res.Header().Add("Content-type", "application/json; charset=utf-8")
+		_, err := res.Write([]byte(`{"success": true, "data": ` + data + `, "query_time": 0}`))
+		assert.NoError(f.t, err)
+	}
+
+	mux.HandleFunc("/atomicassets/v1/collection/mycol", func(res http.ResponseWriter, req *http.Request) {
+		write(res, `{"collection_name": "mycol", "author": "alice", "name": "My Collection", "created_at_time": "100"}`)
+	})
+
+	mux.HandleFunc("/atomicassets/v1/schemas", func(res http.ResponseWriter, req *http.Request) {
+		assert.Equal(f.t, "mycol", req.URL.Query().Get("collection_whitelist"))
+		write(res, `[{"schema_name": "heroes", "format": [{"name": "name", "type": "string"}]}]`)
+	})
+
+	mux.HandleFunc("/atomicassets/v1/templates", func(res http.ResponseWriter, req *http.Request) {
+		q := req.URL.Query()
+		assert.Equal(f.t, "mycol", q.Get("collection_name"))
+		assert.Equal(f.t, "asc", q.Get("order"))
+
+		if f.round == 1 {
+			assert.Equal(f.t, "", q.Get("after"))
+			write(res, `[{"template_id": "100", "schema": {"schema_name": "heroes"}, "created_at_time": "500"}]`)
+			return
+		}
+
+		assert.Equal(f.t, "499", q.Get("after"))
+		write(res, `[{"template_id": "101", "schema": {"schema_name": "heroes"}, "created_at_time": "1900"}]`)
+	})
+
+	mux.HandleFunc("/atomicassets/v1/assets", func(res http.ResponseWriter, req *http.Request) {
+		q := req.URL.Query()
+		assert.Equal(f.t, "mycol", q.Get("collection_name"))
+		assert.Equal(f.t, "updated", q.Get("sort"))
+		assert.Equal(f.t, "desc", q.Get("order"))
+
+		old := `
+			{"asset_id": "3", "owner": "bob", "schema": {"schema_name": "heroes"}, "template": {"template_id": "100"}, "updated_at_time": "1200"},
+			{"asset_id": "1", "owner": "bob", "schema": {"schema_name": "heroes"}, "template": {"template_id": "100"}, "updated_at_time": "1000", "transferred_at_time": "900"}`
+
+		if f.round == 1 {
+			write(res, `[
+				{"asset_id": "2", "owner": "alice", "schema": {"schema_name": "heroes"}, "template": {"template_id": "100"}, "updated_at_time": "1100"},`+old+`]`)
+			return
+		}
+
+		write(res, `[
+			{"asset_id": "2", "owner": null, "burned_by_account": "alice", "burned_at_block": "50", "schema": {"schema_name": "heroes"}, "template": {"template_id": "100"}, "updated_at_time": "2000"},
+			{"asset_id": "4", "owner": "carol", "schema": {"schema_name": "heroes"}, "template": {"template_id": "101"}, "updated_at_time": "1900"},`+old+`]`)
+	})
+
+	mux.HandleFunc("/atomicassets/v1/transfers", func(res http.ResponseWriter, req *http.Request) {
+		q := req.URL.Query()
+		assert.Equal(f.t, "mycol", q.Get("collection_name"))
+
+		t1 := `{"transfer_id": "1", "sender_name": "alice", "recipient_name": "bob", "assets": [{"asset_id": "1"}], "created_at_time": "900"}`
+		if f.round == 1 {
+			assert.Equal(f.t, "", q.Get("after"))
+			write(res, `[`+t1+`]`)
+			return
+		}
+
+		assert.Equal(f.t, "899", q.Get("after"))
+		write(res, `[`+t1+`,
+			{"transfer_id": "2", "sender_name": "bob", "recipient_name": "dave", "assets": [{"asset_id": "3"}], "created_at_time": "1500"}
+		]`)
+	})
+
+	mux.HandleFunc("/atomicmarket/v2/sales", func(res http.ResponseWriter, req *http.Request) {
+		q := req.URL.Query()
+		assert.Equal(f.t, "mycol", q.Get("collection_name"))
+		assert.Equal(f.t, "updated", q.Get("sort"))
+
+		if f.fail {
+			res.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		s1 := `{"sale_id": "1", "seller": "bob", "state": 1, "updated_at_time": "1000"}`
+		if f.round == 1 {
+			write(res, `[`+s1+`]`)
+			return
+		}
+
+		write(res, `[
+			{"sale_id": "1", "seller": "bob", "buyer": "erin", "state": 3, "updated_at_time": "2100"},
+			{"sale_id": "2", "seller": "carol", "state": 1, "updated_at_time": "1800"},
+			{"sale_id": "0", "seller": "bob", "state": 2, "updated_at_time": "900"}
+		]`)
+	})
+
+	return mux
+}
+
+func ids[T any](items []T, id func(T) string) []string {
+	out := []string{}
+	for _, item := range items {
+		out = append(out, id(item))
+	}
+	return out
+}
+
+func assetID(a atomicasset.Asset) string { return a.ID }
+
+func TestMirror_Sync(t *testing.T) {
+	api := &fakeAPI{t: t, round: 1}
+	srv := httptest.NewServer(api.handler())
+	defer srv.Close()
+
+	m, err := Open(filepath.Join(t.TempDir(), "mirror.db"))
+	require.NoError(t, err)
+	defer m.Close()
+
+	ctx := context.Background()
+	client := atomicasset.New(srv.URL)
+
+	require.NoError(t, m.Sync(ctx, client, "mycol"))
+
+	c, err := m.Collection(ctx, "mycol")
+	require.NoError(t, err)
+	assert.Equal(t, "My Collection", c.Name)
+
+	_, err = m.Collection(ctx, "other")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	schemas, err := m.Schemas(ctx, "mycol")
+	require.NoError(t, err)
+	assert.Equal(t, []atomicasset.Schema{{Name: "heroes", Format: []atomicasset.SchemaFormat{{Name: "name", Type: "string"}}}}, schemas)
+
+	holders, err := m.Holders(ctx, "mycol")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{"alice": 1, "bob": 2}, holders)
+
+	cp, err := m.Checkpoint(ctx, "mycol", ResourceAssets)
+	require.NoError(t, err)
+	assert.Equal(t, time.UnixMilli(1200).UTC(), cp)
+
+	// Second sync only fetches what changed.
+	api.round = 2
+	require.NoError(t, m.Sync(ctx, client, "mycol"))
+
+	templates, err := m.Templates(ctx, "mycol")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"100", "101"}, ids(templates, func(t atomicasset.Template) string { return t.ID }))
+
+	holders, err = m.Holders(ctx, "mycol")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{"bob": 1, "carol": 1, "dave": 1}, holders)
+
+	assets, err := m.Assets(ctx, AssetQuery{Collection: "mycol"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"1", "3", "4"}, ids(assets, assetID))
+	assert.Equal(t, "dave", assets[1].Owner)
+
+	assets, err = m.Assets(ctx, AssetQuery{Collection: "mycol", IncludeBurned: true})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"1", "2", "3", "4"}, ids(assets, assetID))
+
+	assets, err = m.Assets(ctx, AssetQuery{TemplateID: "100", Owner: "bob"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"1"}, ids(assets, assetID))
+
+	transfers, err := m.Transfers(ctx, "3")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"2"}, ids(transfers, func(t atomicasset.Transfer) string { return t.ID }))
+
+	sales, err := m.Sales(ctx, "mycol", "")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"1", "2"}, ids(sales, func(s atomicasset.Sale) string { return s.ID }))
+	assert.Equal(t, atomicasset.SalesStateSold, sales[0].State)
+
+	sales, err = m.Sales(ctx, "mycol", atomicasset.SalesStateListed)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"2"}, ids(sales, func(s atomicasset.Sale) string { return s.ID }))
+
+	for resource, expected := range map[string]int64{
+		ResourceTemplates: 1900,
+		ResourceAssets:    2000,
+		ResourceTransfers: 1500,
+		ResourceSales:     2100,
+	} {
+		cp, err := m.Checkpoint(ctx, "mycol", resource)
+		require.NoError(t, err)
+		assert.Equal(t, time.UnixMilli(expected).UTC(), cp, resource)
+	}
+}
+
+func TestMirror_SyncRollback(t *testing.T) {
+	api := &fakeAPI{t: t, round: 1, fail: true}
+	srv := httptest.NewServer(api.handler())
+	defer srv.Close()
+
+	m, err := Open(":memory:")
+	require.NoError(t, err)
+	defer m.Close()
+
+	ctx := context.Background()
+	assert.Error(t, m.Sync(ctx, atomicasset.New(srv.URL), "mycol"))
+
+	assets, err := m.Assets(ctx, AssetQuery{IncludeBurned: true})
+	require.NoError(t, err)
+	assert.Empty(t, assets)
+
+	cp, err := m.Checkpoint(ctx, "mycol", ResourceAssets)
+	require.NoError(t, err)
+	assert.True(t, cp.IsZero())
+}
@@ -0,0 +1,170 @@
+package mirror
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"strings"
+
+	"github.com/eosswedenorg-go/atomicasset"
+)
+
+// AssetQuery filters the assets returned by Assets.
+// Empty fields match all assets.
+type AssetQuery struct {
+	Collection    string
+	Schema        string
+	TemplateID    string
+	Owner         string
+	IncludeBurned bool
+}
+
+// Collection returns a mirrored collection.
+func (m *Mirror) Collection(ctx context.Context, name string) (atomicasset.Collection, error) {
+	var c atomicasset.Collection
+	var data string
+
+	err := m.db.QueryRowContext(ctx, "SELECT json FROM collections WHERE collection_name = ?", name).Scan(&data)
+	if errors.Is(err, sql.ErrNoRows) {
+		return c, ErrNotFound
+	} else if err != nil {
+		return c, err
+	}
+	return c, json.Unmarshal([]byte(data), &c)
+}
+
+// Schemas returns the schemas in collection sorted by name.
+func (m *Mirror) Schemas(ctx context.Context, collection string) ([]atomicasset.Schema, error) {
+	return query[atomicasset.Schema](ctx, m.db,
+		"SELECT json FROM schemas WHERE collection_name = ? ORDER BY schema_name", collection)
+}
+
+// Templates returns the templates in collection sorted by id.
+func (m *Mirror) Templates(ctx context.Context, collection string) ([]atomicasset.Template, error) {
+	return query[atomicasset.Template](ctx, m.db,
+		"SELECT json FROM templates WHERE collection_name = ? ORDER BY CAST(template_id AS INTEGER)", collection)
+}
+
+// Assets returns the assets matching q sorted by id.
+//
+// Owner is the owner according to the latest synced asset or transfer,
+// whichever is newer.
+func (m *Mirror) Assets(ctx context.Context, q AssetQuery) ([]atomicasset.Asset, error) {
+	where := []string{}
+	args := []interface{}{}
+
+	for _, f := range []struct {
+		column string
+		value  string
+	}{
+		{"collection_name", q.Collection},
+		{"schema_name", q.Schema},
+		{"template_id", q.TemplateID},
+		{"owner", q.Owner},
+	} {
+		if len(f.value) > 0 {
+			where = append(where, f.column+" = ?")
+			args = append(args, f.value)
+		}
+	}
+
+	if !q.IncludeBurned {
+		where = append(where, "burned = 0")
+	}
+
+	stmt := "SELECT owner, json FROM assets"
+	if len(where) > 0 {
+		stmt += " WHERE " + strings.Join(where, " AND ")
+	}
+	stmt += " ORDER BY CAST(asset_id AS INTEGER)"
+
+	rows, err := m.db.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	assets := []atomicasset.Asset{}
+	for rows.Next() {
+		var owner, data string
+		if err := rows.Scan(&owner, &data); err != nil {
+			return nil, err
+		}
+
+		var a atomicasset.Asset
+		if err := json.Unmarshal([]byte(data), &a); err != nil {
+			return nil, err
+		}
+		a.Owner = owner
+		assets = append(assets, a)
+	}
+	return assets, rows.Err()
+}
+
+// Holders returns the number of assets (that are not burned)
+// each account holds in collection.
+func (m *Mirror) Holders(ctx context.Context, collection string) (map[string]int, error) {
+	rows, err := m.db.QueryContext(ctx,
+		"SELECT owner, COUNT(*) FROM assets WHERE collection_name = ? AND burned = 0 GROUP BY owner", collection)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	holders := map[string]int{}
+	for rows.Next() {
+		var owner string
+		var count int
+		if err := rows.Scan(&owner, &count); err != nil {
+			return nil, err
+		}
+		holders[owner] = count
+	}
+	return holders, rows.Err()
+}
+
+// Transfers returns the transfers of an asset, oldest first.
+func (m *Mirror) Transfers(ctx context.Context, assetID string) ([]atomicasset.Transfer, error) {
+	return query[atomicasset.Transfer](ctx, m.db, `SELECT t.json FROM transfers t
+		JOIN transfer_assets ta ON ta.transfer_id = t.transfer_id
+		WHERE ta.asset_id = ? ORDER BY t.created_at_time, CAST(t.transfer_id AS INTEGER)`, assetID)
+}
+
+// Sales returns the sales in collection with state (all states if empty),
+// most recently updated first.
+func (m *Mirror) Sales(ctx context.Context, collection string, state atomicasset.SalesState) ([]atomicasset.Sale, error) {
+	stmt := "SELECT json FROM sales WHERE collection_name = ?"
+	args := []interface{}{collection}
+	if len(state) > 0 {
+		stmt += " AND state = ?"
+		args = append(args, string(state))
+	}
+	stmt += " ORDER BY updated_at_time DESC, CAST(sale_id AS INTEGER) DESC"
+
+	return query[atomicasset.Sale](ctx, m.db, stmt, args...)
+}
+
+// query decodes the json column of each row returned by stmt.
+func query[T any](ctx context.Context, db *sql.DB, stmt string, args ...interface{}) ([]T, error) {
+	rows, err := db.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := []T{}
+	for rows.Next() {
+		var data string
+		if err := rows.Scan(&data); err != nil {
+			return nil, err
+		}
+
+		var item T
+		if err := json.Unmarshal([]byte(data), &item); err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	return items, rows.Err()
+}
@@ -0,0 +1,287 @@
+package mirror
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"github.com/eosswedenorg-go/atomicasset"
+)
+
+// pageLimit is the page size used when syncing.
+const pageLimit = 100
+
+// Sync fetches everything that changed in each collection since the last
+// sync. Each collection is synced in a single transaction, so a failed
+// sync leaves the mirror (and its checkpoints) as they were.
+func (m *Mirror) Sync(ctx context.Context, client *atomicasset.Client, collections ...string) error {
+	c := client.WithContext(ctx)
+
+	for _, name := range collections {
+		if err := m.syncCollection(ctx, c, name); err != nil {
+			return fmt.Errorf("mirror '%s': %w", name, err)
+		}
+	}
+	return nil
+}
+
+func (m *Mirror) syncCollection(ctx context.Context, c *atomicasset.Client, name string) error {
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	s := syncer{ctx: ctx, tx: tx, client: c, collection: name}
+	for _, fn := range []func() error{s.collectionInfo, s.schemas, s.templates, s.assets, s.transfers, s.sales} {
+		if err := fn(); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// syncer syncs one collection within a transaction.
+type syncer struct {
+	ctx        context.Context
+	tx         *sql.Tx
+	client     *atomicasset.Client
+	collection string
+}
+
+func (s *syncer) exec(query string, args ...interface{}) error {
+	_, err := s.tx.ExecContext(s.ctx, query, args...)
+	return err
+}
+
+// store executes an insert where the last column is v encoded as json.
+func (s *syncer) store(query string, v interface{}, args ...interface{}) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return s.exec(query, append(args, string(b))...)
+}
+
+func (s *syncer) checkpoint(resource string) (int64, error) {
+	return checkpoint(s.ctx, s.tx, s.collection, resource)
+}
+
+func (s *syncer) setCheckpoint(resource string, ms int64) error {
+	return s.exec(`INSERT INTO checkpoints (collection_name, resource, time) VALUES (?, ?, ?)
+		ON CONFLICT (collection_name, resource) DO UPDATE SET time = excluded.time`, s.collection, resource, ms)
+}
+
+// after returns the after parameter for a checkpoint. It overlaps the
+// checkpoint by one millisecond so rows created in the same millisecond
+// are not missed (they are upserted again).
+func after(ms int64) int {
+	if ms < 1 {
+		return 0
+	}
+	return int(ms - 1)
+}
+
+func (s *syncer) collectionInfo() error {
+	resp, err := s.client.GetCollection(s.collection)
+	if err != nil {
+		return err
+	}
+
+	c := resp.Data
+	return s.store(`INSERT OR REPLACE INTO collections (collection_name, author, name, created_at_time, json) VALUES (?, ?, ?, ?, ?)`, c, c.CollectionName, c.Author, c.Name, int64(c.CreatedAtTime))
+}
+
+func (s *syncer) schemas() error {
+	return pages(s.ctx, func(page int) ([]atomicasset.Schema, error) {
+		resp, err := s.client.GetSchemas(atomicasset.SchemasRequestParams{
+			Whitelist: atomicasset.ReqList[string]{s.collection},
+			Page:      page,
+			Limit:     pageLimit,
+		})
+		return resp.Data, err
+	}, func(sc atomicasset.Schema) (bool, error) {
+		return true, s.store(`INSERT OR REPLACE INTO schemas (collection_name, schema_name, created_at_time, json) VALUES (?, ?, ?, ?)`, sc, s.collection, sc.Name, int64(sc.CreatedAtTime))
+	})
+}
+
+// templates fetches the templates created since the last checkpoint.
+func (s *syncer) templates() error {
+	cp, err := s.checkpoint(ResourceTemplates)
+	if err != nil {
+		return err
+	}
+
+	latest := cp
+	err = pages(s.ctx, func(page int) ([]atomicasset.Template, error) {
+		resp, err := s.client.GetTemplates(atomicasset.TemplateRequestParams{
+			CollectionName: s.collection,
+			After:          after(cp),
+			Page:           page,
+			Limit:          pageLimit,
+			Order:          atomicasset.SortAscending,
+			Sort:           atomicasset.TemplateSortCreated,
+		})
+		return resp.Data, err
+	}, func(t atomicasset.Template) (bool, error) {
+		if ms := int64(t.CreatedAtTime); ms > latest {
+			latest = ms
+		}
+		return true, s.store(`INSERT OR REPLACE INTO templates (template_id, collection_name, schema_name, created_at_time, json) VALUES (?, ?, ?, ?, ?)`, t, t.ID, s.collection, t.Schema.Name, int64(t.CreatedAtTime))
+	})
+	if err != nil {
+		return err
+	}
+	return s.setCheckpoint(ResourceTemplates, latest)
+}
+
+// assets fetches the assets updated (minted, transferred, changed or
+// burned) since the last checkpoint, newest first.
+func (s *syncer) assets() error {
+	cp, err := s.checkpoint(ResourceAssets)
+	if err != nil {
+		return err
+	}
+
+	latest := cp
+	err = pages(s.ctx, func(page int) ([]atomicasset.Asset, error) {
+		resp, err := s.client.GetAssets(atomicasset.AssetsRequestParams{
+			CollectionName: s.collection,
+			Page:           page,
+			Limit:          pageLimit,
+			Order:          atomicasset.SortDescending,
+			Sort:           "updated",
+		})
+		return resp.Data, err
+	}, func(a atomicasset.Asset) (bool, error) {
+		updated := int64(a.UpdatedAtTime)
+		if updated < cp {
+			return false, nil
+		}
+
+		if updated > latest {
+			latest = updated
+		}
+
+		burned := len(a.Owner) < 1 || len(a.BurnedAtBlock) > 0
+		return true, s.store(`INSERT OR REPLACE INTO assets
+			(asset_id, collection_name, schema_name, template_id, owner, burned, updated_at_time, transferred_at_time, json)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`, a, a.ID, s.collection, a.Schema.Name, a.Template.ID, a.Owner, burned, updated, int64(a.TransferedAtTime))
+	})
+	if err != nil {
+		return err
+	}
+	return s.setCheckpoint(ResourceAssets, latest)
+}
+
+// transfers fetches the transfers created since the last checkpoint and
+// moves the assets to the recipient if the transfer is newer than the
+// asset's last known transfer.
+func (s *syncer) transfers() error {
+	cp, err := s.checkpoint(ResourceTransfers)
+	if err != nil {
+		return err
+	}
+
+	latest := cp
+	err = pages(s.ctx, func(page int) ([]atomicasset.Transfer, error) {
+		resp, err := s.client.GetTransfers(atomicasset.TransferRequestParams{
+			CollectionName: atomicasset.ReqList[string]{s.collection},
+			After:          after(cp),
+			Page:           page,
+			Limit:          pageLimit,
+			Order:          atomicasset.SortAscending,
+		})
+		return resp.Data, err
+	}, func(t atomicasset.Transfer) (bool, error) {
+		created := int64(t.CreatedAtTime)
+		if created > latest {
+			latest = created
+		}
+
+		err := s.store(`INSERT OR REPLACE INTO transfers (transfer_id, collection_name, sender, recipient, created_at_time, json) VALUES (?, ?, ?, ?, ?, ?)`, t, t.ID, s.collection, t.Sender, t.Recipient, created)
+		if err != nil {
+			return false, err
+		}
+
+		for _, a := range t.Assets {
+			err = s.exec(`INSERT OR IGNORE INTO transfer_assets (transfer_id, asset_id) VALUES (?, ?)`, t.ID, a.ID)
+			if err != nil {
+				return false, err
+			}
+
+			err = s.exec(`UPDATE assets SET owner = ?, transferred_at_time = ? WHERE asset_id = ? AND burned = 0 AND transferred_at_time < ?`,
+				t.Recipient, created, a.ID, created)
+			if err != nil {
+				return false, err
+			}
+		}
+		return true, nil
+	})
+	if err != nil {
+		return err
+	}
+	return s.setCheckpoint(ResourceTransfers, latest)
+}
+
+// sales fetches the sales updated since the last checkpoint, newest first.
+func (s *syncer) sales() error {
+	cp, err := s.checkpoint(ResourceSales)
+	if err != nil {
+		return err
+	}
+
+	latest := cp
+	err = pages(s.ctx, func(page int) ([]atomicasset.Sale, error) {
+		resp, err := s.client.GetSales(atomicasset.SalesRequestParams{
+			CollectionName: s.collection,
+			Page:           page,
+			Limit:          pageLimit,
+			Order:          atomicasset.SortDescending,
+			Sort:           atomicasset.SaleSortUpdated,
+		})
+		return resp.Data, err
+	}, func(sale atomicasset.Sale) (bool, error) {
+		updated := int64(sale.UpdatedAtTime)
+		if updated < cp {
+			return false, nil
+		}
+
+		if updated > latest {
+			latest = updated
+		}
+
+		return true, s.store(`INSERT OR REPLACE INTO sales (sale_id, collection_name, seller, buyer, state, updated_at_time, json) VALUES (?, ?, ?, ?, ?, ?, ?)`, sale, sale.ID, s.collection, sale.Seller, sale.Buyer, string(sale.State), updated)
+	})
+	if err != nil {
+		return err
+	}
+	return s.setCheckpoint(ResourceSales, latest)
+}
+
+// pages calls fetch for each page (starting at 1) and each for every item
+// until a page returns less than pageLimit items or each returns false.
+func pages[T any](ctx context.Context, fetch func(page int) ([]T, error), each func(T) (bool, error)) error {
+	for page := 1; ; page++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		items, err := fetch(page)
+		if err != nil {
+			return err
+		}
+
+		for _, item := range items {
+			more, err := each(item)
+			if err != nil || !more {
+				return err
+			}
+		}
+
+		if len(items) < pageLimit {
+			return nil
+		}
+	}
+}
@@ -7,16 +7,16 @@ import (
 // Types
 
 type Offer struct {
-	ID                  string  `json:"offer_id"`
-	Contract            string  `json:"contract"`
-	Sender              string  `json:"sender_name"`
-	Recipient           string  `json:"recipient_name"`
-	Memo                string  `json:"memo"`
-	State               int64   `json:"state"`
-	IsSenderContract    bool    `json:"is_sender_contract"`
-	IsRecipientContract bool    `json:"is_recipient_contract"`
-	SenderAssets        []Asset `json:"sender_assets"`
-	RecipientAssets     []Asset `json:"recipient_assets"`
+	ID                  string     `json:"offer_id"`
+	Contract            string     `json:"contract"`
+	Sender              string     `json:"sender_name"`
+	Recipient           string     `json:"recipient_name"`
+	Memo                string     `json:"memo"`
+	State               OfferState `json:"state"`
+	IsSenderContract    bool       `json:"is_sender_contract"`
+	IsRecipientContract bool       `json:"is_recipient_contract"`
+	SenderAssets        []Asset    `json:"sender_assets"`
+	RecipientAssets     []Asset    `json:"recipient_assets"`
 
 	UpdatedAtBlock string        `json:"updated_at_block"`
 	UpdatedAtTime  unixtime.Time `json:"updated_at_time"`
@@ -25,8 +25,7 @@ type Offer struct {
 	CreatedAtTime  unixtime.Time `json:"created_at_time"`
 }
 
-// Request Parameters
-
+// OfferState is stored as a string for the same reason as SalesState.
 type OfferState string
 
 const (
@@ -38,6 +37,32 @@ const (
 	OfferStateCanceled = OfferState("5")
 )
 
+var offerStateNames = []string{"pending", "invalid", "unknown", "accepted", "declined", "canceled"}
+
+// String returns the name of the state.
+func (s OfferState) String() string {
+	return stateString("OfferState", string(s), offerStateNames)
+}
+
+// Validate returns an error if s is not a known offer state.
+func (s OfferState) Validate() error {
+	return validateState("offer state", string(s), offerStateNames)
+}
+
+func (s OfferState) MarshalJSON() ([]byte, error) {
+	return marshalState(string(s))
+}
+
+func (s *OfferState) UnmarshalJSON(b []byte) error {
+	v, err := unmarshalState(b)
+	if err == nil {
+		*s = OfferState(v)
+	}
+	return err
+}
+
+// Request Parameters
+
 type OfferSortColumn string
 
 const (
@@ -176,7 +176,7 @@ func TestClient_GetOffers(t *testing.T) {
 		Sender:    "wzzwk.wam",
 		Recipient: "atomicmarket",
 		Memo:      "sale",
-		State:     0,
+		State:     OfferStatePending,
 		SenderAssets: []Asset{
 			{
 				ID:             "1099814256937",
@@ -456,7 +456,7 @@ func TestClient_GetOffer(t *testing.T) {
 		Sender:    "sellotronwax",
 		Recipient: "atomicmarket",
 		Memo:      "sale",
-		State:     0,
+		State:     OfferStatePending,
 		SenderAssets: []Asset{
 			{
 				ID:             "1099838096677",
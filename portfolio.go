@@ -0,0 +1,327 @@
+package atomicasset
+
+import (
+	"context"
+	"fmt"
+	"sort"
+)
+
+// Types
+
+// ValuationMethod selects which price is used to value an asset.
+type ValuationMethod string
+
+const (
+	// ValuationSuggestedMedian uses the suggested median of the template's sales.
+	ValuationSuggestedMedian = ValuationMethod("suggested_median")
+
+	// ValuationAverage uses the average of the template's sales.
+	ValuationAverage = ValuationMethod("average")
+
+	// ValuationFloor uses the lowest price of the template's active sales.
+	ValuationFloor = ValuationMethod("floor")
+)
+
+// PortfolioOptions controls how a portfolio is valued.
+type PortfolioOptions struct {
+	// Method defaults to ValuationSuggestedMedian.
+	Method ValuationMethod
+
+	// Symbol only values assets in this token symbol if set.
+	Symbol string
+
+	// CollectionWhitelist only includes assets from these collections if set.
+	CollectionWhitelist []string
+}
+
+// AssetValuation is an asset and its value in each token it has a price in.
+type AssetValuation struct {
+	Asset  Asset
+	Values []Token
+}
+
+// CollectionValuation is the value of all priced assets in a collection.
+type CollectionValuation struct {
+	Collection Collection
+	Assets     int
+	Totals     []Token
+
+	// Inventory holds the collection's stats from the price inventory endpoint.
+	Inventory []PriceAsset
+}
+
+// Portfolio is the valuation of all assets owned by an account.
+type Portfolio struct {
+	Account     string
+	Method      ValuationMethod
+	Assets      []AssetValuation
+	Collections []CollectionValuation
+	Totals      []Token
+
+	// Unpriced assets have no price for the valuation method.
+	Unpriced []Asset
+
+	// Untradeable assets are not transferable and can not be sold.
+	Untradeable []Asset
+}
+
+// templatePriceKey identifies a template price in one token.
+type templatePriceKey struct {
+	TemplateID string
+	Symbol     string
+}
+
+// Portfolio fetches all assets owned by account and values them using the
+// template prices (or active sales for ValuationFloor) of each collection.
+func (c *Client) Portfolio(ctx context.Context, account string, opts PortfolioOptions) (Portfolio, error) {
+	c = c.WithContext(ctx)
+
+	method := opts.Method
+	if len(method) < 1 {
+		method = ValuationSuggestedMedian
+	}
+
+	switch method {
+	case ValuationSuggestedMedian, ValuationAverage, ValuationFloor:
+	default:
+		return Portfolio{}, fmt.Errorf("invalid valuation method '%s'", method)
+	}
+
+	assets, err := fetchAll(ctx, func(page int) ([]Asset, error) {
+		resp, err := c.GetAssets(AssetsRequestParams{
+			Owner:               account,
+			CollectionWhitelist: opts.CollectionWhitelist,
+			Page:                page,
+			Limit:               pageLimit,
+			Order:               SortAscending,
+			Sort:                AssetSortID,
+		})
+		return resp.Data, err
+	})
+	if err != nil {
+		return Portfolio{}, err
+	}
+
+	collections := []string{}
+	seen := map[string]bool{}
+	for _, a := range assets {
+		if name := a.Collection.CollectionName; !seen[name] {
+			seen[name] = true
+			collections = append(collections, name)
+		}
+	}
+
+	prices := map[templatePriceKey]Token{}
+	for _, name := range collections {
+		if err := c.collectionPrices(ctx, name, method, opts.Symbol, prices); err != nil {
+			return Portfolio{}, err
+		}
+	}
+
+	inventory, err := c.GetPriceInventory(account, PriceInventoryRequestParams{CollectionWhitelist: opts.CollectionWhitelist})
+	if err != nil {
+		return Portfolio{}, err
+	}
+
+	p := valuePortfolio(assets, prices, inventory.Data)
+	p.Account = account
+	p.Method = method
+	return p, nil
+}
+
+// collectionPrices adds the price of each template in collection to prices.
+func (c *Client) collectionPrices(ctx context.Context, collection string, method ValuationMethod, symbol string, prices map[templatePriceKey]Token) error {
+	if method == ValuationFloor {
+		sales, err := fetchAll(ctx, func(page int) ([]Sale, error) {
+			resp, err := c.GetSalesGroupByTemplate(SalesTemplateRequestParams{
+				CollectionName: collection,
+				Symbol:         symbol,
+				Page:           page,
+				Limit:          pageLimit,
+			})
+			return resp.Data, err
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, s := range sales {
+			if len(s.Assets) < 1 || len(s.Assets[0].Template.ID) < 1 {
+				continue
+			}
+			setFloorPrice(prices, templatePriceKey{s.Assets[0].Template.ID, s.Price.Symbol}, s.Price)
+		}
+		return nil
+	}
+
+	templates, err := fetchAll(ctx, func(page int) ([]PriceTemplate, error) {
+		resp, err := c.GetPriceTemplates(PriceTemplatesRequestParams{
+			Collection: collection,
+			Symbol:     symbol,
+			Page:       page,
+			Limit:      pageLimit,
+		})
+		return resp.Data, err
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, t := range templates {
+		amount := t.SuggestedMedian
+		if method == ValuationAverage {
+			amount = t.Average
+		}
+
+		if len(amount) < 1 {
+			continue
+		}
+
+		prices[templatePriceKey{t.TemplateID, t.TokenSymbol}] = Token{
+			Contract:  t.TokenContract,
+			Symbol:    t.TokenSymbol,
+			Precision: int(t.TokenPrecision),
+			Amount:    amount,
+		}
+	}
+	return nil
+}
+
+// setFloorPrice stores price if it is lower than the current price for key.
+func setFloorPrice(prices map[templatePriceKey]Token, key templatePriceKey, price Token) {
+	units, err := price.Units()
+	if err != nil {
+		return
+	}
+
+	if current, ok := prices[key]; ok {
+		if n, err := current.Units(); err == nil && n <= units {
+			return
+		}
+	}
+	prices[key] = price
+}
+
+// valuePortfolio values assets using prices and groups them by collection.
+func valuePortfolio(assets []Asset, prices map[templatePriceKey]Token, inventory []PriceInventory) Portfolio {
+	p := Portfolio{
+		Assets:      []AssetValuation{},
+		Collections: []CollectionValuation{},
+		Unpriced:    []Asset{},
+		Untradeable: []Asset{},
+	}
+
+	symbols := []string{}
+	seen := map[string]bool{}
+	for key := range prices {
+		if !seen[key.Symbol] {
+			seen[key.Symbol] = true
+			symbols = append(symbols, key.Symbol)
+		}
+	}
+	sort.Strings(symbols)
+
+	totals := tokenTotals{}
+	collections := map[string]*CollectionValuation{}
+	collectionTotals := map[string]tokenTotals{}
+	order := []string{}
+
+	for _, a := range assets {
+		if !a.IsTransferable {
+			p.Untradeable = append(p.Untradeable, a)
+			continue
+		}
+
+		values := []Token{}
+		for _, symbol := range symbols {
+			if price, ok := prices[templatePriceKey{a.Template.ID, symbol}]; ok && len(a.Template.ID) > 0 {
+				values = append(values, price)
+			}
+		}
+
+		if len(values) < 1 {
+			p.Unpriced = append(p.Unpriced, a)
+			continue
+		}
+
+		p.Assets = append(p.Assets, AssetValuation{Asset: a, Values: values})
+
+		name := a.Collection.CollectionName
+		cv, ok := collections[name]
+		if !ok {
+			cv = &CollectionValuation{Collection: a.Collection}
+			collections[name] = cv
+			collectionTotals[name] = tokenTotals{}
+			order = append(order, name)
+		}
+
+		cv.Assets++
+		for _, v := range values {
+			totals.add(v)
+			collectionTotals[name].add(v)
+		}
+	}
+
+	for _, name := range order {
+		cv := collections[name]
+		cv.Totals = collectionTotals[name].tokens()
+		cv.Inventory = []PriceAsset{}
+		for _, inv := range inventory {
+			if inv.Collection.CollectionName == name {
+				cv.Inventory = inv.Prices
+			}
+		}
+		p.Collections = append(p.Collections, *cv)
+	}
+
+	p.Totals = totals.tokens()
+	return p
+}
+
+// tokenTotals sums token amounts per symbol.
+type tokenTotals map[string]Token
+
+func (t tokenTotals) add(v Token) {
+	n, err := v.Units()
+	if err != nil {
+		return
+	}
+
+	total, ok := t[v.Symbol]
+	if !ok {
+		total = v.withUnits(0)
+	}
+
+	sum, _ := total.Units()
+	t[v.Symbol] = total.withUnits(sum + n)
+}
+
+// tokens returns the totals sorted by symbol.
+func (t tokenTotals) tokens() []Token {
+	tokens := []Token{}
+	for _, v := range t {
+		tokens = append(tokens, v)
+	}
+	sort.Slice(tokens, func(i, j int) bool { return tokens[i].Symbol < tokens[j].Symbol })
+	return tokens
+}
+
+// Value returns the asset's value in symbol.
+func (v AssetValuation) Value(symbol string) (Token, bool) {
+	for _, t := range v.Values {
+		if t.Symbol == symbol {
+			return t, true
+		}
+	}
+	return Token{}, false
+}
+
+// Total returns the portfolio's total value in symbol.
+func (p Portfolio) Total(symbol string) Token {
+	for _, t := range p.Totals {
+		if t.Symbol == symbol {
+			return t
+		}
+	}
+	return Token{Symbol: symbol, Amount: "0"}
+}
@@ -0,0 +1,139 @@
+package atomicasset
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newPortfolioTestServer(t *testing.T) *httptest.Server {
+	mux := http.NewServeMux()
+
+	write := func(res http.ResponseWriter, payload string) {
+		res.Header().Add("Content-type", "application/json; charset=utf-8")
+		_, err := res.Write([]byte(payload))
+		assert.NoError(t, err)
+	}
+
+	mux.HandleFunc("/atomicassets/v1/assets", func(res http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "alice", req.URL.Query().Get("owner"))
+		assert.Equal(t, "1", req.URL.Query().Get("page"))
+
+		write(res, `{"success": true, "data": [
+			{"asset_id": "1", "is_transferable": true, "collection": {"collection_name": "cola"}, "template": {"template_id": "10"}},
+			{"asset_id": "2", "is_transferable": true, "collection": {"collection_name": "cola"}, "template": {"template_id": "10"}},
+			{"asset_id": "3", "is_transferable": true, "collection": {"collection_name": "colb"}, "template": {"template_id": "20"}},
+			{"asset_id": "4", "is_transferable": true, "collection": {"collection_name": "colb"}, "template": {"template_id": "21"}},
+			{"asset_id": "5", "is_transferable": true, "collection": {"collection_name": "colb"}},
+			{"asset_id": "6", "is_transferable": false, "collection": {"collection_name": "cola"}, "template": {"template_id": "10"}}
+		], "query_time": 0}`)
+	})
+
+	mux.HandleFunc("/atomicassets/v1/prices/templates", func(res http.ResponseWriter, req *http.Request) {
+		switch req.URL.Query().Get("collection_name") {
+		case "cola":
+			write(res, `{"success": true, "data": [
+				{"collection_name": "cola", "template_id": "10", "token_symbol": "WAX", "token_precision": 8, "token_contract": "eosio.token",
+				 "median": "100", "average": "120", "suggested_median": "110", "suggested_average": "115"}
+			], "query_time": 0}`)
+		case "colb":
+			write(res, `{"success": true, "data": [
+				{"collection_name": "colb", "template_id": "20", "token_symbol": "WAX", "token_precision": 8, "token_contract": "eosio.token",
+				 "median": "50", "average": "60", "suggested_median": "55", "suggested_average": "58"}
+			], "query_time": 0}`)
+		default:
+			t.Errorf("unexpected collection '%s'", req.URL.Query().Get("collection_name"))
+		}
+	})
+
+	mux.HandleFunc("/atomicmarket/v1/sales/templates", func(res http.ResponseWriter, req *http.Request) {
+		switch req.URL.Query().Get("collection_name") {
+		case "cola":
+			write(res, `{"success": true, "data": [
+				{"sale_id": "1", "price": {"token_contract": "eosio.token", "token_symbol": "WAX", "token_precision": 8, "amount": "90"},
+				 "assets": [{"asset_id": "100", "template": {"template_id": "10"}}]}
+			], "query_time": 0}`)
+		default:
+			write(res, `{"success": true, "data": [], "query_time": 0}`)
+		}
+	})
+
+	mux.HandleFunc("/atomicassets/v1/prices/inventory/alice", func(res http.ResponseWriter, req *http.Request) {
+		write(res, `{"success": true, "data": {"collections": [
+			{"collection": {"collection_name": "cola"}, "prices": [{"token_symbol": "WAX", "median": "300"}]}
+		]}, "query_time": 0}`)
+	})
+
+	return httptest.NewServer(mux)
+}
+
+func assetIDs(assets []Asset) []string {
+	ids := []string{}
+	for _, a := range assets {
+		ids = append(ids, a.ID)
+	}
+	return ids
+}
+
+func TestClient_Portfolio(t *testing.T) {
+	srv := newPortfolioTestServer(t)
+	defer srv.Close()
+
+	p, err := New(srv.URL).Portfolio(context.Background(), "alice", PortfolioOptions{})
+	require.NoError(t, err)
+
+	assert.Equal(t, "alice", p.Account)
+	assert.Equal(t, ValuationSuggestedMedian, p.Method)
+
+	require.Len(t, p.Assets, 3)
+	value, ok := p.Assets[0].Value("WAX")
+	require.True(t, ok)
+	assert.Equal(t, Token{Contract: "eosio.token", Symbol: "WAX", Precision: 8, Amount: "110"}, value)
+
+	assert.Equal(t, []string{"4", "5"}, assetIDs(p.Unpriced))
+	assert.Equal(t, []string{"6"}, assetIDs(p.Untradeable))
+
+	assert.Equal(t, []Token{{Contract: "eosio.token", Symbol: "WAX", Precision: 8, Amount: "275"}}, p.Totals)
+	assert.Equal(t, "275", p.Total("WAX").Amount)
+	assert.Equal(t, "0", p.Total("TLM").Amount)
+
+	require.Len(t, p.Collections, 2)
+	assert.Equal(t, "cola", p.Collections[0].Collection.CollectionName)
+	assert.Equal(t, 2, p.Collections[0].Assets)
+	assert.Equal(t, "220", p.Collections[0].Totals[0].Amount)
+	assert.Equal(t, []PriceAsset{{TokenSymbol: "WAX", Median: "300"}}, p.Collections[0].Inventory)
+	assert.Equal(t, "colb", p.Collections[1].Collection.CollectionName)
+	assert.Equal(t, "55", p.Collections[1].Totals[0].Amount)
+	assert.Equal(t, []PriceAsset{}, p.Collections[1].Inventory)
+}
+
+func TestClient_Portfolio_Methods(t *testing.T) {
+	srv := newPortfolioTestServer(t)
+	defer srv.Close()
+
+	p, err := New(srv.URL).Portfolio(context.Background(), "alice", PortfolioOptions{Method: ValuationAverage})
+	require.NoError(t, err)
+	assert.Equal(t, "300", p.Total("WAX").Amount)
+
+	p, err = New(srv.URL).Portfolio(context.Background(), "alice", PortfolioOptions{Method: ValuationFloor})
+	require.NoError(t, err)
+	assert.Equal(t, "180", p.Total("WAX").Amount)
+	assert.Equal(t, []string{"3", "4", "5"}, assetIDs(p.Unpriced))
+
+	_, err = New(srv.URL).Portfolio(context.Background(), "alice", PortfolioOptions{Method: "max"})
+	assert.EqualError(t, err, "invalid valuation method 'max'")
+}
+
+func TestSetFloorPrice(t *testing.T) {
+	prices := map[templatePriceKey]Token{}
+	key := templatePriceKey{"1", "WAX"}
+
+	setFloorPrice(prices, key, Token{Symbol: "WAX", Amount: "20"})
+	setFloorPrice(prices, key, Token{Symbol: "WAX", Amount: "10"})
+	setFloorPrice(prices, key, Token{Symbol: "WAX", Amount: "15"})
+	assert.Equal(t, "10", prices[key].Amount)
+}
@@ -3,6 +3,8 @@ package atomicasset
 import (
 	"fmt"
 	"strings"
+
+	"github.com/sonh/qs"
 )
 
 type SortOrder string
@@ -41,6 +43,15 @@ type ReqList[T any] []T
 func (l ReqList[T]) EncodeParam() (string, error) {
 	f := []string{}
 	for _, v := range l {
+		// Use the elements own encoding if it has one.
+		if p, ok := any(v).(qs.QueryParamEncoder); ok {
+			s, err := p.EncodeParam()
+			if err != nil {
+				return "", err
+			}
+			f = append(f, s)
+			continue
+		}
 		f = append(f, fmt.Sprint(v))
 	}
 	return strings.Join(f, ","), nil
@@ -3,7 +3,6 @@ package atomicasset
 import (
 	"encoding/json"
 	"fmt"
-	"strconv"
 
 	"github.com/eosswedenorg-go/unixtime"
 )
@@ -22,13 +21,27 @@ const (
 	SalesStateInvalid  = SalesState("4")
 )
 
-// And for json, we need to parse the value as and integer
-// and then convert it to string.
+var salesStateNames = []string{"waiting", "listed", "canceled", "sold", "invalid"}
+
+// String returns the name of the state.
+func (s SalesState) String() string {
+	return stateString("SalesState", string(s), salesStateNames)
+}
+
+// Validate returns an error if s is not a known sale state.
+func (s SalesState) Validate() error {
+	return validateState("sale state", string(s), salesStateNames)
+}
+
+// And for json, we need to encode the value as an integer.
+func (s SalesState) MarshalJSON() ([]byte, error) {
+	return marshalState(string(s))
+}
+
+// And parse the integer and then convert it to string.
 func (s *SalesState) UnmarshalJSON(b []byte) error {
-	var n int64
-	err := json.Unmarshal(b, &n)
+	v, err := unmarshalState(b)
 	if err == nil {
-		v := strconv.FormatInt(n, 10)
 		*s = SalesState(v)
 	}
 	return err
@@ -48,6 +61,7 @@ type Sale struct {
 	MakerMarketplace string      `json:"maker_marketplace,omitempty"`
 	TakerMarketplace string      `json:"taker_marketplace,omitempty"`
 	Collection       Collection  `json:"collection"`
+	CollectionFee    *float64    `json:"collection_fee,omitempty"`
 	IsSellerContract bool        `json:"is_seller_contract"`
 	State            SalesState  `json:"state"`
 
@@ -194,6 +208,18 @@ func (c *Client) GetSales(params SalesRequestParams) (SalesResponse, error) {
 	return resp, err
 }
 
+// GetSalesCount fetches "/atomicmarket/v2/sales/_count" from API
+func (c *Client) GetSalesCount(params SalesRequestParams) (CountResponse, error) {
+	var resp CountResponse
+
+	r, err := c.fetch("GET", "/atomicmarket/v2/sales/_count", params, &resp.APIResponse)
+	if err == nil {
+		// Parse json
+		err = r.Unmarshal(&resp)
+	}
+	return resp, err
+}
+
 func (c *Client) GetSalesGroupByTemplate(params SalesTemplateRequestParams) (SalesResponse, error) {
 	var resp SalesResponse
 
@@ -0,0 +1,342 @@
+// Package serialization implements the binary attribute format used by the
+// atomicassets contract to store asset, template and collection data.
+//
+// Attributes are written in schema order as a varint identifier (the index of
+// the attribute in the format plus 4) followed by the value. Integers are
+// varint encoded (zigzag for signed types), fixed types are little endian,
+// strings and byte arrays are prefixed with a varint length and arrays are
+// prefixed with a varint element count.
+package serialization
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+
+	"github.com/eosswedenorg-go/atomicasset"
+	"github.com/eosswedenorg-go/atomicasset/internal/base58"
+)
+
+// Identifiers below this value are reserved by the contract.
+const reservedIdentifiers = 4
+
+// ErrUnexpectedEnd is returned when the data ends in the middle of a value.
+var ErrUnexpectedEnd = errors.New("serialization: unexpected end of data")
+
+// Serialize encodes data using format.
+//
+// Values are converted with atomicasset.DecodeAttributes, so they can be
+// given as any type accepted by the decoder (for example json numbers,
+// numeric strings or go integers).
+func Serialize(data map[string]interface{}, format []atomicasset.SchemaFormat) ([]byte, error) {
+	attrs, err := atomicasset.DecodeAttributes(data, format)
+	if err != nil {
+		return nil, err
+	}
+
+	buf := []byte{}
+	for i, f := range format {
+		attr, ok := attrs[f.Name]
+		if !ok {
+			continue
+		}
+
+		buf = appendUvarint(buf, uint64(i+reservedIdentifiers))
+		buf, err = appendValue(buf, f.Type, attr.Value)
+		if err != nil {
+			return nil, fmt.Errorf("serialization: attribute '%s': %s", f.Name, err)
+		}
+	}
+	return buf, nil
+}
+
+// Deserialize decodes data using format.
+//
+// Values are returned as the go types documented on atomicasset.Attribute.
+func Deserialize(data []byte, format []atomicasset.SchemaFormat) (map[string]interface{}, error) {
+	r := &reader{data: data}
+	obj := map[string]interface{}{}
+
+	for r.pos < len(r.data) {
+		id, err := r.uvarint()
+		if err != nil {
+			return nil, err
+		}
+
+		// The reference implementation terminates objects with a zero identifier.
+		if id == 0 {
+			break
+		}
+
+		if id < reservedIdentifiers || id-reservedIdentifiers >= uint64(len(format)) {
+			return nil, fmt.Errorf("serialization: unknown attribute identifier %d", id)
+		}
+
+		f := format[id-reservedIdentifiers]
+		obj[f.Name], err = r.value(f.Type)
+		if err != nil {
+			return nil, fmt.Errorf("serialization: attribute '%s': %w", f.Name, err)
+		}
+	}
+	return obj, nil
+}
+
+// Encoding
+
+func appendUvarint(buf []byte, n uint64) []byte {
+	b := make([]byte, binary.MaxVarintLen64)
+	return append(buf, b[:binary.PutUvarint(b, n)]...)
+}
+
+func zigzagEncode(n int64) uint64 {
+	return uint64(n<<1) ^ uint64(n>>63)
+}
+
+func zigzagDecode(n uint64) int64 {
+	return int64(n>>1) ^ -int64(n&1)
+}
+
+func fixedSize(typ string) int {
+	switch typ {
+	case "fixed8":
+		return 1
+	case "fixed16":
+		return 2
+	case "fixed32":
+		return 4
+	case "fixed64":
+		return 8
+	}
+	return 0
+}
+
+func appendValue(buf []byte, typ string, v interface{}) ([]byte, error) {
+	if strings.HasSuffix(typ, "[]") {
+		elem := strings.TrimSuffix(typ, "[]")
+		rv := reflect.ValueOf(v)
+		if rv.Kind() != reflect.Slice {
+			return nil, fmt.Errorf("expected array, got %T", v)
+		}
+
+		var err error
+		buf = appendUvarint(buf, uint64(rv.Len()))
+		for i := 0; i < rv.Len(); i++ {
+			buf, err = appendValue(buf, elem, rv.Index(i).Interface())
+			if err != nil {
+				return nil, err
+			}
+		}
+		return buf, nil
+	}
+
+	switch typ {
+	case "int8", "int16", "int32", "int64":
+		return appendUvarint(buf, zigzagEncode(v.(int64))), nil
+	case "uint8", "uint16", "uint32", "uint64":
+		return appendUvarint(buf, v.(uint64)), nil
+	case "fixed8", "fixed16", "fixed32", "fixed64":
+		b := make([]byte, 8)
+		binary.LittleEndian.PutUint64(b, v.(uint64))
+		return append(buf, b[:fixedSize(typ)]...), nil
+	case "float":
+		b := make([]byte, 4)
+		binary.LittleEndian.PutUint32(b, math.Float32bits(float32(v.(float64))))
+		return append(buf, b...), nil
+	case "double":
+		b := make([]byte, 8)
+		binary.LittleEndian.PutUint64(b, math.Float64bits(v.(float64)))
+		return append(buf, b...), nil
+	case "bool":
+		if v.(bool) {
+			return append(buf, 1), nil
+		}
+		return append(buf, 0), nil
+	case "string", "image":
+		s := v.(string)
+		buf = appendUvarint(buf, uint64(len(s)))
+		return append(buf, s...), nil
+	case "ipfs":
+		b, err := base58.Decode(v.(string))
+		if err != nil {
+			return nil, fmt.Errorf("invalid ipfs hash '%s'", v)
+		}
+		buf = appendUvarint(buf, uint64(len(b)))
+		return append(buf, b...), nil
+	case "bytes":
+		b := v.([]byte)
+		buf = appendUvarint(buf, uint64(len(b)))
+		return append(buf, b...), nil
+	}
+	return nil, fmt.Errorf("unsupported type '%s'", typ)
+}
+
+// Decoding
+
+type reader struct {
+	data []byte
+	pos  int
+}
+
+func (r *reader) next(n int) ([]byte, error) {
+	if n < 0 || len(r.data)-r.pos < n {
+		return nil, ErrUnexpectedEnd
+	}
+	b := r.data[r.pos : r.pos+n]
+	r.pos += n
+	return b, nil
+}
+
+func (r *reader) uvarint() (uint64, error) {
+	n, size := binary.Uvarint(r.data[r.pos:])
+	if size == 0 {
+		return 0, ErrUnexpectedEnd
+	}
+	if size < 0 {
+		return 0, errors.New("serialization: varint overflows 64 bits")
+	}
+	r.pos += size
+	return n, nil
+}
+
+func (r *reader) bytes() ([]byte, error) {
+	n, err := r.uvarint()
+	if err != nil {
+		return nil, err
+	}
+	if n > uint64(len(r.data)) {
+		return nil, ErrUnexpectedEnd
+	}
+	return r.next(int(n))
+}
+
+func intBits(typ string) int {
+	switch typ {
+	case "int8", "uint8":
+		return 8
+	case "int16", "uint16":
+		return 16
+	case "int32", "uint32":
+		return 32
+	}
+	return 64
+}
+
+func (r *reader) value(typ string) (interface{}, error) {
+	if strings.HasSuffix(typ, "[]") {
+		return r.array(strings.TrimSuffix(typ, "[]"))
+	}
+
+	switch typ {
+	case "int8", "int16", "int32", "int64":
+		u, err := r.uvarint()
+		if err != nil {
+			return nil, err
+		}
+		n := zigzagDecode(u)
+		bits := intBits(typ)
+		if bits < 64 && (n < -(1<<(bits-1)) || n >= 1<<(bits-1)) {
+			return nil, fmt.Errorf("value %d overflows %s", n, typ)
+		}
+		return n, nil
+	case "uint8", "uint16", "uint32", "uint64":
+		n, err := r.uvarint()
+		if err != nil {
+			return nil, err
+		}
+		if bits := intBits(typ); bits < 64 && n >= 1<<bits {
+			return nil, fmt.Errorf("value %d overflows %s", n, typ)
+		}
+		return n, nil
+	case "fixed8", "fixed16", "fixed32", "fixed64":
+		b, err := r.next(fixedSize(typ))
+		if err != nil {
+			return nil, err
+		}
+		buf := make([]byte, 8)
+		copy(buf, b)
+		return binary.LittleEndian.Uint64(buf), nil
+	case "float":
+		b, err := r.next(4)
+		if err != nil {
+			return nil, err
+		}
+		return float64(math.Float32frombits(binary.LittleEndian.Uint32(b))), nil
+	case "double":
+		b, err := r.next(8)
+		if err != nil {
+			return nil, err
+		}
+		return math.Float64frombits(binary.LittleEndian.Uint64(b)), nil
+	case "bool":
+		b, err := r.next(1)
+		if err != nil {
+			return nil, err
+		}
+		return b[0] == 1, nil
+	case "string", "image":
+		b, err := r.bytes()
+		if err != nil {
+			return nil, err
+		}
+		return string(b), nil
+	case "ipfs":
+		b, err := r.bytes()
+		if err != nil {
+			return nil, err
+		}
+		return base58.Encode(b), nil
+	case "bytes":
+		b, err := r.bytes()
+		if err != nil {
+			return nil, err
+		}
+		return append([]byte{}, b...), nil
+	}
+	return nil, fmt.Errorf("unsupported type '%s'", typ)
+}
+
+func (r *reader) array(elem string) (interface{}, error) {
+	n, err := r.uvarint()
+	if err != nil {
+		return nil, err
+	}
+
+	// Every element takes at least one byte.
+	if n > uint64(len(r.data)-r.pos) {
+		return nil, ErrUnexpectedEnd
+	}
+
+	values := []interface{}{}
+	for i := uint64(0); i < n; i++ {
+		v, err := r.value(elem)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, v)
+	}
+
+	switch elem {
+	case "int8", "int16", "int32", "int64":
+		return typedSlice[int64](values), nil
+	case "uint8", "uint16", "uint32", "uint64", "fixed8", "fixed16", "fixed32", "fixed64":
+		return typedSlice[uint64](values), nil
+	case "float", "double":
+		return typedSlice[float64](values), nil
+	case "string", "image", "ipfs":
+		return typedSlice[string](values), nil
+	case "bool":
+		return typedSlice[bool](values), nil
+	}
+	return values, nil
+}
+
+func typedSlice[T any](values []interface{}) []T {
+	s := make([]T, len(values))
+	for i, v := range values {
+		s[i] = v.(T)
+	}
+	return s
+}
@@ -0,0 +1,48 @@
+// Regenerates the hex of the vectors in vectors.json with the atomicassets-js
+// reference serializer (the version is pinned in package.json).
+//
+//	cd serialization/testdata
+//	npm install
+//	node generate.js          rewrites the hex of every vector
+//	node generate.js --check  fails if a vector does not match
+//
+// The name, format and data of the vectors are written by hand, only the hex
+// is generated. serialize() drops the terminating zero byte the same way the
+// contract stores the data.
+
+const fs = require('fs');
+const path = require('path');
+const { ObjectSchema, serialize } = require('atomicassets');
+
+const file = path.join(__dirname, 'vectors.json');
+const check = process.argv.includes('--check');
+const vectors = JSON.parse(fs.readFileSync(file, 'utf8'));
+
+// bytes attributes are stored as arrays of numbers in the json file,
+// atomicassets-js expects an Uint8Array.
+function input(v) {
+    const data = { ...v.data };
+    for (const f of v.format) {
+        if (f.type === 'bytes' && Array.isArray(data[f.name])) {
+            data[f.name] = Uint8Array.from(data[f.name]);
+        }
+    }
+    return data;
+}
+
+let mismatches = 0;
+for (const v of vectors) {
+    const hex = Buffer.from(serialize(input(v), ObjectSchema(v.format))).toString('hex');
+    if (hex !== v.hex) {
+        console.log(`${v.name}: ${v.hex} -> ${hex}`);
+        mismatches++;
+    }
+    v.hex = hex;
+}
+
+if (check) {
+    process.exit(mismatches > 0 ? 1 : 0);
+}
+
+fs.writeFileSync(file, JSON.stringify(vectors, null, 2) + '\n');
+console.log(`${vectors.length} vectors written, ${mismatches} changed`);
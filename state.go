@@ -0,0 +1,59 @@
+package atomicasset
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+// Helpers shared by the resource state types.
+//
+// Most states are stored as strings holding the numeric code so that
+// request parameters can use the empty string as "not set".
+
+// stateString returns the name of code or "<kind>(<code>)" if it is not known.
+func stateString(kind string, code string, names []string) string {
+	if n, err := strconv.Atoi(code); err == nil && n >= 0 && n < len(names) {
+		return names[n]
+	}
+	return fmt.Sprintf("%s(%s)", kind, code)
+}
+
+// validateState returns an error if code is not a known state.
+func validateState(kind string, code string, names []string) error {
+	if n, err := strconv.Atoi(code); err == nil && n >= 0 && n < len(names) {
+		return nil
+	}
+	return fmt.Errorf("invalid %s '%s'", kind, code)
+}
+
+// marshalState encodes code as a json number, the empty state is encoded as null.
+func marshalState(code string) ([]byte, error) {
+	if len(code) < 1 {
+		return []byte("null"), nil
+	}
+
+	n, err := strconv.ParseInt(code, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("state '%s' is not a numeric code", code)
+	}
+	return json.Marshal(n)
+}
+
+// unmarshalState decodes a state code from a json number or numeric string.
+func unmarshalState(b []byte) (string, error) {
+	if string(b) == "null" {
+		return "", nil
+	}
+
+	var s string
+	if json.Unmarshal(b, &s) == nil {
+		b = []byte(s)
+	}
+
+	var n int64
+	if err := json.Unmarshal(b, &n); err != nil {
+		return "", err
+	}
+	return strconv.FormatInt(n, 10), nil
+}
@@ -0,0 +1,159 @@
+package atomicasset
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/eosswedenorg-go/unixtime"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestState_String(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    interface{ String() string }
+		expected string
+	}{
+		{"SaleListed", SalesStateListed, "listed"},
+		{"SaleSold", SalesStateSold, "sold"},
+		{"SaleUnknown", SalesState("9"), "SalesState(9)"},
+		{"AuctionListed", AuctionStateListed, "active"},
+		{"AuctionSold", AuctionStateSold, "sold"},
+		{"BuyOfferDeclined", BuyOfferStateDeclined, "declined"},
+		{"BuyOfferAccepted", BuyOfferStateAccepted, "accepted"},
+		{"TemplateBuyOfferSold", TemplateBuyOfferStateSold, "sold"},
+		{"OfferUnknown", OfferStateUnknown, "unknown"},
+		{"OfferCanceled", OfferStateCanceled, "canceled"},
+		{"OfferEmpty", OfferState(""), "OfferState()"},
+		{"LinkCreated", LinkStateCreated, "created"},
+		{"LinkClaimed", LinkStateClaimed, "claimed"},
+		{"LinkUnknown", LinkState(-1), "LinkState(-1)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.input.String())
+		})
+	}
+}
+
+func TestState_Validate(t *testing.T) {
+	assert.NoError(t, SalesStateInvalid.Validate())
+	assert.EqualError(t, SalesState("5").Validate(), "invalid sale state '5'")
+	assert.NoError(t, AuctionStateWaiting.Validate())
+	assert.EqualError(t, AuctionState("").Validate(), "invalid auction state ''")
+	assert.NoError(t, BuyOfferStateInvalid.Validate())
+	assert.EqualError(t, BuyOfferState("x").Validate(), "invalid buyoffer state 'x'")
+	assert.NoError(t, TemplateBuyOfferStateCanceled.Validate())
+	assert.EqualError(t, TemplateBuyOfferState("3").Validate(), "invalid template buyoffer state '3'")
+	assert.NoError(t, OfferStateCanceled.Validate())
+	assert.EqualError(t, OfferState("6").Validate(), "invalid offer state '6'")
+	assert.NoError(t, LinkStateClaimed.Validate())
+	assert.EqualError(t, LinkState(4).Validate(), "invalid link state '4'")
+}
+
+func TestState_JSON(t *testing.T) {
+	type states struct {
+		Sale     SalesState    `json:"sale"`
+		Auction  AuctionState  `json:"auction"`
+		BuyOffer BuyOfferState `json:"buyoffer"`
+		Offer    OfferState    `json:"offer"`
+		Link     LinkState     `json:"link"`
+	}
+
+	input := states{
+		Sale:     SalesStateSold,
+		Auction:  AuctionStateListed,
+		BuyOffer: BuyOfferStateDeclined,
+		Offer:    OfferStateAccepted,
+		Link:     LinkStateCanceled,
+	}
+
+	b, err := json.Marshal(input)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"sale":3,"auction":1,"buyoffer":1,"offer":3,"link":2}`, string(b))
+
+	var output states
+	require.NoError(t, json.Unmarshal(b, &output))
+	assert.Equal(t, input, output)
+
+	// Numeric strings are accepted as well.
+	require.NoError(t, json.Unmarshal([]byte(`{"sale":"1","offer":"5","link":"3"}`), &output))
+	assert.Equal(t, SalesStateListed, output.Sale)
+	assert.Equal(t, OfferStateCanceled, output.Offer)
+	assert.Equal(t, LinkStateClaimed, output.Link)
+
+	// The empty state is encoded as null.
+	b, err = json.Marshal(states{})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"sale":null,"auction":null,"buyoffer":null,"offer":null,"link":0}`, string(b))
+
+	assert.Error(t, json.Unmarshal([]byte(`{"sale":"listed"}`), &output))
+}
+
+func TestAuction_States(t *testing.T) {
+	end := time.Date(2022, time.January, 1, 12, 0, 0, 0, time.UTC)
+	before := end.Add(-time.Minute)
+	after := end.Add(time.Minute)
+
+	tests := []struct {
+		name      string
+		auction   Auction
+		now       time.Time
+		active    bool
+		ended     bool
+		unclaimed bool
+		claimed   bool
+	}{
+		{
+			name:    "Active",
+			auction: Auction{State: AuctionStateListed, EndTime: unixtime.Time(end.UnixMilli())},
+			now:     before,
+			active:  true,
+		},
+		{
+			name:    "EndedWithoutBids",
+			auction: Auction{State: AuctionStateListed, EndTime: unixtime.Time(end.UnixMilli())},
+			now:     after,
+			ended:   true,
+		},
+		{
+			name:      "EndedUnclaimed",
+			auction:   Auction{State: AuctionStateListed, Buyer: "buyer", EndTime: unixtime.Time(end.UnixMilli())},
+			now:       end,
+			ended:     true,
+			unclaimed: true,
+		},
+		{
+			name:      "SoldClaimedBySeller",
+			auction:   Auction{State: AuctionStateSold, Buyer: "buyer", ClaimedBySeller: true, EndTime: unixtime.Time(end.UnixMilli())},
+			now:       after,
+			ended:     true,
+			unclaimed: true,
+		},
+		{
+			name:    "Claimed",
+			auction: Auction{State: AuctionStateSold, Buyer: "buyer", ClaimedByBuyer: true, ClaimedBySeller: true, EndTime: unixtime.Time(end.UnixMilli())},
+			now:     after,
+			ended:   true,
+			claimed: true,
+		},
+		{
+			name:    "Canceled",
+			auction: Auction{State: AuctionStateCanceled, EndTime: unixtime.Time(end.UnixMilli())},
+			now:     before,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.active, tt.auction.IsActive(tt.now))
+			assert.Equal(t, tt.ended, tt.auction.IsEnded(tt.now))
+			assert.Equal(t, tt.unclaimed, tt.auction.IsEndedUnclaimed(tt.now))
+			assert.Equal(t, tt.claimed, tt.auction.IsClaimed())
+		})
+	}
+}
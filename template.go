@@ -49,28 +49,28 @@ const (
 )
 
 type TemplateRequestParams struct {
-	SchemaName          string           `qs:"schema_name,omitempty"`
-	CollectionName      string           `qs:"collection_name,omitempty"`
-	CollectionBlacklist ReqList[string]  `qs:"collection_blacklist,omitempty"`
-	CollectionWhitelist ReqList[string]  `qs:"collection_whitelist,omitempty"`
-	IssuedSypply        int              `qs:"issued_supply,omitempty"`
-	MinIssuedSupply     int              `qs:"min_issued_supply,omitempty"`
-	MaxIssuedSupply     int              `qs:"max_issued_supply,omitempty"`
-	MaxSupply           int              `qs:"max_supply,omitempty"`
-	HasAssets           bool             `qs:"has_assets,omitempty"`
-	IsBurnable          bool             `qs:"is_burnable,omitempty"`
-	IsTransferable      bool             `qs:"is_transferable,omitempty"`
-	AuthorizedAccount   string           `qs:"authorized_account,omitempty"`
-	Match               string           `qs:"match,omitempty"`
-	IDs                 ReqList[int]     `qs:"ids,omitempty"`
-	LowerBound          string           `qs:"lower_bound,omitempty"`
-	UpperBound          string           `qs:"upper_bound,omitempty"`
-	Before              int              `qs:"before,omitempty"`
-	After               int              `qs:"after,omitempty"`
-	Page                int              `qs:"page,omitempty"`
-	Limit               int              `qs:"limit,omitempty"`
-	Order               SortOrder        `qs:"order,omitempty"`
-	Sort                SchemaSortColumn `qs:"sort,omitempty"`
+	SchemaName          string             `qs:"schema_name,omitempty"`
+	CollectionName      string             `qs:"collection_name,omitempty"`
+	CollectionBlacklist ReqList[string]    `qs:"collection_blacklist,omitempty"`
+	CollectionWhitelist ReqList[string]    `qs:"collection_whitelist,omitempty"`
+	IssuedSypply        int                `qs:"issued_supply,omitempty"`
+	MinIssuedSupply     int                `qs:"min_issued_supply,omitempty"`
+	MaxIssuedSupply     int                `qs:"max_issued_supply,omitempty"`
+	MaxSupply           int                `qs:"max_supply,omitempty"`
+	HasAssets           bool               `qs:"has_assets,omitempty"`
+	IsBurnable          bool               `qs:"is_burnable,omitempty"`
+	IsTransferable      bool               `qs:"is_transferable,omitempty"`
+	AuthorizedAccount   string             `qs:"authorized_account,omitempty"`
+	Match               string             `qs:"match,omitempty"`
+	IDs                 ReqList[int]       `qs:"ids,omitempty"`
+	LowerBound          string             `qs:"lower_bound,omitempty"`
+	UpperBound          string             `qs:"upper_bound,omitempty"`
+	Before              int                `qs:"before,omitempty"`
+	After               int                `qs:"after,omitempty"`
+	Page                int                `qs:"page,omitempty"`
+	Limit               int                `qs:"limit,omitempty"`
+	Order               SortOrder          `qs:"order,omitempty"`
+	Sort                TemplateSortColumn `qs:"sort,omitempty"`
 }
 
 // Responses
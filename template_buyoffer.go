@@ -0,0 +1,148 @@
+package atomicasset
+
+import (
+	"fmt"
+
+	"github.com/eosswedenorg-go/unixtime"
+)
+
+// Types
+
+// TemplateBuyOfferState is stored as a string for the same reason as SalesState.
+type TemplateBuyOfferState string
+
+const (
+	TemplateBuyOfferStateListed   = TemplateBuyOfferState("0")
+	TemplateBuyOfferStateCanceled = TemplateBuyOfferState("1")
+	TemplateBuyOfferStateSold     = TemplateBuyOfferState("2")
+)
+
+var templateBuyOfferStateNames = []string{"listed", "canceled", "sold"}
+
+// String returns the name of the state.
+func (s TemplateBuyOfferState) String() string {
+	return stateString("TemplateBuyOfferState", string(s), templateBuyOfferStateNames)
+}
+
+// Validate returns an error if s is not a known template buyoffer state.
+func (s TemplateBuyOfferState) Validate() error {
+	return validateState("template buyoffer state", string(s), templateBuyOfferStateNames)
+}
+
+func (s TemplateBuyOfferState) MarshalJSON() ([]byte, error) {
+	return marshalState(string(s))
+}
+
+func (s *TemplateBuyOfferState) UnmarshalJSON(b []byte) error {
+	v, err := unmarshalState(b)
+	if err == nil {
+		*s = TemplateBuyOfferState(v)
+	}
+	return err
+}
+
+// TemplateBuyOffer is an offer to buy any asset of a template.
+// Seller and Assets are set once the offer is sold.
+type TemplateBuyOffer struct {
+	ID               string                `json:"buyoffer_id"`
+	MarketContract   string                `json:"market_contract"`
+	AssetsContract   string                `json:"assets_contract"`
+	Seller           string                `json:"seller"`
+	Buyer            string                `json:"buyer"`
+	Price            Token                 `json:"price"`
+	Assets           []Asset               `json:"assets"`
+	MakerMarketplace string                `json:"maker_marketplace,omitempty"`
+	TakerMarketplace string                `json:"taker_marketplace,omitempty"`
+	Collection       Collection            `json:"collection"`
+	Template         Template              `json:"template"`
+	State            TemplateBuyOfferState `json:"state"`
+
+	UpdatedAtBlock string        `json:"updated_at_block"`
+	UpdatedAtTime  unixtime.Time `json:"updated_at_time"`
+
+	CreatedAtBlock string        `json:"created_at_block"`
+	CreatedAtTime  unixtime.Time `json:"created_at_time"`
+}
+
+// Request Parameters
+
+type TemplateBuyOfferSortColumn string
+
+const (
+	TemplateBuyOfferSortCreated = TemplateBuyOfferSortColumn("created")
+	TemplateBuyOfferSortUpdated = TemplateBuyOfferSortColumn("updated")
+	TemplateBuyOfferSortID      = TemplateBuyOfferSortColumn("buyoffer_id")
+	TemplateBuyOfferSortPrice   = TemplateBuyOfferSortColumn("price")
+)
+
+type TemplateBuyOffersRequestParams struct {
+	State               TemplateBuyOfferState      `qs:"state,omitempty"`
+	MaxAssets           int                        `qs:"max_assets,omitempty"`
+	MinAssets           int                        `qs:"min_assets,omitempty"`
+	ShowSellerContract  string                     `qs:"show_seller_contract,omitempty"`
+	ContractBlacklist   ReqList[string]            `qs:"contract_blacklist,omitempty"`
+	ContractWhitelist   ReqList[string]            `qs:"contract_whitelist,omitempty"`
+	SellerBlacklist     ReqList[string]            `qs:"seller_blacklist,omitempty"`
+	BuyerBlacklist      ReqList[string]            `qs:"buyer_blacklist,omitempty"`
+	Marketplace         ReqList[string]            `qs:"marketplace,omitempty"`
+	MakerMarketplace    ReqList[string]            `qs:"maker_marketplace,omitempty"`
+	TakerMarketplace    ReqList[string]            `qs:"taker_marketplace,omitempty"`
+	Symbol              string                     `qs:"symbol,omitempty"`
+	Account             string                     `qs:"account,omitempty"`
+	Seller              ReqList[string]            `qs:"seller,omitempty"`
+	Buyer               ReqList[string]            `qs:"buyer,omitempty"`
+	MinPrice            int                        `qs:"min_price,omitempty"`
+	MaxPrice            int                        `qs:"max_price,omitempty"`
+	CollectionName      string                     `qs:"collection_name,omitempty"`
+	CollectionBlacklist ReqList[string]            `qs:"collection_blacklist,omitempty"`
+	CollectionWhitelist ReqList[string]            `qs:"collection_whitelist,omitempty"`
+	SchemaName          string                     `qs:"schema_name,omitempty"`
+	TemplateID          int                        `qs:"template_id,omitempty"`
+	IDs                 ReqList[int]               `qs:"ids,omitempty"`
+	LowerBound          string                     `qs:"lower_bound,omitempty"`
+	UpperBound          string                     `qs:"upper_bound,omitempty"`
+	Before              int                        `qs:"before,omitempty"`
+	After               int                        `qs:"after,omitempty"`
+	Page                int                        `qs:"page,omitempty"`
+	Limit               int                        `qs:"limit,omitempty"`
+	Order               SortOrder                  `qs:"order,omitempty"`
+	Sort                TemplateBuyOfferSortColumn `qs:"sort,omitempty"`
+}
+
+// Responses
+
+type TemplateBuyOfferResponse struct {
+	APIResponse
+	Data TemplateBuyOffer
+}
+
+type TemplateBuyOffersResponse struct {
+	APIResponse
+	Data []TemplateBuyOffer
+}
+
+// API Client functions
+
+// GetTemplateBuyOffer fetches "/atomicmarket/v1/template_buyoffers/{buyoffer_id}" from API
+func (c *Client) GetTemplateBuyOffer(buyoffer_id int) (TemplateBuyOfferResponse, error) {
+	var resp TemplateBuyOfferResponse
+
+	r, err := c.fetch("GET", fmt.Sprintf("/atomicmarket/v1/template_buyoffers/%d", buyoffer_id), nil, &resp.APIResponse)
+	if err == nil {
+		// Parse json
+		err = r.Unmarshal(&resp)
+	}
+	return resp, err
+}
+
+// GetTemplateBuyOffers fetches "/atomicmarket/v1/template_buyoffers" from API
+func (c *Client) GetTemplateBuyOffers(params TemplateBuyOffersRequestParams) (TemplateBuyOffersResponse, error) {
+	var resp TemplateBuyOffersResponse
+
+	r, err := c.fetch("GET", "/atomicmarket/v1/template_buyoffers", params, &resp.APIResponse)
+	if err == nil {
+		// Parse json
+		err = r.Unmarshal(&resp)
+	}
+	return resp, err
+}
@@ -0,0 +1,55 @@
+package atomicasset
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/sonh/qs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequest_TemplateBuyOffersRequestParams(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    TemplateBuyOffersRequestParams
+		expected url.Values
+	}{
+		{"Empty", TemplateBuyOffersRequestParams{}, url.Values{}},
+
+		{"StateListed", TemplateBuyOffersRequestParams{State: TemplateBuyOfferStateListed}, url.Values{"state": []string{"0"}}},
+		{"StateCanceled", TemplateBuyOffersRequestParams{State: TemplateBuyOfferStateCanceled}, url.Values{"state": []string{"1"}}},
+		{"StateSold", TemplateBuyOffersRequestParams{State: TemplateBuyOfferStateSold}, url.Values{"state": []string{"2"}}},
+
+		{"MaxAssets", TemplateBuyOffersRequestParams{MaxAssets: 25}, url.Values{"max_assets": []string{"25"}}},
+		{"MinAssets", TemplateBuyOffersRequestParams{MinAssets: 30}, url.Values{"min_assets": []string{"30"}}},
+
+		{"Seller", TemplateBuyOffersRequestParams{Seller: []string{"alice", "bob"}}, url.Values{"seller": []string{"alice,bob"}}},
+		{"Buyer", TemplateBuyOffersRequestParams{Buyer: []string{"alice", "bob"}}, url.Values{"buyer": []string{"alice,bob"}}},
+
+		{"Symbol", TemplateBuyOffersRequestParams{Symbol: "WAX"}, url.Values{"symbol": []string{"WAX"}}},
+		{"MinPrice", TemplateBuyOffersRequestParams{MinPrice: 20}, url.Values{"min_price": []string{"20"}}},
+		{"MaxPrice", TemplateBuyOffersRequestParams{MaxPrice: 40}, url.Values{"max_price": []string{"40"}}},
+
+		{"CollectionName", TemplateBuyOffersRequestParams{CollectionName: "collection"}, url.Values{"collection_name": []string{"collection"}}},
+		{"TemplateID", TemplateBuyOffersRequestParams{TemplateID: 1337}, url.Values{"template_id": []string{"1337"}}},
+
+		{"IDs", TemplateBuyOffersRequestParams{IDs: []int{1, 2, 3}}, url.Values{"ids": []string{"1,2,3"}}},
+
+		{"Limit", TemplateBuyOffersRequestParams{Limit: 50}, url.Values{"limit": []string{"50"}}},
+		{"Order Desc", TemplateBuyOffersRequestParams{Order: SortDescending}, url.Values{"order": []string{"desc"}}},
+
+		{"Sort Created", TemplateBuyOffersRequestParams{Sort: TemplateBuyOfferSortCreated}, url.Values{"sort": []string{"created"}}},
+		{"Sort Update", TemplateBuyOffersRequestParams{Sort: TemplateBuyOfferSortUpdated}, url.Values{"sort": []string{"updated"}}},
+		{"Sort ID", TemplateBuyOffersRequestParams{Sort: TemplateBuyOfferSortID}, url.Values{"sort": []string{"buyoffer_id"}}},
+		{"Sort Price", TemplateBuyOffersRequestParams{Sort: TemplateBuyOfferSortPrice}, url.Values{"sort": []string{"price"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v, err := qs.NewEncoder().Values(tt.input)
+
+			assert.NoError(t, err)
+			assert.EqualValues(t, tt.expected, v)
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package atomicasset
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/eosswedenorg-go/unixtime"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const templateBuyOfferPayload = `{
+	"market_contract": "atomicmarket",
+	"assets_contract": "atomicassets",
+	"buyoffer_id": "20451",
+	"seller": null,
+	"buyer": "fu2hw.wam",
+	"price": {
+		"token_contract": "eosio.token",
+		"token_symbol": "WAX",
+		"token_precision": 8,
+		"amount": "500000000"
+	},
+	"assets": [],
+	"maker_marketplace": "market.nefty",
+	"taker_marketplace": "",
+	"collection": {"collection_name": "unlinked", "name": "Unlinked"},
+	"template": {"template_id": "443127", "max_supply": "0"},
+	"state": 0,
+	"updated_at_block": "219983297",
+	"updated_at_time": "1671457786000",
+	"created_at_block": "219983297",
+	"created_at_time": "1671457786000"
+}`
+
+var expectedTemplateBuyOffer = TemplateBuyOffer{
+	ID:             "20451",
+	MarketContract: "atomicmarket",
+	AssetsContract: "atomicassets",
+	Buyer:          "fu2hw.wam",
+	Price: Token{
+		Contract:  "eosio.token",
+		Symbol:    "WAX",
+		Precision: 8,
+		Amount:    "500000000",
+	},
+	Assets:           []Asset{},
+	MakerMarketplace: "market.nefty",
+	Collection:       Collection{CollectionName: "unlinked", Name: "Unlinked"},
+	Template:         Template{ID: "443127", MaxSupply: "0"},
+	State:            TemplateBuyOfferStateListed,
+	UpdatedAtBlock:   "219983297",
+	UpdatedAtTime:    unixtime.Time(1671457786000),
+	CreatedAtBlock:   "219983297",
+	CreatedAtTime:    unixtime.Time(1671457786000),
+}
+
+func TestGetTemplateBuyOffer(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "/atomicmarket/v1/template_buyoffers/20451", req.URL.Path)
+
+		res.Header().Add("Content-type", "application/json; charset=utf-8")
+		_, err := res.Write([]byte(`{"success": true, "data": ` + templateBuyOfferPayload + `, "query_time": 1623321161000}`))
+		assert.NoError(t, err)
+	}))
+	defer srv.Close()
+
+	res, err := New(srv.URL).GetTemplateBuyOffer(20451)
+
+	require.NoError(t, err)
+	assert.Equal(t, 200, res.HTTPStatusCode)
+	assert.True(t, res.Success)
+	assert.Equal(t, time.Date(2021, time.June, 10, 10, 32, 41, 0, time.UTC), res.QueryTime.Time())
+	assert.Equal(t, expectedTemplateBuyOffer, res.Data)
+}
+
+func TestGetTemplateBuyOffers(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "/atomicmarket/v1/template_buyoffers", req.URL.Path)
+		assert.Equal(t, "443127", req.URL.Query().Get("template_id"))
+
+		res.Header().Add("Content-type", "application/json; charset=utf-8")
+		_, err := res.Write([]byte(`{"success": true, "data": [` + templateBuyOfferPayload + `], "query_time": 1623321161000}`))
+		assert.NoError(t, err)
+	}))
+	defer srv.Close()
+
+	res, err := New(srv.URL).GetTemplateBuyOffers(TemplateBuyOffersRequestParams{TemplateID: 443127})
+
+	require.NoError(t, err)
+	assert.Equal(t, 200, res.HTTPStatusCode)
+	assert.True(t, res.Success)
+	assert.Equal(t, []TemplateBuyOffer{expectedTemplateBuyOffer}, res.Data)
+}
@@ -47,6 +47,9 @@ func TestRequest_TemplateRequestParams(t *testing.T) {
 		{"Limit", TemplateRequestParams{Limit: 50}, url.Values{"limit": []string{"50"}}},
 		{"Order", TemplateRequestParams{Order: SortDescending}, url.Values{"order": []string{"desc"}}},
 		{"Sort", TemplateRequestParams{Sort: "column"}, url.Values{"sort": []string{"column"}}},
+		{"Sort Default", TemplateRequestParams{Sort: TemplateSortDefault}, url.Values{}},
+		{"Sort Created", TemplateRequestParams{Sort: TemplateSortCreated}, url.Values{"sort": []string{"created"}}},
+		{"Sort Name", TemplateRequestParams{Sort: TemplateSortName}, url.Values{"sort": []string{"name"}}},
 	}
 
 	for _, tt := range tests {
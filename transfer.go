@@ -12,6 +12,7 @@ type Transfer struct {
 	Sender    string  `json:"sender_name"`
 	Recipient string  `json:"recipient_name"`
 	Memo      string  `json:"memo"`
+	TxID      string  `json:"txid"`
 	Assets    []Asset `json:"assets"`
 
 	CreatedAtBlock string        `json:"created_at_block"`
@@ -20,30 +21,34 @@ type Transfer struct {
 
 // Request Parameters
 
-type TransferRequestParams struct {
-	Account             ReqList[string] `qs:"account,omitempty"`
-	Sender              ReqList[string] `qs:"sender,omitempty"`
-	Recipient           ReqList[string] `qs:"recipient,omitempty"`
-	Memo                string          `qs:"memo,omitempty"`
-	MatchMemo           string          `qs:"match_memo,omitempty"`
-	AssetID             ReqList[int]    `qs:"asset_id,omitempty"`
-	TemplateID          ReqList[int]    `qs:"template_id,omitempty"`
-	SchemaName          ReqList[string] `qs:"schema_name,omitempty"`
-	CollectionName      ReqList[string] `qs:"collection_name,omitempty"`
-	CollectionWhitelist ReqList[string] `qs:"collection_whitelist,omitempty"`
-	CollectionBlacklist ReqList[string] `qs:"collection_blacklist,omitempty"`
-	HideContracts       bool            `qs:"hide_contracts,omitempty"`
-	IDs                 ReqList[int]    `qs:"ids,omitempty"`
-	LowerBound          string          `qs:"lower_bound,omitempty"`
-	UpperBound          string          `qs:"upper_bound,omitempty"`
-	Before              int             `qs:"before,omitempty"`
-	After               int             `qs:"after,omitempty"`
-	Page                int             `qs:"page,omitempty"`
-	Limit               int             `qs:"limit,omitempty"`
-	Order               SortOrder       `qs:"order,omitempty"`
+type TransferSortColumn string
+
+// TransferSortCreated is the only sort column of the transfers endpoint,
+// the api sorts it by transfer id.
+const TransferSortCreated = TransferSortColumn("created")
 
-	// Sort parameter exists but only has one value "created" that also is the default.
-	// So skip that for now until more values are added.
+type TransferRequestParams struct {
+	Account             ReqList[string]    `qs:"account,omitempty"`
+	Sender              ReqList[string]    `qs:"sender,omitempty"`
+	Recipient           ReqList[string]    `qs:"recipient,omitempty"`
+	Memo                string             `qs:"memo,omitempty"`
+	MatchMemo           string             `qs:"match_memo,omitempty"`
+	AssetID             ReqList[int]       `qs:"asset_id,omitempty"`
+	TemplateID          ReqList[int]       `qs:"template_id,omitempty"`
+	SchemaName          ReqList[string]    `qs:"schema_name,omitempty"`
+	CollectionName      ReqList[string]    `qs:"collection_name,omitempty"`
+	CollectionWhitelist ReqList[string]    `qs:"collection_whitelist,omitempty"`
+	CollectionBlacklist ReqList[string]    `qs:"collection_blacklist,omitempty"`
+	HideContracts       bool               `qs:"hide_contracts,omitempty"`
+	IDs                 ReqList[int]       `qs:"ids,omitempty"`
+	LowerBound          string             `qs:"lower_bound,omitempty"`
+	UpperBound          string             `qs:"upper_bound,omitempty"`
+	Before              int                `qs:"before,omitempty"`
+	After               int                `qs:"after,omitempty"`
+	Page                int                `qs:"page,omitempty"`
+	Limit               int                `qs:"limit,omitempty"`
+	Order               SortOrder          `qs:"order,omitempty"`
+	Sort                TransferSortColumn `qs:"sort,omitempty"`
 }
 
 // Responses
@@ -66,3 +71,15 @@ func (c *Client) GetTransfers(params TransferRequestParams) (TransfersResponse,
 	}
 	return resp, err
 }
+
+// GetTransfersCount fetches "/atomicassets/v1/transfers/_count" from API
+func (c *Client) GetTransfersCount(params TransferRequestParams) (CountResponse, error) {
+	var resp CountResponse
+
+	r, err := c.fetch("GET", "/atomicassets/v1/transfers/_count", params, &resp.APIResponse)
+	if err == nil {
+		// Parse json
+		err = r.Unmarshal(&resp)
+	}
+	return resp, err
+}
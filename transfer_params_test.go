@@ -43,6 +43,7 @@ func TestRequest_TransferRequestParams(t *testing.T) {
 
 		{"Limit", TransferRequestParams{Limit: 50}, url.Values{"limit": []string{"50"}}},
 		{"Order", TransferRequestParams{Order: SortDescending}, url.Values{"order": []string{"desc"}}},
+		{"Sort Created", TransferRequestParams{Sort: TransferSortCreated}, url.Values{"sort": []string{"created"}}},
 	}
 
 	for _, tt := range tests {
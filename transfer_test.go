@@ -149,7 +149,7 @@ func TestClient_GetTransfers(t *testing.T) {
 		Sender:    "starshipgame",
 		Recipient: "4awaxaccount",
 		Memo:      "Starship part replaced",
-		// TxID
+		TxID:      "b036bfecda89eae9d325dc7f895e4d248f0513b032973bd1cfba052a151118b6",
 		Assets: []Asset{
 			{
 				ID:             "1099801566489",
@@ -269,3 +269,21 @@ func TestClient_GetTransfers(t *testing.T) {
 	assert.Equal(t, time.Date(2020, time.May, 25, 19, 15, 3, 0, time.UTC), a.QueryTime.Time())
 	assert.Equal(t, []Transfer{expected}, a.Data)
 }
+
+func TestClient_GetTransfersCount(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "/atomicassets/v1/transfers/_count?sender=bob", req.URL.String())
+
+		res.Header().Add("Content-type", "application/json; charset=utf-8")
+		_, err := res.Write([]byte(`{"success": true, "data": "1337", "query_time": 1645374772067}`))
+		assert.NoError(t, err)
+	}))
+	defer srv.Close()
+
+	resp, err := New(srv.URL).GetTransfersCount(TransferRequestParams{Sender: ReqList[string]{"bob"}})
+	require.NoError(t, err)
+
+	n, err := resp.Data.Int64()
+	require.NoError(t, err)
+	assert.Equal(t, int64(1337), n)
+}